@@ -0,0 +1,14 @@
+package use_cases
+
+import "time"
+
+func exponentialBackoff(base, max time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
@@ -0,0 +1,19 @@
+package use_cases
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	base := 5 * time.Second
+	max := time.Minute
+
+	assert.Equal(t, 5*time.Second, exponentialBackoff(base, max, 1))
+	assert.Equal(t, 10*time.Second, exponentialBackoff(base, max, 2))
+	assert.Equal(t, 40*time.Second, exponentialBackoff(base, max, 4))
+	assert.Equal(t, time.Minute, exponentialBackoff(base, max, 5))
+	assert.Equal(t, time.Minute, exponentialBackoff(base, max, 50))
+}
@@ -0,0 +1,150 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+const subscriptionBillingLease = 5 * time.Minute
+
+type BillSubscriptionsUseCase struct {
+	txManager        domain.TransactionManager
+	subscriptionRepo domain.SubscriptionRepository
+	planRepo         domain.SubscriptionPlanRepository
+	createPayment    *CreatePaymentUseCase
+	dunning          []time.Duration
+	batchSize        int
+}
+
+func NewBillSubscriptionsUseCase(
+	txManager domain.TransactionManager,
+	subscriptionRepo domain.SubscriptionRepository,
+	planRepo domain.SubscriptionPlanRepository,
+	createPayment *CreatePaymentUseCase,
+	dunning []time.Duration,
+	batchSize int,
+) *BillSubscriptionsUseCase {
+	return &BillSubscriptionsUseCase{
+		txManager:        txManager,
+		subscriptionRepo: subscriptionRepo,
+		planRepo:         planRepo,
+		createPayment:    createPayment,
+		dunning:          dunning,
+		batchSize:        batchSize,
+	}
+}
+
+func (uc *BillSubscriptionsUseCase) Execute(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	due, err := uc.subscriptionRepo.ClaimDue(ctx, now, now.Add(subscriptionBillingLease), uc.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	billed := 0
+	for _, sub := range due {
+		plan, err := uc.planRepo.FindByID(ctx, sub.PlanID)
+		if err != nil {
+			return billed, err
+		}
+		if plan == nil {
+			continue
+		}
+
+		result, err := uc.createPayment.Execute(ctx, sub.BillingKey(), billingRequest(sub, plan))
+		if err := uc.settle(ctx, sub, plan, result, err); err != nil {
+			return billed, err
+		}
+		billed++
+	}
+	return billed, nil
+}
+
+func billingRequest(sub *domain.Subscription, plan *domain.SubscriptionPlan) domain.PaymentRequest {
+	return domain.PaymentRequest{
+		Amount:          plan.Money().Number(),
+		Currency:        plan.Currency,
+		CustomerID:      sub.CustomerID,
+		RideID:          fmt.Sprintf("subscription-%s-%d", sub.ID, sub.Period),
+		Description:     fmt.Sprintf("%s, period %d", plan.Name, sub.Period),
+		PaymentMethodID: sub.PaymentMethodID,
+		AllowDuplicate:  true,
+	}
+}
+
+func (uc *BillSubscriptionsUseCase) settle(
+	ctx context.Context,
+	claimed *domain.Subscription,
+	plan *domain.SubscriptionPlan,
+	result *CreatePaymentResult,
+	chargeErr error,
+) error {
+	return uc.txManager.RunInTransaction(ctx, func(txCtx context.Context) error {
+		sub, err := uc.subscriptionRepo.FindByIDForUpdate(txCtx, claimed.ID)
+		if err != nil || sub == nil {
+			return err
+		}
+
+		sub.LockedUntil = nil
+		sub.UpdatedAt = time.Now().UTC()
+		if sub.Period == claimed.Period && sub.FailedAttempts == claimed.FailedAttempts {
+			uc.apply(sub, plan, result, chargeErr)
+		}
+		return uc.subscriptionRepo.Update(txCtx, sub)
+	})
+}
+
+func (uc *BillSubscriptionsUseCase) apply(
+	sub *domain.Subscription,
+	plan *domain.SubscriptionPlan,
+	result *CreatePaymentResult,
+	chargeErr error,
+) {
+	if chargeErr != nil {
+		var appErr *apperrors.AppError
+		if !errors.As(chargeErr, &appErr) {
+			appErr = apperrors.ErrInternal()
+		}
+		sub.LastError = appErr.Message
+		if appErr.HTTPCode >= http.StatusInternalServerError || appErr.Code == apperrors.ErrPaymentProcessing().Code {
+			return
+		}
+		uc.fail(sub, appErr.Message)
+		return
+	}
+
+	payment := result.Payment
+	switch payment.Status {
+	case domain.PaymentStatusFailed, domain.PaymentStatusBlocked:
+		sub.LastPaymentID = payment.ID
+		reason := payment.FailReason
+		if reason == "" {
+			reason = "payment " + string(payment.Status)
+		}
+		uc.fail(sub, reason)
+	case domain.PaymentStatusPending, domain.PaymentStatusUnknown:
+		sub.LastPaymentID = payment.ID
+		sub.LastError = "payment " + string(payment.Status) + ", waiting for the processor"
+	default:
+		status := sub.Status
+		sub.Advance(plan, payment.ID)
+		if status == domain.SubscriptionPaused || status == domain.SubscriptionCanceled {
+			sub.Status = status
+			sub.NextBillingAt = nil
+		}
+	}
+}
+
+func (uc *BillSubscriptionsUseCase) fail(sub *domain.Subscription, reason string) {
+	if !sub.Live() {
+		sub.LastError = reason
+		return
+	}
+	sub.Fail(reason, uc.dunning, time.Now().UTC())
+}
@@ -0,0 +1,38 @@
+package use_cases
+
+import (
+	"context"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type CancelScheduledPaymentUseCase struct {
+	scheduledRepo domain.ScheduledPaymentRepository
+}
+
+func NewCancelScheduledPaymentUseCase(scheduledRepo domain.ScheduledPaymentRepository) *CancelScheduledPaymentUseCase {
+	return &CancelScheduledPaymentUseCase{
+		scheduledRepo: scheduledRepo,
+	}
+}
+
+func (uc *CancelScheduledPaymentUseCase) Execute(ctx context.Context, id string) (*domain.ScheduledPayment, error) {
+	canceled, err := uc.scheduledRepo.Cancel(ctx, id, time.Now())
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+
+	scheduled, err := uc.scheduledRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if scheduled == nil {
+		return nil, apperrors.ErrScheduledPaymentNotFound()
+	}
+	if !canceled && scheduled.Status != domain.ScheduledPaymentCanceled {
+		return nil, apperrors.ErrScheduledPaymentNotCancelable(string(scheduled.Status))
+	}
+	return scheduled, nil
+}
@@ -0,0 +1,54 @@
+package use_cases
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+func validateCardDetails(number string, expMonth, expYear int, cvv string, now time.Time) (domain.CardBrand, error) {
+	if !domain.IsCardNumberFormat(number) {
+		return "", apperrors.ErrInvalidCardNumber()
+	}
+	if !domain.LuhnValid(number) {
+		return "", apperrors.ErrInvalidCardChecksum()
+	}
+	brand := domain.DetectCardBrand(number)
+
+	if expMonth != 0 || expYear != 0 {
+		if _, err := validateCardExpiry(expMonth, expYear, now); err != nil {
+			return "", err
+		}
+	}
+	if err := validateCVV(cvv, brand); err != nil {
+		return "", err
+	}
+	return brand, nil
+}
+
+func validateCardExpiry(expMonth, expYear int, now time.Time) (int, error) {
+	year := expYear
+	if year > 0 && year < 100 {
+		year += 2000
+	}
+	if expMonth < 1 || expMonth > 12 || year < 2000 || year > 2099 {
+		return 0, apperrors.ErrInvalidCardExpiry()
+	}
+	if year < now.Year() || (year == now.Year() && expMonth < int(now.Month())) {
+		return 0, apperrors.ErrCardExpired()
+	}
+	return year, nil
+}
+
+func validateCVV(cvv string, brand domain.CardBrand) error {
+	if cvv == "" {
+		return nil
+	}
+	length := domain.CVVLength(brand)
+	if len(cvv) != length || strings.Trim(cvv, "0123456789") != "" {
+		return apperrors.ErrInvalidCVV(length)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package use_cases
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type RateLimitSubject struct {
+	APIKey     string
+	CustomerID string
+	IP         string
+}
+
+type RateLimitPolicy map[domain.RateLimitScope]domain.RateLimit
+
+type CheckRateLimitUseCase struct {
+	store  domain.RateLimitStore
+	policy RateLimitPolicy
+}
+
+func NewCheckRateLimitUseCase(store domain.RateLimitStore, policy RateLimitPolicy) *CheckRateLimitUseCase {
+	return &CheckRateLimitUseCase{
+		store:  store,
+		policy: policy,
+	}
+}
+
+func (uc *CheckRateLimitUseCase) Execute(ctx context.Context, subject RateLimitSubject) (*domain.RateLimitDecision, error) {
+	now := time.Now()
+	var tightest *domain.RateLimitDecision
+	taken := make(map[string]domain.RateLimit)
+
+	for _, scope := range []domain.RateLimitScope{domain.RateLimitScopeAPIKey, domain.RateLimitScopeCustomer, domain.RateLimitScopeIP} {
+		limit, ok := uc.policy[scope]
+		id := subject.identifier(scope)
+		if !ok || !limit.Enabled() || id == "" {
+			continue
+		}
+
+		key := string(scope) + ":" + id
+		decision, err := uc.store.Take(ctx, key, limit, now)
+		if err != nil {
+			log.Printf("rate limit store error for %s: %v", scope, err)
+			continue
+		}
+		if decision.Allowed {
+			taken[key] = limit
+		}
+		if tightest == nil || tighter(decision, *tightest) {
+			tightest = &decision
+		}
+	}
+
+	if tightest != nil && !tightest.Allowed {
+		for key, limit := range taken {
+			if err := uc.store.Release(ctx, key, limit); err != nil {
+				log.Printf("rate limit store error releasing %s: %v", key, err)
+			}
+		}
+		return tightest, apperrors.ErrRateLimited(int(math.Ceil(tightest.RetryAfter.Seconds())))
+	}
+	return tightest, nil
+}
+
+func (s RateLimitSubject) identifier(scope domain.RateLimitScope) string {
+	switch scope {
+	case domain.RateLimitScopeAPIKey:
+		if s.APIKey == "" {
+			return ""
+		}
+		return sha256Hex(s.APIKey)
+	case domain.RateLimitScopeCustomer:
+		return s.CustomerID
+	default:
+		return s.IP
+	}
+}
+
+func tighter(a, b domain.RateLimitDecision) bool {
+	if a.Allowed != b.Allowed {
+		return !a.Allowed
+	}
+	if !a.Allowed {
+		return a.RetryAfter > b.RetryAfter
+	}
+	return a.Remaining < b.Remaining
+}
@@ -9,16 +9,61 @@ import (
 	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
 	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm/repositories"
 	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/processor"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/ratelimit"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/receipt"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/vault"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/webhook"
 	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/utils/config"
 )
 
 type Container struct {
-	CreatePayment       *CreatePaymentUseCase
-	GetPayment          *GetPaymentUseCase
-	GetByIdempotencyKey *GetByIdempotencyKeyUseCase
+	CreatePayment           *CreatePaymentUseCase
+	GetPayment              *GetPaymentUseCase
+	GetByIdempotencyKey     *GetByIdempotencyKeyUseCase
+	ListPayments            *ListPaymentsUseCase
+	RegisterWebhookEndpoint *RegisterWebhookEndpointUseCase
+	ListWebhookEndpoints    *ListWebhookEndpointsUseCase
+	DeleteWebhookEndpoint   *DeleteWebhookEndpointUseCase
+	ListWebhookDeliveries   *ListWebhookDeliveriesUseCase
+	RedeliverEvent          *RedeliverEventUseCase
+	HandleProcessorEvent    *HandleProcessorEventUseCase
+	CreatePaymentMethod     *CreatePaymentMethodUseCase
+	ListPaymentMethods      *ListPaymentMethodsUseCase
+	DeletePaymentMethod     *DeletePaymentMethodUseCase
+	CreateCustomer          *CreateCustomerUseCase
+	GetCustomer             *GetCustomerUseCase
+	ListCustomers           *ListCustomersUseCase
+	ListCustomerPayments    *ListCustomerPaymentsUseCase
+	CreateTip               *CreateTipUseCase
+	RefundPayment           *RefundPaymentUseCase
+	GetLedgerBalances       *GetLedgerBalancesUseCase
+	ReconcileSettlement     *ReconcileSettlementUseCase
+	GetReconciliation       *GetReconciliationUseCase
+	GenerateSettlementFile  *GenerateSettlementFileUseCase
+	GetRiskAssessment       *GetRiskAssessmentUseCase
+	CheckRateLimit          *CheckRateLimitUseCase
+	CreatePaymentBatch      *CreatePaymentBatchUseCase
+	CreatePaymentJob        *CreatePaymentJobUseCase
+	GetPaymentJob           *GetPaymentJobUseCase
+	SchedulePayment         *SchedulePaymentUseCase
+	ListScheduledPayments   *ListScheduledPaymentsUseCase
+	GetScheduledPayment     *GetScheduledPaymentUseCase
+	CancelScheduledPayment  *CancelScheduledPaymentUseCase
+	CreatePlan              *CreatePlanUseCase
+	GetPlan                 *GetPlanUseCase
+	ListPlans               *ListPlansUseCase
+	CreateSubscription      *CreateSubscriptionUseCase
+	GetSubscription         *GetSubscriptionUseCase
+	ListSubscriptions       *ListSubscriptionsUseCase
+	TransitionSubscription  *TransitionSubscriptionUseCase
+	GetPaymentReceipt       *GetPaymentReceiptUseCase
 }
 
 func NewContainer(cfg *config.Config) (*Container, error) {
+	if err := cfg.CheckSecrets(); err != nil {
+		return nil, err
+	}
+
 	db, err := gormdb.NewConnection(cfg)
 	if err != nil {
 		return nil, err
@@ -28,36 +73,390 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 		return nil, err
 	}
 
+	switch cfg.CurrencySource {
+	case "database":
+		refreshCurrencies := NewRefreshCurrenciesUseCase(repositories.NewCurrencyRepo(db), domain.Currencies())
+		if _, err := refreshCurrencies.Execute(context.Background()); err != nil {
+			return nil, err
+		}
+		go startCurrencyRefreshLoop(refreshCurrencies, cfg.CurrencyRefreshInterval)
+	default:
+		if cfg.Currencies != "" {
+			definitions, err := domain.ParseCurrencyDefinitions(cfg.Currencies)
+			if err != nil {
+				return nil, err
+			}
+			domain.Currencies().Load(definitions)
+		}
+	}
+
 	idempotencyRepo := repositories.NewIdempotencyRepo(db)
 	paymentRepo := repositories.NewPaymentRepo(db)
-	paymentProcessor := processor.NewSimulator()
+	outboxRepo := repositories.NewOutboxRepo(db)
+	webhookEndpointRepo := repositories.NewWebhookEndpointRepo(db)
+	webhookDeliveryRepo := repositories.NewWebhookDeliveryRepo(db)
+	processorEventRepo := repositories.NewProcessorEventRepo(db)
+	paymentMethodRepo := repositories.NewPaymentMethodRepo(db)
+	customerRepo := repositories.NewCustomerRepo(db)
+	ledgerRepo := repositories.NewLedgerRepo(db)
+	reconciliationRepo := repositories.NewReconciliationRepo(db)
+	riskRepo := repositories.NewRiskAssessmentRepo(db)
+	paymentBatchRepo := repositories.NewPaymentBatchRepo(db)
+	paymentJobRepo := repositories.NewPaymentJobRepo(db)
+	scheduledPaymentRepo := repositories.NewScheduledPaymentRepo(db)
+	planRepo := repositories.NewSubscriptionPlanRepo(db)
+	subscriptionRepo := repositories.NewSubscriptionRepo(db)
+	refundRepo := repositories.NewRefundRepo(db)
+	ledger := NewLedgerRecorder(ledgerRepo, int64(cfg.LedgerProcessorFeeBps))
+	cardVault, err := vault.NewAESGCMVault(cfg.PaymentMethodEncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	riskEngine, err := newRiskEngine(cfg, riskRepo, customerRepo, cardVault)
+	if err != nil {
+		return nil, err
+	}
+	dunning, err := domain.ParseDunningSchedule(cfg.SubscriptionDunningSchedule)
+	if err != nil {
+		return nil, err
+	}
+	receiptRenderer, err := receipt.NewTemplateRenderer(cfg.ReceiptTemplateDir)
+	if err != nil {
+		return nil, err
+	}
+	paymentProcessor, err := newPaymentProcessor(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	txManager := gormdb.NewTransactionManager(db)
 
-	createPayment := NewCreatePaymentUseCase(txManager, idempotencyRepo, paymentRepo, paymentProcessor, cfg.IdempotencyKeyTTL)
+	createPaymentOpts := []CreatePaymentOption{
+		WithOutbox(outboxRepo),
+		WithDuplicateRideGuard(DuplicateRidePolicy(cfg.DuplicateRidePolicy), cfg.DuplicateRideWindow),
+		WithPaymentMethods(paymentMethodRepo, cardVault),
+		WithLedger(ledger),
+		WithRiskEngine(riskEngine),
+	}
+	if cfg.RequireExistingCustomer {
+		createPaymentOpts = append(createPaymentOpts, WithCustomerValidation(customerRepo))
+	}
+	createPayment := NewCreatePaymentUseCase(
+		txManager, idempotencyRepo, paymentRepo, paymentProcessor, cfg.IdempotencyKeyTTL,
+		createPaymentOpts...,
+	)
 	getPayment := NewGetPaymentUseCase(paymentRepo)
 	getByIdempotencyKey := NewGetByIdempotencyKeyUseCase(idempotencyRepo)
+	resolvePending := NewResolvePendingPaymentsUseCase(
+		txManager, idempotencyRepo, paymentRepo, outboxRepo, paymentProcessor,
+		cfg.PendingBackoffBase, cfg.PendingBackoffMax, cfg.PendingMaxAge, cfg.PendingBatchSize,
+		WithStatusLedger(ledger),
+	)
+	resolveUnknown := NewResolveUnknownPaymentsUseCase(
+		txManager, idempotencyRepo, paymentRepo, outboxRepo, paymentProcessor,
+		cfg.UnknownBackoffBase, cfg.UnknownBackoffMax, cfg.UnknownNotChargedGrace, cfg.UnknownMaxAge, cfg.UnknownBatchSize,
+		WithStatusLedger(ledger),
+	)
+	dispatchWebhooks := NewDispatchWebhooksUseCase(
+		txManager, outboxRepo, webhookEndpointRepo, webhookDeliveryRepo,
+		webhook.NewHTTPSender(cfg.WebhookTimeout),
+		cfg.WebhookMaxAttempts, cfg.WebhookBackoffBase, cfg.WebhookBackoffMax, cfg.WebhookBatchSize,
+	)
+
+	var rateLimitStore domain.RateLimitStore
+	switch cfg.RateLimitStore {
+	case "memory":
+		rateLimitStore = ratelimit.NewMemoryStore()
+	default:
+		rateLimitStore = repositories.NewRateLimitRepo(db)
+	}
 
-	go startCleanupLoop(idempotencyRepo, cfg.CleanupInterval)
+	go startCleanupLoop(idempotencyRepo, paymentBatchRepo, cfg.CleanupInterval)
+	go startRateLimitCleanupLoop(rateLimitStore, cfg.RateLimitPeriod, cfg.CleanupInterval)
+	go startPendingResolverLoop(resolvePending, cfg.PendingCheckInterval)
+	go startUnknownResolverLoop(resolveUnknown, cfg.UnknownCheckInterval)
+	go startWebhookDispatchLoop(dispatchWebhooks, cfg.WebhookDispatchInterval)
+	go startPaymentJobLoop(
+		NewProcessPaymentJobsUseCase(paymentJobRepo, createPayment, cfg.JobChunkSize, cfg.JobConcurrency),
+		cfg.JobWorkerInterval,
+	)
+	go startSchedulerLoop(
+		NewExecuteScheduledPaymentsUseCase(scheduledPaymentRepo, createPayment, cfg.SchedulerBatchSize),
+		cfg.SchedulerInterval,
+	)
+	go startSubscriptionBillingLoop(
+		NewBillSubscriptionsUseCase(txManager, subscriptionRepo, planRepo, createPayment, dunning, cfg.SubscriptionBatchSize),
+		cfg.SubscriptionBillingInterval,
+	)
 
 	return &Container{
-		CreatePayment:       createPayment,
-		GetPayment:          getPayment,
-		GetByIdempotencyKey: getByIdempotencyKey,
+		CreatePayment:           createPayment,
+		GetPayment:              getPayment,
+		GetByIdempotencyKey:     getByIdempotencyKey,
+		ListPayments:            NewListPaymentsUseCase(paymentRepo),
+		RegisterWebhookEndpoint: NewRegisterWebhookEndpointUseCase(webhookEndpointRepo),
+		ListWebhookEndpoints:    NewListWebhookEndpointsUseCase(webhookEndpointRepo),
+		DeleteWebhookEndpoint:   NewDeleteWebhookEndpointUseCase(webhookEndpointRepo),
+		ListWebhookDeliveries:   NewListWebhookDeliveriesUseCase(webhookDeliveryRepo),
+		RedeliverEvent:          NewRedeliverEventUseCase(txManager, outboxRepo, webhookDeliveryRepo),
+		HandleProcessorEvent: NewHandleProcessorEventUseCase(
+			txManager, processorEventRepo, idempotencyRepo, paymentRepo, outboxRepo,
+			cfg.ProcessorWebhookSecret, cfg.ProcessorEventTolerance,
+			WithStatusLedger(ledger),
+		),
+		CreatePaymentMethod:  NewCreatePaymentMethodUseCase(paymentMethodRepo, cardVault),
+		ListPaymentMethods:   NewListPaymentMethodsUseCase(paymentMethodRepo),
+		DeletePaymentMethod:  NewDeletePaymentMethodUseCase(paymentMethodRepo),
+		CreateCustomer:       NewCreateCustomerUseCase(customerRepo),
+		GetCustomer:          NewGetCustomerUseCase(customerRepo),
+		ListCustomers:        NewListCustomersUseCase(customerRepo),
+		ListCustomerPayments: NewListCustomerPaymentsUseCase(customerRepo, paymentRepo),
+		CreateTip:            NewCreateTipUseCase(paymentRepo, createPayment, cfg.TipMaxRatio),
+		RefundPayment: NewRefundPaymentUseCase(
+			txManager, paymentRepo, refundRepo, idempotencyRepo, paymentProcessor, ledger,
+		),
+		GetLedgerBalances:      NewGetLedgerBalancesUseCase(ledgerRepo),
+		ReconcileSettlement:    NewReconcileSettlementUseCase(paymentRepo, reconciliationRepo),
+		GetReconciliation:      NewGetReconciliationUseCase(reconciliationRepo),
+		GenerateSettlementFile: NewGenerateSettlementFileUseCase(paymentProcessor),
+		GetRiskAssessment:      NewGetRiskAssessmentUseCase(riskRepo),
+		CheckRateLimit: NewCheckRateLimitUseCase(rateLimitStore, RateLimitPolicy{
+			domain.RateLimitScopeAPIKey:   {Limit: cfg.RateLimitPerAPIKey, Period: cfg.RateLimitPeriod},
+			domain.RateLimitScopeCustomer: {Limit: cfg.RateLimitPerCustomer, Period: cfg.RateLimitPeriod},
+			domain.RateLimitScopeIP:       {Limit: cfg.RateLimitPerIP, Period: cfg.RateLimitPeriod},
+		}),
+		CreatePaymentBatch: NewCreatePaymentBatchUseCase(
+			paymentBatchRepo, createPayment, cfg.BatchMaxItems, cfg.BatchConcurrency, cfg.IdempotencyKeyTTL,
+		),
+		CreatePaymentJob: NewCreatePaymentJobUseCase(paymentJobRepo, cfg.JobMaxRows),
+		GetPaymentJob:    NewGetPaymentJobUseCase(paymentJobRepo),
+		SchedulePayment: NewSchedulePaymentUseCase(
+			scheduledPaymentRepo, idempotencyRepo, cfg.ScheduleMaxAhead,
+		),
+		ListScheduledPayments:  NewListScheduledPaymentsUseCase(scheduledPaymentRepo),
+		GetScheduledPayment:    NewGetScheduledPaymentUseCase(scheduledPaymentRepo),
+		CancelScheduledPayment: NewCancelScheduledPaymentUseCase(scheduledPaymentRepo),
+		CreatePlan:             NewCreatePlanUseCase(planRepo),
+		GetPlan:                NewGetPlanUseCase(planRepo),
+		ListPlans:              NewListPlansUseCase(planRepo),
+		CreateSubscription:     NewCreateSubscriptionUseCase(subscriptionRepo, planRepo, paymentMethodRepo),
+		GetSubscription:        NewGetSubscriptionUseCase(subscriptionRepo),
+		ListSubscriptions:      NewListSubscriptionsUseCase(subscriptionRepo),
+		TransitionSubscription: NewTransitionSubscriptionUseCase(txManager, subscriptionRepo, planRepo),
+		GetPaymentReceipt:      NewGetPaymentReceiptUseCase(paymentRepo, ledgerRepo, receiptRenderer),
 	}, nil
 }
 
-func startCleanupLoop(repo domain.IdempotencyRepository, interval time.Duration) {
+func newPaymentProcessor(cfg *config.Config) (domain.PaymentProcessor, error) {
+	names, err := domain.ParseProcessorNames(cfg.Processors)
+	if err != nil {
+		return nil, err
+	}
+	routes, err := domain.ParseProcessorRoutes(cfg.ProcessorRoutes)
+	if err != nil {
+		return nil, err
+	}
+	weights, err := domain.ParseProcessorWeights(cfg.ProcessorWeights)
+	if err != nil {
+		return nil, err
+	}
+	unavailableRates, err := processor.ParseUnavailableRates(cfg.SimulatorUnavailableRates)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := processor.NewRegistry()
+	for _, name := range names {
+		simulator := processor.NewSimulator(
+			processor.WithPendingSettleAfter(cfg.SimulatorPendingSettle),
+			processor.WithCallbacks(cfg.SimulatorCallbackURL, cfg.ProcessorWebhookSecret, cfg.SimulatorCallbackRepeats),
+			processor.WithUnavailableRate(unavailableRates[name]),
+		)
+		breaker := processor.NewBreaker(name, simulator,
+			processor.WithCallTimeout(cfg.ProcessorTimeout),
+			processor.WithFailureThreshold(cfg.ProcessorBreakerFailures),
+			processor.WithOpenDuration(cfg.ProcessorBreakerOpenFor),
+			processor.WithHalfOpenProbes(cfg.ProcessorBreakerHalfOpenProbes),
+		)
+		if err := registry.Register(name, breaker); err != nil {
+			return nil, err
+		}
+	}
+	return processor.NewRouter(registry, routes, weights)
+}
+
+func newRiskEngine(
+	cfg *config.Config,
+	riskRepo domain.RiskAssessmentRepository,
+	customerRepo domain.CustomerRepository,
+	cardVault domain.CardVault,
+) (*RiskEngine, error) {
+	var rules []domain.RiskRule
+	if cfg.RiskVelocityCustomerLimit > 0 {
+		rules = append(rules, NewVelocityRule(riskRepo, RiskVelocityPerCustomer, cfg.RiskVelocityCustomerLimit, cfg.RiskVelocityWindow))
+	}
+	if cfg.RiskVelocityCardLimit > 0 {
+		rules = append(rules, NewVelocityRule(riskRepo, RiskVelocityPerCard, cfg.RiskVelocityCardLimit, cfg.RiskVelocityWindow))
+	}
+
+	maxAmounts, err := domain.ParseRiskAmountLimits(cfg.RiskMaxAmounts)
+	if err != nil {
+		return nil, err
+	}
+	if len(maxAmounts) > 0 {
+		rules = append(rules, NewMaxAmountRule(maxAmounts))
+	}
+
+	blockedBINs, err := domain.ParseBINList(cfg.RiskBlockedBINs)
+	if err != nil {
+		return nil, err
+	}
+	if len(blockedBINs) > 0 {
+		rules = append(rules, NewBlockedBINRule(blockedBINs))
+	}
+
+	binCountries, err := domain.ParseBINCountries(cfg.RiskBINCountries)
+	if err != nil {
+		return nil, err
+	}
+	if len(binCountries) > 0 {
+		rules = append(rules, NewCountryMismatchRule(binCountries, customerRepo))
+	}
+
+	return NewRiskEngine(riskRepo, cardVault.Fingerprint, rules...), nil
+}
+
+func startCleanupLoop(repo domain.IdempotencyRepository, batchRepo domain.PaymentBatchRepository, interval time.Duration) {
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 	for range ticker.C {
 		cleaned, err := repo.DeleteExpired(context.Background())
 		if err != nil {
 			log.Printf("cleanup error: %v", err)
+		} else if cleaned > 0 {
+			log.Printf("cleaned %d expired idempotency records", cleaned)
+		}
+
+		cleaned, err = batchRepo.DeleteExpired(context.Background())
+		if err != nil {
+			log.Printf("batch cleanup error: %v", err)
+		} else if cleaned > 0 {
+			log.Printf("cleaned %d expired payment batches", cleaned)
+		}
+	}
+}
+
+func startRateLimitCleanupLoop(store domain.RateLimitStore, period, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		deleted, err := store.DeleteIdle(context.Background(), time.Now().Add(-period))
+		if err != nil {
+			log.Printf("rate limit cleanup error: %v", err)
 			continue
 		}
-		if cleaned > 0 {
-			log.Printf("cleaned %d expired idempotency records", cleaned)
+		if deleted > 0 {
+			log.Printf("deleted %d idle rate limit buckets", deleted)
+		}
+	}
+}
+
+func startPendingResolverLoop(uc *ResolvePendingPaymentsUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		resolved, err := uc.Execute(context.Background())
+		if err != nil {
+			log.Printf("pending resolver error: %v", err)
+			continue
+		}
+		if resolved > 0 {
+			log.Printf("resolved %d pending payments", resolved)
+		}
+	}
+}
+
+func startUnknownResolverLoop(uc *ResolveUnknownPaymentsUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		resolved, err := uc.Execute(context.Background())
+		if err != nil {
+			log.Printf("unknown resolver error: %v", err)
+			continue
+		}
+		if resolved > 0 {
+			log.Printf("resolved %d unknown payments", resolved)
+		}
+	}
+}
+
+func startWebhookDispatchLoop(uc *DispatchWebhooksUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		delivered, err := uc.Execute(context.Background())
+		if err != nil {
+			log.Printf("webhook dispatcher error: %v", err)
+			continue
+		}
+		if delivered > 0 {
+			log.Printf("delivered %d webhooks", delivered)
+		}
+	}
+}
+
+func startPaymentJobLoop(uc *ProcessPaymentJobsUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		finished, err := uc.Execute(context.Background())
+		if err != nil {
+			log.Printf("payment job worker error: %v", err)
+			continue
+		}
+		if finished > 0 {
+			log.Printf("finished %d payment jobs", finished)
+		}
+	}
+}
+
+func startSchedulerLoop(uc *ExecuteScheduledPaymentsUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		executed, err := uc.Execute(context.Background())
+		if err != nil {
+			log.Printf("scheduler error: %v", err)
+			continue
+		}
+		if executed > 0 {
+			log.Printf("executed %d scheduled payments", executed)
+		}
+	}
+}
+
+func startSubscriptionBillingLoop(uc *BillSubscriptionsUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		billed, err := uc.Execute(context.Background())
+		if err != nil {
+			log.Printf("subscription billing error: %v", err)
+			continue
+		}
+		if billed > 0 {
+			log.Printf("billed %d subscriptions", billed)
+		}
+	}
+}
+
+func startCurrencyRefreshLoop(uc *RefreshCurrenciesUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if _, err := uc.Execute(context.Background()); err != nil {
+			log.Printf("currency refresh error: %v", err)
 		}
 	}
 }
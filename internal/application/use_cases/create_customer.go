@@ -0,0 +1,74 @@
+package use_cases
+
+import (
+	"context"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type CreateCustomerUseCase struct {
+	customerRepo domain.CustomerRepository
+}
+
+func NewCreateCustomerUseCase(customerRepo domain.CustomerRepository) *CreateCustomerUseCase {
+	return &CreateCustomerUseCase{
+		customerRepo: customerRepo,
+	}
+}
+
+func (uc *CreateCustomerUseCase) Execute(ctx context.Context, req domain.CustomerRequest) (*domain.Customer, error) {
+	customer := &domain.Customer{
+		ID:        strings.TrimSpace(req.ID),
+		Name:      strings.TrimSpace(req.Name),
+		Email:     strings.TrimSpace(req.Email),
+		Country:   strings.ToUpper(strings.TrimSpace(req.Country)),
+		CreatedAt: time.Now(),
+	}
+
+	if err := validateCustomer(customer); err != nil {
+		return nil, err
+	}
+
+	if customer.ID == "" {
+		customer.ID = "cust_" + uuid.New().String()
+	} else {
+		existing, err := uc.customerRepo.FindByID(ctx, customer.ID)
+		if err != nil {
+			return nil, apperrors.ErrInternal()
+		}
+		if existing != nil {
+			return nil, apperrors.ErrCustomerAlreadyExists(customer.ID)
+		}
+	}
+
+	if err := uc.customerRepo.Create(ctx, customer); err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	return customer, nil
+}
+
+func validateCustomer(customer *domain.Customer) error {
+	if len(customer.ID) > 100 {
+		return apperrors.ErrInvalidCustomer("id must be at most 100 characters")
+	}
+	if customer.Name == "" {
+		return apperrors.ErrInvalidCustomer("name is required")
+	}
+	if len(customer.Name) > 200 {
+		return apperrors.ErrInvalidCustomer("name must be at most 200 characters")
+	}
+	if customer.Email != "" {
+		if _, err := mail.ParseAddress(customer.Email); err != nil {
+			return apperrors.ErrInvalidCustomer("email is invalid")
+		}
+	}
+	if customer.Country != "" && len(customer.Country) != 2 {
+		return apperrors.ErrInvalidCustomer("country must be an ISO 3166-1 alpha-2 code")
+	}
+	return nil
+}
@@ -3,24 +3,99 @@ package use_cases
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
 	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
 	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/utils/fingerprint"
 )
 
 type CreatePaymentResult struct {
-	Payment  *domain.Payment
-	Replayed bool
+	Payment   *domain.Payment
+	Replayed  bool
+	Duplicate bool
 }
 
+func (r *CreatePaymentResult) OutcomeUnknown() bool {
+	return r.Payment.Status == domain.PaymentStatusUnknown
+}
+
+type DuplicateRidePolicy string
+
+const (
+	DuplicateRidePolicyOff            DuplicateRidePolicy = "off"
+	DuplicateRidePolicyReject         DuplicateRidePolicy = "reject"
+	DuplicateRidePolicyReturnOriginal DuplicateRidePolicy = "return_original"
+)
+
 type CreatePaymentUseCase struct {
 	txManager       domain.TransactionManager
 	idempotencyRepo domain.IdempotencyRepository
 	paymentRepo     domain.PaymentRepository
 	processor       domain.PaymentProcessor
+	outboxRepo      domain.OutboxRepository
 	keyTTL          time.Duration
+
+	duplicateRidePolicy DuplicateRidePolicy
+	duplicateRideWindow time.Duration
+
+	paymentMethodRepo domain.PaymentMethodRepository
+	cardVault         domain.CardVault
+
+	customerRepo domain.CustomerRepository
+
+	ledger *LedgerRecorder
+
+	risk *RiskEngine
+}
+
+type resolvedPaymentMethod struct {
+	*domain.PaymentMethod
+	cardNumber string
+}
+
+type CreatePaymentOption func(*CreatePaymentUseCase)
+
+type paymentGuard func(ctx context.Context, money domain.Money) error
+
+func WithOutbox(outboxRepo domain.OutboxRepository) CreatePaymentOption {
+	return func(uc *CreatePaymentUseCase) {
+		uc.outboxRepo = outboxRepo
+	}
+}
+
+func WithDuplicateRideGuard(policy DuplicateRidePolicy, window time.Duration) CreatePaymentOption {
+	return func(uc *CreatePaymentUseCase) {
+		uc.duplicateRidePolicy = policy
+		uc.duplicateRideWindow = window
+	}
+}
+
+func WithPaymentMethods(paymentMethodRepo domain.PaymentMethodRepository, cardVault domain.CardVault) CreatePaymentOption {
+	return func(uc *CreatePaymentUseCase) {
+		uc.paymentMethodRepo = paymentMethodRepo
+		uc.cardVault = cardVault
+	}
+}
+
+func WithCustomerValidation(customerRepo domain.CustomerRepository) CreatePaymentOption {
+	return func(uc *CreatePaymentUseCase) {
+		uc.customerRepo = customerRepo
+	}
+}
+
+func WithLedger(ledger *LedgerRecorder) CreatePaymentOption {
+	return func(uc *CreatePaymentUseCase) {
+		uc.ledger = ledger
+	}
+}
+
+func WithRiskEngine(risk *RiskEngine) CreatePaymentOption {
+	return func(uc *CreatePaymentUseCase) {
+		uc.risk = risk
+	}
 }
 
 func NewCreatePaymentUseCase(
@@ -29,24 +104,56 @@ func NewCreatePaymentUseCase(
 	paymentRepo domain.PaymentRepository,
 	processor domain.PaymentProcessor,
 	keyTTL time.Duration,
+	opts ...CreatePaymentOption,
 ) *CreatePaymentUseCase {
-	return &CreatePaymentUseCase{
+	uc := &CreatePaymentUseCase{
 		txManager:       txManager,
 		idempotencyRepo: idempotencyRepo,
 		paymentRepo:     paymentRepo,
 		processor:       processor,
 		keyTTL:          keyTTL,
 	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
 }
 
 func (uc *CreatePaymentUseCase) Execute(ctx context.Context, idempotencyKey string, req domain.PaymentRequest) (*CreatePaymentResult, error) {
 	if err := validateIdempotencyKey(idempotencyKey); err != nil {
 		return nil, err
 	}
+	if req.ParentPaymentID != "" {
+		return nil, apperrors.ErrInvalidPaymentRequest("parent_payment_id is set by POST /v1/payments/:id/tips")
+	}
+	if req.ScheduledAt != nil {
+		return nil, apperrors.ErrInvalidSchedule("scheduled_at is only accepted by POST /v1/payments")
+	}
+	return uc.execute(ctx, idempotencyKey, req, nil)
+}
 
-	if err := validatePaymentRequest(req); err != nil {
+func (uc *CreatePaymentUseCase) execute(ctx context.Context, idempotencyKey string, req domain.PaymentRequest, guard paymentGuard) (*CreatePaymentResult, error) {
+	req.CardNumber = domain.NormalizeCardNumber(req.CardNumber)
+	money, err := validatePaymentRequest(req)
+	if err != nil {
 		return nil, err
 	}
+	var brand domain.CardBrand
+	if req.PaymentMethodID == "" {
+		brand, err = validateCardDetails(req.CardNumber, req.ExpMonth, req.ExpYear, req.CVV, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+	splits, err := resolvePaymentSplits(money, req.Splits)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.validateCustomer(ctx, req.CustomerID); err != nil {
+		return nil, err
+	}
+	req.Amount = money.Number()
+	req.Splits = normalizeSplitRequests(req.Splits, splits)
 
 	fp := fingerprint.Compute(req)
 
@@ -80,6 +187,23 @@ func (uc *CreatePaymentUseCase) Execute(ctx context.Context, idempotencyKey stri
 			return nil
 		}
 
+		if guard != nil {
+			if err := guard(txCtx, money); err != nil {
+				returnErr = err
+				return err
+			}
+		}
+
+		original, err := uc.findDuplicateRide(txCtx, req, money)
+		if err != nil {
+			returnErr = apperrors.ErrInternal()
+			return err
+		}
+		if original != nil && uc.duplicateRidePolicy == DuplicateRidePolicyReject {
+			returnErr = apperrors.ErrDuplicateRidePayment(original.ID)
+			return returnErr
+		}
+
 		newRecord := &domain.IdempotencyRecord{
 			Key:                idempotencyKey,
 			RequestFingerprint: fp,
@@ -92,17 +216,88 @@ func (uc *CreatePaymentUseCase) Execute(ctx context.Context, idempotencyKey stri
 			return err
 		}
 
-		payment, err := uc.processor.Process(ctx, req)
+		if original != nil {
+			responseBody, err := json.Marshal(original)
+			if err != nil {
+				returnErr = apperrors.ErrInternal()
+				return err
+			}
+			newRecord.Status = domain.IdempotencyStatusCompleted
+			newRecord.PaymentID = original.ID
+			newRecord.ResponseBody = responseBody
+			if err := uc.idempotencyRepo.Update(txCtx, newRecord); err != nil {
+				returnErr = apperrors.ErrInternal()
+				return err
+			}
+			result = &CreatePaymentResult{Payment: original, Duplicate: true}
+			return nil
+		}
+
+		processorReq := req
+		if req.PaymentMethodID != "" {
+			method, err := uc.resolvePaymentMethod(txCtx, req)
+			if err != nil {
+				returnErr = err
+				return err
+			}
+			processorReq.CardNumber = method.cardNumber
+			processorReq.ExpMonth = method.ExpMonth
+			processorReq.ExpYear = method.ExpYear
+			brand = method.CardBrand
+		}
+
+		assessment, err := uc.risk.assess(txCtx, idempotencyKey, processorReq, money)
 		if err != nil {
 			returnErr = apperrors.ErrInternal()
 			return err
 		}
 
+		var payment *domain.Payment
+		if assessment != nil && assessment.Decision == domain.RiskDecisionDeny {
+			payment = newBlockedPayment(processorReq, money, assessment.DenyReason())
+		} else {
+			processorKey := domain.ProcessorIdempotencyKey(idempotencyKey)
+			payment, err = uc.processor.Process(ctx, processorKey, processorReq)
+			switch {
+			case errors.Is(err, domain.ErrProcessorTimeout):
+				payment = newUnknownPayment(processorReq, money)
+			case errors.Is(err, domain.ErrProcessorUnavailable):
+				returnErr = apperrors.ErrProcessorUnavailable()
+				return err
+			case err != nil:
+				returnErr = apperrors.ErrInternal()
+				return err
+			}
+			payment.ProcessorKey = processorKey
+		}
+		if assessment != nil {
+			payment.RiskDecision = assessment.Decision
+		}
+		payment.CardBrand = brand
+		payment.PaymentMethodID = req.PaymentMethodID
+		payment.Splits = splits
+		payment.ParentPaymentID = req.ParentPaymentID
+
 		if err := uc.paymentRepo.Create(txCtx, payment); err != nil {
 			returnErr = apperrors.ErrInternal()
 			return err
 		}
 
+		if err := uc.risk.record(txCtx, assessment, payment.ID); err != nil {
+			returnErr = apperrors.ErrInternal()
+			return err
+		}
+
+		if err := uc.ledger.recordCharge(txCtx, payment); err != nil {
+			returnErr = apperrors.ErrInternal()
+			return err
+		}
+
+		if err := recordPaymentEvent(txCtx, uc.outboxRepo, payment); err != nil {
+			returnErr = apperrors.ErrInternal()
+			return err
+		}
+
 		responseBody, err := json.Marshal(payment)
 		if err != nil {
 			returnErr = apperrors.ErrInternal()
@@ -131,6 +326,92 @@ func (uc *CreatePaymentUseCase) Execute(ctx context.Context, idempotencyKey stri
 	return result, nil
 }
 
+func newBlockedPayment(req domain.PaymentRequest, money domain.Money, reason string) *domain.Payment {
+	return newLocalPayment(req, money, domain.PaymentStatusBlocked, reason)
+}
+
+func newUnknownPayment(req domain.PaymentRequest, money domain.Money) *domain.Payment {
+	return newLocalPayment(req, money, domain.PaymentStatusUnknown, "")
+}
+
+func newLocalPayment(req domain.PaymentRequest, money domain.Money, status domain.PaymentStatus, reason string) *domain.Payment {
+	last4 := req.CardNumber
+	if len(last4) > 4 {
+		last4 = last4[len(last4)-4:]
+	}
+	return &domain.Payment{
+		ID:          uuid.New().String(),
+		Amount:      money.Amount,
+		Currency:    money.Currency,
+		CustomerID:  req.CustomerID,
+		RideID:      req.RideID,
+		Status:      status,
+		CardLast4:   last4,
+		Description: req.Description,
+		FailReason:  reason,
+		CreatedAt:   time.Now(),
+	}
+}
+
+func (uc *CreatePaymentUseCase) findDuplicateRide(ctx context.Context, req domain.PaymentRequest, money domain.Money) (*domain.Payment, error) {
+	switch uc.duplicateRidePolicy {
+	case DuplicateRidePolicyReject, DuplicateRidePolicyReturnOriginal:
+	default:
+		return nil, nil
+	}
+	if req.AllowDuplicate {
+		return nil, nil
+	}
+
+	if err := uc.paymentRepo.LockRide(ctx, req.CustomerID, req.RideID); err != nil {
+		return nil, err
+	}
+	since := time.Now().Add(-uc.duplicateRideWindow)
+	return uc.paymentRepo.FindRecentCharge(ctx, req.CustomerID, req.RideID, money, since)
+}
+
+func (uc *CreatePaymentUseCase) validateCustomer(ctx context.Context, customerID string) error {
+	if uc.customerRepo == nil {
+		return nil
+	}
+
+	customer, err := uc.customerRepo.FindByID(ctx, customerID)
+	if err != nil {
+		return apperrors.ErrInternal()
+	}
+	if customer == nil {
+		return apperrors.ErrUnknownCustomer(customerID)
+	}
+	return nil
+}
+
+func (uc *CreatePaymentUseCase) resolvePaymentMethod(ctx context.Context, req domain.PaymentRequest) (*resolvedPaymentMethod, error) {
+	if uc.paymentMethodRepo == nil || uc.cardVault == nil {
+		return nil, apperrors.ErrPaymentMethodNotFound()
+	}
+
+	method, err := uc.paymentMethodRepo.FindByID(ctx, req.PaymentMethodID)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if method == nil || method.CustomerID != req.CustomerID {
+		return nil, apperrors.ErrPaymentMethodNotFound()
+	}
+
+	if _, err := validateCardExpiry(method.ExpMonth, method.ExpYear, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := validateCVV(req.CVV, method.CardBrand); err != nil {
+		return nil, err
+	}
+
+	cardNumber, err := uc.cardVault.Decrypt(method.EncryptedCard)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	return &resolvedPaymentMethod{PaymentMethod: method, cardNumber: string(cardNumber)}, nil
+}
+
 func validateIdempotencyKey(key string) error {
 	if key == "" {
 		return apperrors.ErrIdempotencyKeyMissing()
@@ -141,16 +422,32 @@ func validateIdempotencyKey(key string) error {
 	return nil
 }
 
-func validatePaymentRequest(req domain.PaymentRequest) error {
+func validatePaymentRequest(req domain.PaymentRequest) (domain.Money, error) {
 	var reasons []string
 
-	if req.Amount <= 0 {
+	currencies := domain.Currencies()
+	if req.Currency != "" && !currencies.Supported(req.Currency) {
+		return domain.Money{}, apperrors.ErrInvalidCurrency(string(req.Currency), currencies.EnabledCodes())
+	}
+
+	money, err := req.Money()
+	switch {
+	case req.Amount == "":
+		reasons = append(reasons, "amount must be greater than 0")
+	case req.Currency == "":
+	case errors.Is(err, domain.ErrAmountPrecision):
+		return domain.Money{}, apperrors.ErrInvalidAmountPrecision(string(req.Currency), currencies.Exponent(req.Currency))
+	case err != nil:
+		reasons = append(reasons, err.Error())
+	case money.Amount <= 0:
 		reasons = append(reasons, "amount must be greater than 0")
+	default:
+		if err := validateAmountBounds(money); err != nil {
+			return domain.Money{}, err
+		}
 	}
 	if req.Currency == "" {
 		reasons = append(reasons, "currency is required")
-	} else if !domain.ValidCurrencies[req.Currency] {
-		return apperrors.ErrInvalidCurrency(string(req.Currency))
 	}
 	if req.CustomerID == "" {
 		reasons = append(reasons, "customer_id is required")
@@ -158,12 +455,54 @@ func validatePaymentRequest(req domain.PaymentRequest) error {
 	if req.RideID == "" {
 		reasons = append(reasons, "ride_id is required")
 	}
-	if req.CardNumber == "" {
-		reasons = append(reasons, "card_number is required")
+	switch {
+	case req.CardNumber == "" && req.PaymentMethodID == "":
+		reasons = append(reasons, "card_number or payment_method_id is required")
+	case req.CardNumber != "" && req.PaymentMethodID != "":
+		reasons = append(reasons, "card_number and payment_method_id cannot be combined")
+	case req.PaymentMethodID != "" && (req.ExpMonth != 0 || req.ExpYear != 0):
+		reasons = append(reasons, "exp_month and exp_year are taken from the payment method")
 	}
 
 	if len(reasons) > 0 {
-		return apperrors.ErrInvalidPaymentRequest(reasons[0])
+		return domain.Money{}, apperrors.ErrInvalidPaymentRequest(reasons[0])
+	}
+	return money, nil
+}
+
+func resolvePaymentSplits(total domain.Money, requests []domain.SplitRequest) ([]*domain.PaymentSplit, error) {
+	splits, err := domain.ResolveSplits(total, requests)
+	switch {
+	case errors.Is(err, domain.ErrAmountPrecision):
+		return nil, apperrors.ErrInvalidAmountPrecision(string(total.Currency), total.Exponent())
+	case err != nil:
+		return nil, apperrors.ErrInvalidSplit(err.Error())
+	}
+	return splits, nil
+}
+
+func normalizeSplitRequests(requests []domain.SplitRequest, splits []*domain.PaymentSplit) []domain.SplitRequest {
+	if len(requests) == 0 {
+		return nil
+	}
+
+	normalized := make([]domain.SplitRequest, len(requests))
+	for i, split := range splits {
+		normalized[i] = domain.SplitRequest{Recipient: split.Recipient, Percentage: split.Percentage}
+		if split.Percentage == "" {
+			normalized[i].Amount = domain.NewMoney(split.Amount, split.Currency).Number()
+		}
+	}
+	return normalized
+}
+
+func validateAmountBounds(money domain.Money) error {
+	def, _ := domain.Currencies().Lookup(money.Currency)
+	if def.MinAmount > 0 && money.Amount < def.MinAmount {
+		return apperrors.ErrAmountBelowMinimum(string(money.Currency), domain.NewMoney(def.MinAmount, money.Currency).Decimal())
+	}
+	if def.MaxAmount > 0 && money.Amount > def.MaxAmount {
+		return apperrors.ErrAmountAboveMaximum(string(money.Currency), domain.NewMoney(def.MaxAmount, money.Currency).Decimal())
 	}
 	return nil
 }
@@ -0,0 +1,238 @@
+package use_cases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/utils/fingerprint"
+)
+
+const batchStaleAfter = 5 * time.Minute
+
+type CreatePaymentBatchResult struct {
+	Response *domain.BatchPaymentResponse
+	Replayed bool
+}
+
+type CreatePaymentBatchUseCase struct {
+	batchRepo     domain.PaymentBatchRepository
+	createPayment *CreatePaymentUseCase
+	maxItems      int
+	concurrency   int
+	keyTTL        time.Duration
+}
+
+func NewCreatePaymentBatchUseCase(
+	batchRepo domain.PaymentBatchRepository,
+	createPayment *CreatePaymentUseCase,
+	maxItems, concurrency int,
+	keyTTL time.Duration,
+) *CreatePaymentBatchUseCase {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &CreatePaymentBatchUseCase{
+		batchRepo:     batchRepo,
+		createPayment: createPayment,
+		maxItems:      maxItems,
+		concurrency:   concurrency,
+		keyTTL:        keyTTL,
+	}
+}
+
+func (uc *CreatePaymentBatchUseCase) Execute(ctx context.Context, idempotencyKey string, req domain.BatchPaymentRequest) (*CreatePaymentBatchResult, error) {
+	if err := validateIdempotencyKey(idempotencyKey); err != nil {
+		return nil, err
+	}
+	if err := uc.validate(req); err != nil {
+		return nil, err
+	}
+
+	fp := fingerprint.ComputeBatch(req.Items)
+	now := time.Now()
+	batch := &domain.PaymentBatch{
+		IdempotencyKey:     idempotencyKey,
+		RequestFingerprint: fp,
+		Status:             domain.IdempotencyStatusProcessing,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+		ExpiresAt:          now.Add(uc.keyTTL),
+	}
+
+	created, err := uc.batchRepo.CreateIfAbsent(ctx, batch)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if !created {
+		existing, err := uc.batchRepo.FindByKey(ctx, idempotencyKey)
+		if err != nil {
+			return nil, apperrors.ErrInternal()
+		}
+		if existing == nil {
+			return nil, apperrors.ErrBatchProcessing()
+		}
+		if existing.RequestFingerprint != fp {
+			return nil, apperrors.ErrIdempotencyKeyConflict()
+		}
+		if existing.Status == domain.IdempotencyStatusCompleted {
+			var cached domain.BatchPaymentResponse
+			if err := json.Unmarshal(existing.ResponseBody, &cached); err != nil {
+				return nil, apperrors.ErrInternal()
+			}
+			if !uc.rerunTransient(context.WithoutCancel(ctx), req.Items, &cached) {
+				return &CreatePaymentBatchResult{Response: &cached, Replayed: true}, nil
+			}
+			if err := uc.complete(context.WithoutCancel(ctx), existing, &cached); err != nil {
+				return nil, err
+			}
+			return &CreatePaymentBatchResult{Response: &cached}, nil
+		}
+
+		claimed, err := uc.batchRepo.ClaimStale(ctx, idempotencyKey, now.Add(-batchStaleAfter))
+		if err != nil {
+			return nil, apperrors.ErrInternal()
+		}
+		if !claimed {
+			return nil, apperrors.ErrBatchProcessing()
+		}
+		batch = existing
+	}
+
+	response := &domain.BatchPaymentResponse{
+		IdempotencyKey: idempotencyKey,
+		Items:          runPayments(context.WithoutCancel(ctx), uc.createPayment, req.Items, uc.concurrency),
+	}
+	response.Tally()
+
+	if err := uc.complete(context.WithoutCancel(ctx), batch, response); err != nil {
+		return nil, err
+	}
+	return &CreatePaymentBatchResult{Response: response}, nil
+}
+
+func (uc *CreatePaymentBatchUseCase) complete(ctx context.Context, batch *domain.PaymentBatch, response *domain.BatchPaymentResponse) error {
+	body, err := json.Marshal(response)
+	if err != nil {
+		return apperrors.ErrInternal()
+	}
+	batch.Status = domain.IdempotencyStatusCompleted
+	batch.ResponseBody = body
+	batch.UpdatedAt = time.Now()
+	if err := uc.batchRepo.Update(ctx, batch); err != nil {
+		return apperrors.ErrInternal()
+	}
+	return nil
+}
+
+func (uc *CreatePaymentBatchUseCase) rerunTransient(ctx context.Context, items []domain.BatchPaymentItem, response *domain.BatchPaymentResponse) bool {
+	var retry []domain.BatchPaymentItem
+	var indexes []int
+	for i, item := range response.Items {
+		if transientBatchItem(item) {
+			retry = append(retry, items[i])
+			indexes = append(indexes, i)
+		}
+	}
+	if len(retry) == 0 {
+		return false
+	}
+
+	for j, result := range runPayments(ctx, uc.createPayment, retry, uc.concurrency) {
+		result.Index = indexes[j]
+		response.Items[indexes[j]] = result
+	}
+	response.Tally()
+	return true
+}
+
+func transientBatchItem(item *domain.BatchItemResult) bool {
+	if item.Error == nil {
+		return false
+	}
+	return item.HTTPStatus >= http.StatusInternalServerError || item.Error.Code == apperrors.ErrPaymentProcessing().Code
+}
+
+func (uc *CreatePaymentBatchUseCase) validate(req domain.BatchPaymentRequest) error {
+	if len(req.Items) == 0 {
+		return apperrors.ErrInvalidBatchRequest("items must not be empty")
+	}
+	if uc.maxItems > 0 && len(req.Items) > uc.maxItems {
+		return apperrors.ErrInvalidBatchRequest(fmt.Sprintf("at most %d items are allowed", uc.maxItems))
+	}
+
+	seen := make(map[string]int, len(req.Items))
+	for i, item := range req.Items {
+		if item.IdempotencyKey == "" {
+			return apperrors.ErrInvalidBatchRequest(fmt.Sprintf("items[%d].idempotency_key is required", i))
+		}
+		if len(item.IdempotencyKey) > 64 {
+			return apperrors.ErrInvalidBatchRequest(fmt.Sprintf("items[%d].idempotency_key must be at most 64 characters", i))
+		}
+		if first, ok := seen[item.IdempotencyKey]; ok {
+			return apperrors.ErrInvalidBatchRequest(fmt.Sprintf("items[%d].idempotency_key repeats items[%d]", i, first))
+		}
+		seen[item.IdempotencyKey] = i
+	}
+	return nil
+}
+
+func runPayments(ctx context.Context, createPayment *CreatePaymentUseCase, items []domain.BatchPaymentItem, concurrency int) []*domain.BatchItemResult {
+	results := make([]*domain.BatchItemResult, len(items))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, item := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, item domain.BatchPaymentItem) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, err := createPayment.Execute(ctx, item.IdempotencyKey, item.PaymentRequest)
+			results[i] = batchItemResult(i, item.IdempotencyKey, result, err)
+		}(i, item)
+	}
+
+	wg.Wait()
+	return results
+}
+
+func batchItemResult(index int, key string, result *CreatePaymentResult, err error) *domain.BatchItemResult {
+	item := &domain.BatchItemResult{Index: index, IdempotencyKey: key}
+
+	if err != nil {
+		var appErr *apperrors.AppError
+		if !errors.As(err, &appErr) {
+			appErr = apperrors.ErrInternal()
+		}
+		item.Outcome = domain.BatchItemFailed
+		if appErr.HTTPCode == http.StatusConflict {
+			item.Outcome = domain.BatchItemConflict
+		}
+		item.HTTPStatus = appErr.HTTPCode
+		item.Error = &domain.BatchItemError{Code: appErr.Code, Message: appErr.Message}
+		return item
+	}
+
+	item.Payment = result.Payment
+	switch {
+	case result.Duplicate:
+		item.Outcome = domain.BatchItemReplayed
+		item.HTTPStatus = http.StatusOK
+		item.DuplicateRide = true
+	case result.Replayed:
+		item.Outcome = domain.BatchItemReplayed
+		item.HTTPStatus = http.StatusCreated
+	default:
+		item.Outcome = domain.BatchItemCreated
+		item.HTTPStatus = http.StatusCreated
+	}
+	return item
+}
@@ -0,0 +1,57 @@
+package use_cases
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type CreatePaymentJobCommand struct {
+	FileName string
+	Content  []byte
+}
+
+type CreatePaymentJobUseCase struct {
+	jobRepo domain.PaymentJobRepository
+	maxRows int
+}
+
+func NewCreatePaymentJobUseCase(jobRepo domain.PaymentJobRepository, maxRows int) *CreatePaymentJobUseCase {
+	return &CreatePaymentJobUseCase{
+		jobRepo: jobRepo,
+		maxRows: maxRows,
+	}
+}
+
+func (uc *CreatePaymentJobUseCase) Execute(ctx context.Context, cmd CreatePaymentJobCommand) (*domain.PaymentJob, error) {
+	rows, err := domain.ParsePaymentJobCSV(bytes.NewReader(cmd.Content))
+	if err != nil {
+		return nil, apperrors.ErrInvalidPaymentJobFile(err.Error())
+	}
+	if len(rows) == 0 {
+		return nil, apperrors.ErrInvalidPaymentJobFile("file has no payment rows")
+	}
+	if uc.maxRows > 0 && len(rows) > uc.maxRows {
+		return nil, apperrors.ErrInvalidPaymentJobFile(fmt.Sprintf("file has %d rows; at most %d are allowed", len(rows), uc.maxRows))
+	}
+
+	now := time.Now()
+	job := &domain.PaymentJob{
+		ID:        uuid.New().String(),
+		FileName:  cmd.FileName,
+		Status:    domain.PaymentJobQueued,
+		TotalRows: len(rows),
+		Input:     string(cmd.Content),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.jobRepo.Create(ctx, job); err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	return job, nil
+}
@@ -0,0 +1,80 @@
+package use_cases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type CreatePaymentMethodResult struct {
+	PaymentMethod *domain.PaymentMethod
+	Existing      bool
+}
+
+type CreatePaymentMethodUseCase struct {
+	paymentMethodRepo domain.PaymentMethodRepository
+	cardVault         domain.CardVault
+}
+
+func NewCreatePaymentMethodUseCase(paymentMethodRepo domain.PaymentMethodRepository, cardVault domain.CardVault) *CreatePaymentMethodUseCase {
+	return &CreatePaymentMethodUseCase{
+		paymentMethodRepo: paymentMethodRepo,
+		cardVault:         cardVault,
+	}
+}
+
+func (uc *CreatePaymentMethodUseCase) Execute(ctx context.Context, customerID string, req domain.PaymentMethodRequest) (*CreatePaymentMethodResult, error) {
+	if customerID == "" {
+		return nil, apperrors.ErrInvalidPaymentMethod("customer id is required")
+	}
+	if req.CardNumber == "" {
+		return nil, apperrors.ErrInvalidPaymentMethod("card_number is required")
+	}
+	if req.ExpMonth == 0 || req.ExpYear == 0 {
+		return nil, apperrors.ErrInvalidPaymentMethod("exp_month and exp_year are required")
+	}
+
+	now := time.Now()
+	cardNumber := domain.NormalizeCardNumber(req.CardNumber)
+	brand, err := validateCardDetails(cardNumber, req.ExpMonth, req.ExpYear, "", now)
+	if err != nil {
+		return nil, err
+	}
+	expYear, err := validateCardExpiry(req.ExpMonth, req.ExpYear, now)
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint := uc.cardVault.Fingerprint(cardNumber)
+	existing, err := uc.paymentMethodRepo.FindByFingerprint(ctx, customerID, fingerprint)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if existing != nil {
+		return &CreatePaymentMethodResult{PaymentMethod: existing, Existing: true}, nil
+	}
+
+	encrypted, err := uc.cardVault.Encrypt([]byte(cardNumber))
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+
+	method := &domain.PaymentMethod{
+		ID:              "pm_" + uuid.New().String(),
+		CustomerID:      customerID,
+		CardBrand:       brand,
+		CardLast4:       cardNumber[len(cardNumber)-4:],
+		ExpMonth:        req.ExpMonth,
+		ExpYear:         expYear,
+		CardFingerprint: fingerprint,
+		EncryptedCard:   encrypted,
+		CreatedAt:       now,
+	}
+	if err := uc.paymentMethodRepo.Create(ctx, method); err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	return &CreatePaymentMethodResult{PaymentMethod: method}, nil
+}
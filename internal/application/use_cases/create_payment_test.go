@@ -11,7 +11,7 @@ import (
 
 func validRequest() domain.PaymentRequest {
 	return domain.PaymentRequest{
-		Amount:      85000,
+		Amount:      "85000",
 		Currency:    domain.CurrencyIDR,
 		CustomerID:  "cust_001",
 		RideID:      "ride_001",
@@ -81,7 +81,7 @@ func TestValidatePaymentRequest(t *testing.T) {
 		{
 			name: "zero amount",
 			req: domain.PaymentRequest{
-				Amount:     0,
+				Amount:     "0",
 				Currency:   domain.CurrencyIDR,
 				CustomerID: "cust_001",
 				RideID:     "ride_001",
@@ -93,7 +93,7 @@ func TestValidatePaymentRequest(t *testing.T) {
 		{
 			name: "negative amount",
 			req: domain.PaymentRequest{
-				Amount:     -100,
+				Amount:     "-100",
 				Currency:   domain.CurrencyIDR,
 				CustomerID: "cust_001",
 				RideID:     "ride_001",
@@ -105,7 +105,7 @@ func TestValidatePaymentRequest(t *testing.T) {
 		{
 			name: "invalid currency",
 			req: domain.PaymentRequest{
-				Amount:     100,
+				Amount:     "100",
 				Currency:   "USD",
 				CustomerID: "cust_001",
 				RideID:     "ride_001",
@@ -117,7 +117,7 @@ func TestValidatePaymentRequest(t *testing.T) {
 		{
 			name: "missing customer_id",
 			req: domain.PaymentRequest{
-				Amount:     100,
+				Amount:     "100",
 				Currency:   domain.CurrencyIDR,
 				CustomerID: "",
 				RideID:     "ride_001",
@@ -129,7 +129,7 @@ func TestValidatePaymentRequest(t *testing.T) {
 		{
 			name: "missing ride_id",
 			req: domain.PaymentRequest{
-				Amount:     100,
+				Amount:     "100",
 				Currency:   domain.CurrencyIDR,
 				CustomerID: "cust_001",
 				RideID:     "",
@@ -141,7 +141,7 @@ func TestValidatePaymentRequest(t *testing.T) {
 		{
 			name: "missing card_number",
 			req: domain.PaymentRequest{
-				Amount:     100,
+				Amount:     "100",
 				Currency:   domain.CurrencyIDR,
 				CustomerID: "cust_001",
 				RideID:     "ride_001",
@@ -153,7 +153,7 @@ func TestValidatePaymentRequest(t *testing.T) {
 		{
 			name: "missing currency",
 			req: domain.PaymentRequest{
-				Amount:     100,
+				Amount:     "100",
 				Currency:   "",
 				CustomerID: "cust_001",
 				RideID:     "ride_001",
@@ -166,7 +166,7 @@ func TestValidatePaymentRequest(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validatePaymentRequest(tt.req)
+			_, err := validatePaymentRequest(tt.req)
 			if tt.wantErr {
 				assert.Error(t, err)
 				appErr, ok := err.(*apperrors.AppError)
@@ -186,7 +186,7 @@ func TestFingerprintConsistency(t *testing.T) {
 	assert.Equal(t, fp1, fp2)
 
 	req2 := validRequest()
-	req2.Amount = 99999
+	req2.Amount = "99999"
 	fp3 := fingerprint.Compute(req2)
 	assert.NotEqual(t, fp1, fp3)
 }
@@ -0,0 +1,75 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type CreatePlanUseCase struct {
+	planRepo domain.SubscriptionPlanRepository
+}
+
+func NewCreatePlanUseCase(planRepo domain.SubscriptionPlanRepository) *CreatePlanUseCase {
+	return &CreatePlanUseCase{
+		planRepo: planRepo,
+	}
+}
+
+func (uc *CreatePlanUseCase) Execute(ctx context.Context, req domain.PlanRequest) (*domain.SubscriptionPlan, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, apperrors.ErrInvalidPlan("name is required")
+	}
+
+	currencies := domain.Currencies()
+	if req.Currency == "" {
+		return nil, apperrors.ErrInvalidPlan("currency is required")
+	}
+	if !currencies.Supported(req.Currency) {
+		return nil, apperrors.ErrInvalidCurrency(string(req.Currency), currencies.EnabledCodes())
+	}
+	money, err := domain.ParseMoney(req.Amount.String(), req.Currency)
+	switch {
+	case errors.Is(err, domain.ErrAmountPrecision):
+		return nil, apperrors.ErrInvalidAmountPrecision(string(req.Currency), currencies.Exponent(req.Currency))
+	case err != nil || money.Amount <= 0:
+		return nil, apperrors.ErrInvalidPlan("amount must be greater than 0")
+	}
+	if err := validateAmountBounds(money); err != nil {
+		return nil, err
+	}
+
+	interval := domain.BillingInterval(strings.ToUpper(string(req.Interval)))
+	switch interval {
+	case domain.BillingIntervalDay, domain.BillingIntervalWeek, domain.BillingIntervalMonth, domain.BillingIntervalYear:
+	default:
+		return nil, apperrors.ErrInvalidPlan("interval must be one of DAY, WEEK, MONTH, YEAR")
+	}
+	count := req.IntervalCount
+	if count == 0 {
+		count = 1
+	}
+	if count < 1 || count > 365 {
+		return nil, apperrors.ErrInvalidPlan("interval_count must be between 1 and 365")
+	}
+
+	plan := &domain.SubscriptionPlan{
+		ID:            uuid.New().String(),
+		Name:          name,
+		Amount:        money.Amount,
+		Currency:      money.Currency,
+		Interval:      interval,
+		IntervalCount: count,
+		CreatedAt:     time.Now(),
+	}
+	if err := uc.planRepo.Create(ctx, plan); err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	return plan, nil
+}
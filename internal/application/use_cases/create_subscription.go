@@ -0,0 +1,78 @@
+package use_cases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type CreateSubscriptionUseCase struct {
+	subscriptionRepo  domain.SubscriptionRepository
+	planRepo          domain.SubscriptionPlanRepository
+	paymentMethodRepo domain.PaymentMethodRepository
+}
+
+func NewCreateSubscriptionUseCase(
+	subscriptionRepo domain.SubscriptionRepository,
+	planRepo domain.SubscriptionPlanRepository,
+	paymentMethodRepo domain.PaymentMethodRepository,
+) *CreateSubscriptionUseCase {
+	return &CreateSubscriptionUseCase{
+		subscriptionRepo:  subscriptionRepo,
+		planRepo:          planRepo,
+		paymentMethodRepo: paymentMethodRepo,
+	}
+}
+
+func (uc *CreateSubscriptionUseCase) Execute(ctx context.Context, req domain.SubscriptionRequest) (*domain.Subscription, error) {
+	switch {
+	case req.CustomerID == "":
+		return nil, apperrors.ErrInvalidSubscription("customer_id is required")
+	case req.PlanID == "":
+		return nil, apperrors.ErrInvalidSubscription("plan_id is required")
+	case req.PaymentMethodID == "":
+		return nil, apperrors.ErrInvalidSubscription("payment_method_id is required")
+	}
+
+	now := time.Now().UTC()
+	start := now
+	if req.StartAt != nil {
+		start = req.StartAt.UTC()
+		if start.Before(now.Add(-time.Minute)) {
+			return nil, apperrors.ErrInvalidSubscription("start_at must not be in the past")
+		}
+	}
+
+	plan, err := uc.planRepo.FindByID(ctx, req.PlanID)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if plan == nil {
+		return nil, apperrors.ErrPlanNotFound()
+	}
+
+	method, err := uc.paymentMethodRepo.FindByID(ctx, req.PaymentMethodID)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if method == nil || method.CustomerID != req.CustomerID {
+		return nil, apperrors.ErrPaymentMethodNotFound()
+	}
+
+	existing, err := uc.subscriptionRepo.FindLive(ctx, req.CustomerID, req.PlanID)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if existing != nil {
+		return nil, apperrors.ErrSubscriptionExists(existing.ID)
+	}
+
+	sub := domain.NewSubscription(uuid.New().String(), req, plan, start, now)
+	if err := uc.subscriptionRepo.Create(ctx, sub); err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	return sub, nil
+}
@@ -0,0 +1,86 @@
+package use_cases
+
+import (
+	"context"
+	"math"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type CreateTipUseCase struct {
+	paymentRepo   domain.PaymentRepository
+	createPayment *CreatePaymentUseCase
+	maxRatio      float64
+}
+
+func NewCreateTipUseCase(paymentRepo domain.PaymentRepository, createPayment *CreatePaymentUseCase, maxRatio float64) *CreateTipUseCase {
+	return &CreateTipUseCase{
+		paymentRepo:   paymentRepo,
+		createPayment: createPayment,
+		maxRatio:      maxRatio,
+	}
+}
+
+func (uc *CreateTipUseCase) Execute(ctx context.Context, idempotencyKey, parentID string, req domain.TipRequest) (*CreatePaymentResult, error) {
+	if err := validateIdempotencyKey(idempotencyKey); err != nil {
+		return nil, err
+	}
+
+	parent, err := uc.paymentRepo.FindByID(ctx, parentID)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if parent == nil {
+		return nil, apperrors.ErrPaymentNotFound()
+	}
+	if parent.ParentPaymentID != "" {
+		return nil, apperrors.ErrTipNotAllowed("tips cannot be added to a tip")
+	}
+	if parent.PaymentMethodID == "" {
+		return nil, apperrors.ErrTipNotAllowed("parent payment was not charged with a stored payment method")
+	}
+
+	paymentReq := domain.PaymentRequest{
+		Amount:          req.Amount,
+		Currency:        parent.Currency,
+		CustomerID:      parent.CustomerID,
+		RideID:          parent.RideID,
+		CVV:             req.CVV,
+		Description:     req.Description,
+		AllowDuplicate:  true,
+		PaymentMethodID: parent.PaymentMethodID,
+		ParentPaymentID: parent.ID,
+	}
+	return uc.createPayment.execute(ctx, idempotencyKey, paymentReq, uc.tipLimitGuard(parent.ID))
+}
+
+func (uc *CreateTipUseCase) tipLimitGuard(parentID string) paymentGuard {
+	return func(ctx context.Context, tip domain.Money) error {
+		parent, err := uc.paymentRepo.FindByIDForUpdate(ctx, parentID)
+		if err != nil {
+			return apperrors.ErrInternal()
+		}
+		if parent == nil {
+			return apperrors.ErrPaymentNotFound()
+		}
+		if parent.Status != domain.PaymentStatusSucceeded {
+			return apperrors.ErrTipNotAllowed("parent payment has not succeeded")
+		}
+		if uc.maxRatio <= 0 {
+			return nil
+		}
+
+		tipped, err := uc.paymentRepo.TipTotal(ctx, parentID)
+		if err != nil {
+			return apperrors.ErrInternal()
+		}
+
+		limit := int64(math.Floor(float64(parent.Amount) * uc.maxRatio))
+		if tipped+tip.Amount > limit {
+			remaining := max(limit-tipped, 0)
+			return apperrors.ErrTipLimitExceeded(string(parent.Currency), domain.NewMoney(remaining, parent.Currency).Decimal())
+		}
+		return nil
+	}
+}
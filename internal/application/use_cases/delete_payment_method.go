@@ -0,0 +1,32 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type DeletePaymentMethodUseCase struct {
+	paymentMethodRepo domain.PaymentMethodRepository
+}
+
+func NewDeletePaymentMethodUseCase(paymentMethodRepo domain.PaymentMethodRepository) *DeletePaymentMethodUseCase {
+	return &DeletePaymentMethodUseCase{
+		paymentMethodRepo: paymentMethodRepo,
+	}
+}
+
+func (uc *DeletePaymentMethodUseCase) Execute(ctx context.Context, customerID, id string) error {
+	method, err := uc.paymentMethodRepo.FindByID(ctx, id)
+	if err != nil {
+		return apperrors.ErrInternal()
+	}
+	if method == nil || method.CustomerID != customerID {
+		return apperrors.ErrPaymentMethodNotFound()
+	}
+	if err := uc.paymentMethodRepo.Delete(ctx, id); err != nil {
+		return apperrors.ErrInternal()
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type DeleteWebhookEndpointUseCase struct {
+	endpointRepo domain.WebhookEndpointRepository
+}
+
+func NewDeleteWebhookEndpointUseCase(endpointRepo domain.WebhookEndpointRepository) *DeleteWebhookEndpointUseCase {
+	return &DeleteWebhookEndpointUseCase{
+		endpointRepo: endpointRepo,
+	}
+}
+
+func (uc *DeleteWebhookEndpointUseCase) Execute(ctx context.Context, id string) error {
+	endpoint, err := uc.endpointRepo.FindByID(ctx, id)
+	if err != nil {
+		return apperrors.ErrInternal()
+	}
+	if endpoint == nil {
+		return apperrors.ErrWebhookEndpointNotFound()
+	}
+	if err := uc.endpointRepo.Delete(ctx, id); err != nil {
+		return apperrors.ErrInternal()
+	}
+	return nil
+}
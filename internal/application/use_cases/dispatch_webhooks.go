@@ -0,0 +1,155 @@
+package use_cases
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+)
+
+const (
+	webhookEndpointRemoved = "webhook endpoint removed or disabled"
+	webhookDeliveryLease   = 5 * time.Minute
+)
+
+type DispatchWebhooksUseCase struct {
+	txManager    domain.TransactionManager
+	outboxRepo   domain.OutboxRepository
+	endpointRepo domain.WebhookEndpointRepository
+	deliveryRepo domain.WebhookDeliveryRepository
+	sender       domain.WebhookSender
+	maxAttempts  int
+	backoffBase  time.Duration
+	backoffMax   time.Duration
+	batchSize    int
+}
+
+func NewDispatchWebhooksUseCase(
+	txManager domain.TransactionManager,
+	outboxRepo domain.OutboxRepository,
+	endpointRepo domain.WebhookEndpointRepository,
+	deliveryRepo domain.WebhookDeliveryRepository,
+	sender domain.WebhookSender,
+	maxAttempts int,
+	backoffBase time.Duration,
+	backoffMax time.Duration,
+	batchSize int,
+) *DispatchWebhooksUseCase {
+	return &DispatchWebhooksUseCase{
+		txManager:    txManager,
+		outboxRepo:   outboxRepo,
+		endpointRepo: endpointRepo,
+		deliveryRepo: deliveryRepo,
+		sender:       sender,
+		maxAttempts:  maxAttempts,
+		backoffBase:  backoffBase,
+		backoffMax:   backoffMax,
+		batchSize:    batchSize,
+	}
+}
+
+func (uc *DispatchWebhooksUseCase) Execute(ctx context.Context) (int, error) {
+	if err := uc.fanOut(ctx); err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	deliveries, err := uc.deliveryRepo.ClaimDue(ctx, now, now.Add(webhookDeliveryLease), uc.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, delivery := range deliveries {
+		ok, err := uc.deliver(ctx, delivery)
+		if err != nil {
+			log.Printf("webhook dispatcher: delivery %s: %v", delivery.ID, err)
+			continue
+		}
+		if ok {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+func (uc *DispatchWebhooksUseCase) fanOut(ctx context.Context) error {
+	events, err := uc.outboxRepo.FindPending(ctx, uc.batchSize)
+	if err != nil || len(events) == 0 {
+		return err
+	}
+
+	endpoints, err := uc.endpointRepo.FindEnabled(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		err := uc.txManager.RunInTransaction(ctx, func(txCtx context.Context) error {
+			claimed, err := uc.outboxRepo.MarkDispatched(txCtx, event.ID)
+			if err != nil || !claimed {
+				return err
+			}
+			for _, endpoint := range endpoints {
+				delivery := &domain.WebhookDelivery{
+					ID:            uuid.New().String(),
+					EventID:       event.ID,
+					EndpointID:    endpoint.ID,
+					Status:        domain.WebhookDeliveryStatusPending,
+					NextAttemptAt: time.Now(),
+					CreatedAt:     time.Now(),
+				}
+				if err := uc.deliveryRepo.Create(txCtx, delivery); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (uc *DispatchWebhooksUseCase) deliver(ctx context.Context, delivery *domain.WebhookDelivery) (bool, error) {
+	event, err := uc.outboxRepo.FindByID(ctx, delivery.EventID)
+	if err != nil {
+		return false, err
+	}
+	endpoint, err := uc.endpointRepo.FindByID(ctx, delivery.EndpointID)
+	if err != nil {
+		return false, err
+	}
+
+	if event == nil || endpoint == nil || !endpoint.Enabled {
+		delivery.Status = domain.WebhookDeliveryStatusDead
+		delivery.LastError = webhookEndpointRemoved
+		return false, uc.deliveryRepo.Update(ctx, delivery)
+	}
+
+	code, sendErr := uc.sender.Send(ctx, endpoint, event.ID, event.Payload)
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastStatusCode = code
+
+	switch {
+	case sendErr == nil:
+		delivery.Status = domain.WebhookDeliveryStatusDelivered
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= uc.maxAttempts:
+		delivery.Status = domain.WebhookDeliveryStatusDead
+		delivery.LastError = sendErr.Error()
+	default:
+		delivery.LastError = sendErr.Error()
+		delivery.NextAttemptAt = now.Add(exponentialBackoff(uc.backoffBase, uc.backoffMax, delivery.Attempts))
+	}
+
+	if err := uc.deliveryRepo.Update(ctx, delivery); err != nil {
+		return false, err
+	}
+	return sendErr == nil, nil
+}
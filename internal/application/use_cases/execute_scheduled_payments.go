@@ -0,0 +1,88 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+const (
+	scheduledPaymentStaleAfter  = 5 * time.Minute
+	scheduledPaymentMaxAttempts = 5
+	scheduledPaymentRetryBase   = 30 * time.Second
+	scheduledPaymentRetryMax    = 10 * time.Minute
+)
+
+type ExecuteScheduledPaymentsUseCase struct {
+	scheduledRepo domain.ScheduledPaymentRepository
+	createPayment *CreatePaymentUseCase
+	batchSize     int
+}
+
+func NewExecuteScheduledPaymentsUseCase(
+	scheduledRepo domain.ScheduledPaymentRepository,
+	createPayment *CreatePaymentUseCase,
+	batchSize int,
+) *ExecuteScheduledPaymentsUseCase {
+	return &ExecuteScheduledPaymentsUseCase{
+		scheduledRepo: scheduledRepo,
+		createPayment: createPayment,
+		batchSize:     batchSize,
+	}
+}
+
+func (uc *ExecuteScheduledPaymentsUseCase) Execute(ctx context.Context) (int, error) {
+	now := time.Now()
+	due, err := uc.scheduledRepo.ClaimDue(ctx, now, now.Add(-scheduledPaymentStaleAfter), uc.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	executed := 0
+	for _, scheduled := range due {
+		uc.run(ctx, scheduled)
+		if err := uc.scheduledRepo.Update(ctx, scheduled); err != nil {
+			return executed, err
+		}
+		if scheduled.Status != domain.ScheduledPaymentScheduled {
+			executed++
+		}
+	}
+	return executed, nil
+}
+
+func (uc *ExecuteScheduledPaymentsUseCase) run(ctx context.Context, scheduled *domain.ScheduledPayment) {
+	req := scheduled.Request
+	req.ScheduledAt = nil
+
+	result, err := uc.createPayment.Execute(ctx, scheduled.IdempotencyKey, req)
+	now := time.Now()
+	scheduled.Attempts++
+	scheduled.UpdatedAt = now
+
+	if err == nil {
+		scheduled.Status = domain.ScheduledPaymentExecuted
+		scheduled.PaymentID = &result.Payment.ID
+		scheduled.ExecutedAt = &now
+		scheduled.ErrorCode, scheduled.ErrorMessage = "", ""
+		return
+	}
+
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) {
+		appErr = apperrors.ErrInternal()
+	}
+	scheduled.ErrorCode, scheduled.ErrorMessage = appErr.Code, appErr.Message
+
+	retryable := appErr.HTTPCode >= http.StatusInternalServerError || appErr.Code == apperrors.ErrPaymentProcessing().Code
+	if retryable && scheduled.Attempts < scheduledPaymentMaxAttempts {
+		scheduled.Status = domain.ScheduledPaymentScheduled
+		scheduled.ScheduledAt = now.Add(exponentialBackoff(scheduledPaymentRetryBase, scheduledPaymentRetryMax, scheduled.Attempts))
+		return
+	}
+	scheduled.Status = domain.ScheduledPaymentFailed
+}
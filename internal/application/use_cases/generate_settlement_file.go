@@ -0,0 +1,37 @@
+package use_cases
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type GenerateSettlementFileUseCase struct {
+	generator domain.SettlementFileGenerator
+}
+
+func NewGenerateSettlementFileUseCase(processor domain.PaymentProcessor) *GenerateSettlementFileUseCase {
+	generator, _ := processor.(domain.SettlementFileGenerator)
+	return &GenerateSettlementFileUseCase{
+		generator: generator,
+	}
+}
+
+func (uc *GenerateSettlementFileUseCase) Execute(ctx context.Context, settlementDate string) ([]byte, error) {
+	if uc.generator == nil {
+		return nil, apperrors.ErrSettlementFileUnavailable()
+	}
+
+	day, err := parseSettlementDate(settlementDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := uc.generator.GenerateSettlementFile(ctx, day, &buf); err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,29 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type GetCustomerUseCase struct {
+	customerRepo domain.CustomerRepository
+}
+
+func NewGetCustomerUseCase(customerRepo domain.CustomerRepository) *GetCustomerUseCase {
+	return &GetCustomerUseCase{
+		customerRepo: customerRepo,
+	}
+}
+
+func (uc *GetCustomerUseCase) Execute(ctx context.Context, id string) (*domain.Customer, error) {
+	customer, err := uc.customerRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if customer == nil {
+		return nil, apperrors.ErrCustomerNotFound()
+	}
+	return customer, nil
+}
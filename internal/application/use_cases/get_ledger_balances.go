@@ -0,0 +1,63 @@
+package use_cases
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type LedgerBalanceQuery struct {
+	AccountID string
+	Currency  string
+	AsOf      string
+}
+
+type GetLedgerBalancesUseCase struct {
+	ledgerRepo domain.LedgerRepository
+}
+
+func NewGetLedgerBalancesUseCase(ledgerRepo domain.LedgerRepository) *GetLedgerBalancesUseCase {
+	return &GetLedgerBalancesUseCase{
+		ledgerRepo: ledgerRepo,
+	}
+}
+
+func (uc *GetLedgerBalancesUseCase) Execute(ctx context.Context, query LedgerBalanceQuery) ([]*domain.AccountBalance, error) {
+	filter := domain.LedgerBalanceFilter{AccountID: query.AccountID, AsOf: time.Now()}
+
+	if query.AsOf != "" {
+		asOf, err := time.Parse(time.RFC3339, query.AsOf)
+		if err != nil {
+			return nil, apperrors.ErrInvalidLedgerQuery("as_of must be an RFC 3339 timestamp")
+		}
+		filter.AsOf = asOf
+	}
+
+	if query.Currency != "" {
+		currency := domain.Currency(strings.ToUpper(query.Currency))
+		if _, ok := domain.Currencies().Lookup(currency); !ok {
+			return nil, apperrors.ErrInvalidCurrency(query.Currency, domain.Currencies().EnabledCodes())
+		}
+		filter.Currency = currency
+	}
+
+	balances, err := uc.ledgerRepo.Balances(ctx, filter)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if filter.AccountID != "" && len(balances) == 0 {
+		return nil, apperrors.ErrLedgerAccountNotFound()
+	}
+
+	for _, balance := range balances {
+		balance.AsOf = filter.AsOf
+		balance.ApplyNormalSide()
+	}
+	if balances == nil {
+		balances = []*domain.AccountBalance{}
+	}
+	return balances, nil
+}
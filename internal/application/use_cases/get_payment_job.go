@@ -0,0 +1,43 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type GetPaymentJobUseCase struct {
+	jobRepo domain.PaymentJobRepository
+}
+
+func NewGetPaymentJobUseCase(jobRepo domain.PaymentJobRepository) *GetPaymentJobUseCase {
+	return &GetPaymentJobUseCase{
+		jobRepo: jobRepo,
+	}
+}
+
+func (uc *GetPaymentJobUseCase) Execute(ctx context.Context, id string) (*domain.PaymentJob, error) {
+	job, err := uc.jobRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if job == nil {
+		return nil, apperrors.ErrPaymentJobNotFound()
+	}
+	if job.Status == domain.PaymentJobCompleted {
+		job.ResultURL = "/v1/jobs/" + job.ID + "/result"
+	}
+	return job, nil
+}
+
+func (uc *GetPaymentJobUseCase) Result(ctx context.Context, id string) ([]byte, error) {
+	job, err := uc.Execute(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != domain.PaymentJobCompleted {
+		return nil, apperrors.ErrPaymentJobNotFinished()
+	}
+	return []byte(job.Result), nil
+}
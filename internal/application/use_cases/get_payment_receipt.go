@@ -0,0 +1,82 @@
+package use_cases
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/utils/language"
+)
+
+var tenantIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+type ReceiptQuery struct {
+	Language string
+	Format   string
+	Tenant   string
+}
+
+type RenderedReceipt struct {
+	Format   domain.ReceiptFormat
+	Language string
+	Body     []byte
+}
+
+type GetPaymentReceiptUseCase struct {
+	paymentRepo domain.PaymentRepository
+	ledgerRepo  domain.LedgerRepository
+	renderer    domain.ReceiptRenderer
+}
+
+func NewGetPaymentReceiptUseCase(
+	paymentRepo domain.PaymentRepository,
+	ledgerRepo domain.LedgerRepository,
+	renderer domain.ReceiptRenderer,
+) *GetPaymentReceiptUseCase {
+	return &GetPaymentReceiptUseCase{
+		paymentRepo: paymentRepo,
+		ledgerRepo:  ledgerRepo,
+		renderer:    renderer,
+	}
+}
+
+func (uc *GetPaymentReceiptUseCase) Execute(ctx context.Context, paymentID string, query ReceiptQuery) (*RenderedReceipt, error) {
+	format := domain.ReceiptFormat(strings.ToLower(query.Format))
+	switch format {
+	case "":
+		format = domain.ReceiptFormatHTML
+	case domain.ReceiptFormatHTML, domain.ReceiptFormatText:
+	default:
+		return nil, apperrors.ErrInvalidReceiptRequest("format must be html or text")
+	}
+	if query.Tenant != "" && !tenantIDPattern.MatchString(query.Tenant) {
+		return nil, apperrors.ErrInvalidReceiptRequest("tenant must be 1-64 letters, digits, '-' or '_'")
+	}
+
+	payment, err := uc.paymentRepo.FindByID(ctx, paymentID)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if payment == nil {
+		return nil, apperrors.ErrPaymentNotFound()
+	}
+
+	entries, err := uc.ledgerRepo.ListEntriesByPayment(ctx, payment.ID)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+
+	receipt := domain.NewReceipt(payment, entries, language.Base(query.Language), time.Now())
+	if !receipt.Available() {
+		return nil, apperrors.ErrReceiptUnavailable(string(payment.Status))
+	}
+
+	body, err := uc.renderer.Render(query.Tenant, format, receipt)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	return &RenderedReceipt{Format: format, Language: receipt.Language, Body: body}, nil
+}
@@ -0,0 +1,29 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type GetPlanUseCase struct {
+	planRepo domain.SubscriptionPlanRepository
+}
+
+func NewGetPlanUseCase(planRepo domain.SubscriptionPlanRepository) *GetPlanUseCase {
+	return &GetPlanUseCase{
+		planRepo: planRepo,
+	}
+}
+
+func (uc *GetPlanUseCase) Execute(ctx context.Context, id string) (*domain.SubscriptionPlan, error) {
+	plan, err := uc.planRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if plan == nil {
+		return nil, apperrors.ErrPlanNotFound()
+	}
+	return plan, nil
+}
@@ -0,0 +1,32 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type GetReconciliationUseCase struct {
+	reconciliationRepo domain.ReconciliationRepository
+}
+
+func NewGetReconciliationUseCase(reconciliationRepo domain.ReconciliationRepository) *GetReconciliationUseCase {
+	return &GetReconciliationUseCase{
+		reconciliationRepo: reconciliationRepo,
+	}
+}
+
+func (uc *GetReconciliationUseCase) Execute(ctx context.Context, id string) (*domain.Reconciliation, error) {
+	reconciliation, err := uc.reconciliationRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if reconciliation == nil {
+		return nil, apperrors.ErrReconciliationNotFound()
+	}
+	if reconciliation.Items == nil {
+		reconciliation.Items = []*domain.ReconciliationItem{}
+	}
+	return reconciliation, nil
+}
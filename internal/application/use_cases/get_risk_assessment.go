@@ -0,0 +1,32 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type GetRiskAssessmentUseCase struct {
+	riskRepo domain.RiskAssessmentRepository
+}
+
+func NewGetRiskAssessmentUseCase(riskRepo domain.RiskAssessmentRepository) *GetRiskAssessmentUseCase {
+	return &GetRiskAssessmentUseCase{
+		riskRepo: riskRepo,
+	}
+}
+
+func (uc *GetRiskAssessmentUseCase) Execute(ctx context.Context, paymentID string) (*domain.RiskAssessment, error) {
+	assessment, err := uc.riskRepo.FindByPaymentID(ctx, paymentID)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if assessment == nil {
+		return nil, apperrors.ErrRiskAssessmentNotFound()
+	}
+	if assessment.Triggers == nil {
+		assessment.Triggers = []domain.RiskTrigger{}
+	}
+	return assessment, nil
+}
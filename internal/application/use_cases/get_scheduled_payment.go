@@ -0,0 +1,29 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type GetScheduledPaymentUseCase struct {
+	scheduledRepo domain.ScheduledPaymentRepository
+}
+
+func NewGetScheduledPaymentUseCase(scheduledRepo domain.ScheduledPaymentRepository) *GetScheduledPaymentUseCase {
+	return &GetScheduledPaymentUseCase{
+		scheduledRepo: scheduledRepo,
+	}
+}
+
+func (uc *GetScheduledPaymentUseCase) Execute(ctx context.Context, id string) (*domain.ScheduledPayment, error) {
+	scheduled, err := uc.scheduledRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if scheduled == nil {
+		return nil, apperrors.ErrScheduledPaymentNotFound()
+	}
+	return scheduled, nil
+}
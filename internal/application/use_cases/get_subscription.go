@@ -0,0 +1,29 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type GetSubscriptionUseCase struct {
+	subscriptionRepo domain.SubscriptionRepository
+}
+
+func NewGetSubscriptionUseCase(subscriptionRepo domain.SubscriptionRepository) *GetSubscriptionUseCase {
+	return &GetSubscriptionUseCase{
+		subscriptionRepo: subscriptionRepo,
+	}
+}
+
+func (uc *GetSubscriptionUseCase) Execute(ctx context.Context, id string) (*domain.Subscription, error) {
+	sub, err := uc.subscriptionRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if sub == nil {
+		return nil, apperrors.ErrSubscriptionNotFound()
+	}
+	return sub, nil
+}
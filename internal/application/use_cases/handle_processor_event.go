@@ -0,0 +1,161 @@
+package use_cases
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/utils/signature"
+)
+
+type HandleProcessorEventResult struct {
+	Event     *domain.ProcessorEvent
+	Duplicate bool
+}
+
+type HandleProcessorEventUseCase struct {
+	txManager          domain.TransactionManager
+	processorEventRepo domain.ProcessorEventRepository
+	paymentRepo        domain.PaymentRepository
+	updater            *paymentStatusUpdater
+	secret             string
+	tolerance          time.Duration
+}
+
+func NewHandleProcessorEventUseCase(
+	txManager domain.TransactionManager,
+	processorEventRepo domain.ProcessorEventRepository,
+	idempotencyRepo domain.IdempotencyRepository,
+	paymentRepo domain.PaymentRepository,
+	outboxRepo domain.OutboxRepository,
+	secret string,
+	tolerance time.Duration,
+	opts ...PaymentStatusOption,
+) *HandleProcessorEventUseCase {
+	return &HandleProcessorEventUseCase{
+		txManager:          txManager,
+		processorEventRepo: processorEventRepo,
+		paymentRepo:        paymentRepo,
+		updater:            newPaymentStatusUpdater(idempotencyRepo, paymentRepo, outboxRepo, opts...),
+		secret:             secret,
+		tolerance:          tolerance,
+	}
+}
+
+func (uc *HandleProcessorEventUseCase) Execute(ctx context.Context, body []byte, sig, timestamp string) (*HandleProcessorEventResult, error) {
+	if err := signature.Verify(uc.secret, sig, timestamp, body, uc.tolerance, time.Now()); err != nil {
+		return nil, apperrors.ErrInvalidProcessorSignature()
+	}
+
+	var req domain.ProcessorEventRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, apperrors.ErrInvalidProcessorEvent("invalid request body")
+	}
+	if err := validateProcessorEventRequest(req); err != nil {
+		return nil, err
+	}
+
+	var result *HandleProcessorEventResult
+	var returnErr error
+
+	txErr := uc.txManager.RunInTransaction(ctx, func(txCtx context.Context) error {
+		event := &domain.ProcessorEvent{
+			EventID:    req.EventID,
+			PaymentID:  req.PaymentID,
+			Status:     req.Status,
+			FailReason: req.FailReason,
+			Outcome:    domain.ProcessorEventOutcomeIgnored,
+			ReceivedAt: time.Now(),
+		}
+		created, err := uc.processorEventRepo.CreateIfAbsent(txCtx, event)
+		if err != nil {
+			returnErr = apperrors.ErrInternal()
+			return err
+		}
+
+		if !created {
+			existing, err := uc.processorEventRepo.FindByEventID(txCtx, req.EventID)
+			if err != nil {
+				returnErr = apperrors.ErrInternal()
+				return err
+			}
+			if existing == nil {
+				returnErr = apperrors.ErrInternal()
+				return returnErr
+			}
+			result = &HandleProcessorEventResult{Event: existing, Duplicate: true}
+			return nil
+		}
+
+		update := domain.PaymentStatusUpdate{Status: req.Status, FailReason: req.FailReason}
+		payment, applied, err := uc.updater.apply(txCtx, req.PaymentID, update)
+		if err == nil && payment == nil {
+			payment, applied, err = uc.applyByProcessorID(txCtx, event, update)
+		}
+		if err != nil {
+			returnErr = apperrors.ErrInternal()
+			return err
+		}
+		if payment == nil {
+			returnErr = apperrors.ErrPaymentNotFound()
+			return returnErr
+		}
+
+		if applied {
+			event.Outcome = domain.ProcessorEventOutcomeApplied
+			if err := uc.processorEventRepo.Update(txCtx, event); err != nil {
+				returnErr = apperrors.ErrInternal()
+				return err
+			}
+		}
+
+		result = &HandleProcessorEventResult{Event: event, Duplicate: false}
+		return nil
+	})
+
+	if txErr != nil && returnErr != nil {
+		return nil, returnErr
+	}
+	if txErr != nil {
+		return nil, apperrors.ErrInternal()
+	}
+
+	return result, nil
+}
+
+func (uc *HandleProcessorEventUseCase) applyByProcessorID(
+	ctx context.Context,
+	event *domain.ProcessorEvent,
+	update domain.PaymentStatusUpdate,
+) (*domain.Payment, bool, error) {
+	payment, err := uc.paymentRepo.FindByProcessorReference(ctx, event.PaymentID)
+	if err == nil && payment == nil {
+		payment, err = uc.paymentRepo.FindByProcessorKey(ctx, event.PaymentID)
+	}
+	if err != nil || payment == nil {
+		return nil, false, err
+	}
+
+	event.PaymentID = payment.ID
+	return uc.updater.apply(ctx, payment.ID, update)
+}
+
+func validateProcessorEventRequest(req domain.ProcessorEventRequest) error {
+	if req.EventID == "" {
+		return apperrors.ErrInvalidProcessorEvent("event_id is required")
+	}
+	if len(req.EventID) > 100 {
+		return apperrors.ErrInvalidProcessorEvent("event_id must be at most 100 characters")
+	}
+	if req.PaymentID == "" {
+		return apperrors.ErrInvalidProcessorEvent("payment_id is required")
+	}
+	switch req.Status {
+	case domain.PaymentStatusSucceeded, domain.PaymentStatusFailed, domain.PaymentStatusPending:
+		return nil
+	default:
+		return apperrors.ErrInvalidProcessorEvent("status must be one of SUCCEEDED, FAILED, PENDING")
+	}
+}
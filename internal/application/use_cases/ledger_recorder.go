@@ -0,0 +1,185 @@
+package use_cases
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+)
+
+type LedgerRecorder struct {
+	ledgerRepo     domain.LedgerRepository
+	feeBasisPoints int64
+}
+
+func NewLedgerRecorder(ledgerRepo domain.LedgerRepository, feeBasisPoints int64) *LedgerRecorder {
+	return &LedgerRecorder{
+		ledgerRepo:     ledgerRepo,
+		feeBasisPoints: feeBasisPoints,
+	}
+}
+
+func (l *LedgerRecorder) recordCharge(ctx context.Context, payment *domain.Payment) error {
+	if l == nil || (payment.Status != domain.PaymentStatusSucceeded && payment.Status != domain.PaymentStatusPending) {
+		return nil
+	}
+
+	now := time.Now()
+	receivable := domain.NewLedgerAccount(domain.AccountProcessorReceivable, domain.LedgerAccountAsset, payment.Currency)
+	accounts := []*domain.LedgerAccount{receivable}
+
+	charge := newJournalEntry(payment.ID, domain.JournalEntryCharge, fmt.Sprintf("charge for ride %s", payment.RideID), now)
+	charge.Debit(receivable, payment.Amount)
+	switch {
+	case payment.ParentPaymentID != "":
+		tips := domain.NewLedgerAccount(domain.AccountTipsPayable, domain.LedgerAccountLiability, payment.Currency)
+		accounts = append(accounts, tips)
+		charge.Credit(tips, payment.Amount)
+	case len(payment.Splits) > 0:
+		for _, split := range payment.Splits {
+			if split.Amount == 0 {
+				continue
+			}
+			recipient := domain.NewLedgerAccount(domain.AccountRecipientPrefix+split.Recipient, domain.LedgerAccountLiability, payment.Currency)
+			accounts = append(accounts, recipient)
+			charge.Credit(recipient, split.Amount)
+		}
+	default:
+		merchant := domain.NewLedgerAccount(domain.AccountMerchantPayable, domain.LedgerAccountLiability, payment.Currency)
+		accounts = append(accounts, merchant)
+		charge.Credit(merchant, payment.Amount)
+	}
+	entries := []*domain.JournalEntry{charge}
+
+	if fee := payment.Amount * l.feeBasisPoints / 10000; fee > 0 {
+		fees := domain.NewLedgerAccount(domain.AccountProcessingFees, domain.LedgerAccountExpense, payment.Currency)
+		accounts = append(accounts, fees)
+
+		feeEntry := newJournalEntry(payment.ID, domain.JournalEntryFee, "processor fee", now)
+		feeEntry.Debit(fees, fee)
+		feeEntry.Credit(receivable, fee)
+		entries = append(entries, feeEntry)
+	}
+
+	if err := l.ledgerRepo.EnsureAccounts(ctx, accounts...); err != nil {
+		return err
+	}
+	return l.createEntries(ctx, entries...)
+}
+
+func (l *LedgerRecorder) recordReversal(ctx context.Context, payment *domain.Payment) error {
+	if l == nil {
+		return nil
+	}
+
+	entries, err := l.ledgerRepo.ListEntriesByPayment(ctx, payment.ID)
+	if err != nil {
+		return err
+	}
+
+	var order []*domain.LedgerAccount
+	net := make(map[string]int64)
+	for _, entry := range entries {
+		for _, posting := range entry.Postings {
+			if _, seen := net[posting.AccountID]; !seen {
+				order = append(order, &domain.LedgerAccount{ID: posting.AccountID, Currency: posting.Currency})
+			}
+			net[posting.AccountID] += posting.Amount
+		}
+	}
+
+	reversal := newJournalEntry(payment.ID, domain.JournalEntryReversal, "reversal of failed payment", time.Now())
+	for _, account := range order {
+		if amount := net[account.ID]; amount != 0 {
+			reversal.Credit(account, amount)
+		}
+	}
+	if len(reversal.Postings) == 0 {
+		return nil
+	}
+	return l.createEntries(ctx, reversal)
+}
+
+func (l *LedgerRecorder) recordRefund(ctx context.Context, payment *domain.Payment, amount int64) error {
+	if l == nil {
+		return nil
+	}
+
+	entries, err := l.ledgerRepo.ListEntriesByPayment(ctx, payment.ID)
+	if err != nil {
+		return err
+	}
+
+	var payables []*domain.LedgerAccount
+	owed := make(map[string]int64)
+	for _, entry := range entries {
+		for _, posting := range entry.Postings {
+			if !domain.IsPayableAccount(posting.AccountID) {
+				continue
+			}
+			if _, seen := owed[posting.AccountID]; !seen {
+				payables = append(payables, &domain.LedgerAccount{ID: posting.AccountID, Currency: posting.Currency})
+			}
+			owed[posting.AccountID] -= posting.Amount
+		}
+	}
+
+	var totalOwed int64
+	for _, account := range payables {
+		totalOwed += max(owed[account.ID], 0)
+	}
+	if totalOwed == 0 {
+		return nil
+	}
+	amount = min(amount, totalOwed)
+
+	shares := make([]int64, len(payables))
+	allocated := int64(0)
+	for i, account := range payables {
+		share := new(big.Int).Mul(big.NewInt(amount), big.NewInt(max(owed[account.ID], 0)))
+		share.Quo(share, big.NewInt(totalOwed))
+		shares[i] = share.Int64()
+		allocated += shares[i]
+	}
+	for i := 0; allocated < amount; i = (i + 1) % len(payables) {
+		if shares[i] < owed[payables[i].ID] {
+			shares[i]++
+			allocated++
+		}
+	}
+
+	receivable := domain.NewLedgerAccount(domain.AccountProcessorReceivable, domain.LedgerAccountAsset, payment.Currency)
+	refund := newJournalEntry(payment.ID, domain.JournalEntryRefund, fmt.Sprintf("refund for ride %s", payment.RideID), time.Now())
+	for i, account := range payables {
+		if shares[i] != 0 {
+			refund.Debit(account, shares[i])
+		}
+	}
+	refund.Credit(receivable, amount)
+	return l.createEntries(ctx, refund)
+}
+
+func (l *LedgerRecorder) createEntries(ctx context.Context, entries ...*domain.JournalEntry) error {
+	for _, entry := range entries {
+		if err := entry.Validate(); err != nil {
+			return err
+		}
+		if err := l.ledgerRepo.CreateEntry(ctx, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newJournalEntry(paymentID string, entryType domain.JournalEntryType, description string, at time.Time) *domain.JournalEntry {
+	return &domain.JournalEntry{
+		ID:          uuid.Must(uuid.NewV7()).String(),
+		PaymentID:   paymentID,
+		Type:        entryType,
+		Description: description,
+		CreatedAt:   at,
+	}
+}
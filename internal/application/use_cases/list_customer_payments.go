@@ -0,0 +1,47 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type ListCustomerPaymentsUseCase struct {
+	customerRepo domain.CustomerRepository
+	paymentRepo  domain.PaymentRepository
+	listPayments *ListPaymentsUseCase
+}
+
+func NewListCustomerPaymentsUseCase(customerRepo domain.CustomerRepository, paymentRepo domain.PaymentRepository) *ListCustomerPaymentsUseCase {
+	return &ListCustomerPaymentsUseCase{
+		customerRepo: customerRepo,
+		paymentRepo:  paymentRepo,
+		listPayments: NewListPaymentsUseCase(paymentRepo),
+	}
+}
+
+func (uc *ListCustomerPaymentsUseCase) Execute(ctx context.Context, customerID string, query ListPaymentsQuery) (*domain.CustomerPayments, error) {
+	customer, err := uc.customerRepo.FindByID(ctx, customerID)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if customer == nil {
+		return nil, apperrors.ErrCustomerNotFound()
+	}
+
+	query.CustomerID = customer.ID
+	page, err := uc.listPayments.Execute(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := uc.paymentRepo.TotalsByCurrency(ctx, customer.ID, domain.PaymentStatusSucceeded)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if totals == nil {
+		totals = []*domain.CurrencyTotal{}
+	}
+	return &domain.CustomerPayments{PaymentPage: *page, Totals: totals}, nil
+}
@@ -0,0 +1,39 @@
+package use_cases
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type ListCustomersUseCase struct {
+	customerRepo domain.CustomerRepository
+}
+
+func NewListCustomersUseCase(customerRepo domain.CustomerRepository) *ListCustomersUseCase {
+	return &ListCustomersUseCase{
+		customerRepo: customerRepo,
+	}
+}
+
+func (uc *ListCustomersUseCase) Execute(ctx context.Context, limitParam string) ([]*domain.Customer, error) {
+	limit := defaultPaymentPageSize
+	if limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed < 1 || parsed > maxPaymentPageSize {
+			return nil, apperrors.ErrInvalidCustomer("limit must be between 1 and 100")
+		}
+		limit = parsed
+	}
+
+	customers, err := uc.customerRepo.List(ctx, limit)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if customers == nil {
+		customers = []*domain.Customer{}
+	}
+	return customers, nil
+}
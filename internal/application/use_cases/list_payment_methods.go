@@ -0,0 +1,29 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type ListPaymentMethodsUseCase struct {
+	paymentMethodRepo domain.PaymentMethodRepository
+}
+
+func NewListPaymentMethodsUseCase(paymentMethodRepo domain.PaymentMethodRepository) *ListPaymentMethodsUseCase {
+	return &ListPaymentMethodsUseCase{
+		paymentMethodRepo: paymentMethodRepo,
+	}
+}
+
+func (uc *ListPaymentMethodsUseCase) Execute(ctx context.Context, customerID string) ([]*domain.PaymentMethod, error) {
+	methods, err := uc.paymentMethodRepo.ListByCustomer(ctx, customerID)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if methods == nil {
+		methods = []*domain.PaymentMethod{}
+	}
+	return methods, nil
+}
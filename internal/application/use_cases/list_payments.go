@@ -0,0 +1,152 @@
+package use_cases
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+const (
+	defaultPaymentPageSize = 20
+	maxPaymentPageSize     = 100
+)
+
+type ListPaymentsQuery struct {
+	CustomerID  string
+	RideID      string
+	Status      string
+	Currency    string
+	CreatedFrom string
+	CreatedTo   string
+	Cursor      string
+	Limit       string
+}
+
+type ListPaymentsUseCase struct {
+	paymentRepo domain.PaymentRepository
+}
+
+func NewListPaymentsUseCase(paymentRepo domain.PaymentRepository) *ListPaymentsUseCase {
+	return &ListPaymentsUseCase{
+		paymentRepo: paymentRepo,
+	}
+}
+
+func (uc *ListPaymentsUseCase) Execute(ctx context.Context, query ListPaymentsQuery) (*domain.PaymentPage, error) {
+	filter, err := parsePaymentFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	filter.Limit = limit + 1
+
+	payments, err := uc.paymentRepo.List(ctx, filter)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+
+	page := &domain.PaymentPage{Data: payments}
+	if len(payments) > limit {
+		page.Data = payments[:limit]
+		page.HasMore = true
+		last := page.Data[limit-1]
+		page.NextCursor = encodePaymentCursor(domain.PaymentCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+	if page.Data == nil {
+		page.Data = []*domain.Payment{}
+	}
+	return page, nil
+}
+
+func parsePaymentFilter(query ListPaymentsQuery) (domain.PaymentFilter, error) {
+	filter := domain.PaymentFilter{
+		CustomerID: query.CustomerID,
+		RideID:     query.RideID,
+		Limit:      defaultPaymentPageSize,
+	}
+
+	if query.Status != "" {
+		status := domain.PaymentStatus(strings.ToUpper(query.Status))
+		switch status {
+		case domain.PaymentStatusSucceeded, domain.PaymentStatusFailed, domain.PaymentStatusPending, domain.PaymentStatusBlocked, domain.PaymentStatusUnknown:
+			filter.Status = status
+		default:
+			return filter, apperrors.ErrInvalidPaymentQuery("status must be one of SUCCEEDED, FAILED, PENDING, BLOCKED, UNKNOWN")
+		}
+	}
+
+	if query.Currency != "" {
+		currency := domain.Currency(strings.ToUpper(query.Currency))
+		if _, ok := domain.Currencies().Lookup(currency); !ok {
+			return filter, apperrors.ErrInvalidCurrency(query.Currency, domain.Currencies().EnabledCodes())
+		}
+		filter.Currency = currency
+	}
+
+	if query.CreatedFrom != "" {
+		from, err := time.Parse(time.RFC3339, query.CreatedFrom)
+		if err != nil {
+			return filter, apperrors.ErrInvalidPaymentQuery("created_from must be an RFC 3339 timestamp")
+		}
+		filter.CreatedFrom = &from
+	}
+
+	if query.CreatedTo != "" {
+		to, err := time.Parse(time.RFC3339, query.CreatedTo)
+		if err != nil {
+			return filter, apperrors.ErrInvalidPaymentQuery("created_to must be an RFC 3339 timestamp")
+		}
+		filter.CreatedTo = &to
+	}
+
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		return filter, apperrors.ErrInvalidPaymentQuery("created_from must be before created_to")
+	}
+
+	if query.Limit != "" {
+		limit, err := strconv.Atoi(query.Limit)
+		if err != nil || limit < 1 || limit > maxPaymentPageSize {
+			return filter, apperrors.ErrInvalidPaymentQuery("limit must be between 1 and 100")
+		}
+		filter.Limit = limit
+	}
+
+	if query.Cursor != "" {
+		cursor, err := decodePaymentCursor(query.Cursor)
+		if err != nil {
+			return filter, apperrors.ErrInvalidPaymentQuery("cursor is invalid")
+		}
+		filter.After = cursor
+	}
+
+	return filter, nil
+}
+
+func encodePaymentCursor(cursor domain.PaymentCursor) string {
+	raw := cursor.CreatedAt.Format(time.RFC3339Nano) + "|" + cursor.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodePaymentCursor(value string) (*domain.PaymentCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return nil, apperrors.ErrInvalidPaymentQuery("cursor is invalid")
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PaymentCursor{CreatedAt: createdAt, ID: parts[1]}, nil
+}
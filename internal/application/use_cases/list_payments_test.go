@@ -0,0 +1,74 @@
+package use_cases
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentCursorRoundTrip(t *testing.T) {
+	cursor := domain.PaymentCursor{
+		CreatedAt: time.Date(2026, 2, 24, 10, 30, 0, 123456000, time.UTC),
+		ID:        "pay-123",
+	}
+
+	decoded, err := decodePaymentCursor(encodePaymentCursor(cursor))
+	require.NoError(t, err)
+	assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+	assert.Equal(t, cursor.ID, decoded.ID)
+}
+
+func TestDecodePaymentCursor_Invalid(t *testing.T) {
+	_, err := decodePaymentCursor("!!!")
+	assert.Error(t, err)
+
+	_, err = decodePaymentCursor("bm90LWEtY3Vyc29y")
+	assert.Error(t, err)
+}
+
+func TestParsePaymentFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   ListPaymentsQuery
+		errCode string
+	}{
+		{name: "defaults", query: ListPaymentsQuery{}},
+		{name: "all filters", query: ListPaymentsQuery{
+			CustomerID:  "cust-1",
+			RideID:      "ride-1",
+			Status:      "succeeded",
+			Currency:    "idr",
+			CreatedFrom: "2026-01-01T00:00:00Z",
+			CreatedTo:   "2026-02-01T00:00:00Z",
+			Limit:       "50",
+		}},
+		{name: "unknown status", query: ListPaymentsQuery{Status: "REFUNDED"}, errCode: "INVALID_PAYMENT_QUERY"},
+		{name: "unsupported currency", query: ListPaymentsQuery{Currency: "USD"}, errCode: "INVALID_CURRENCY"},
+		{name: "bad created_from", query: ListPaymentsQuery{CreatedFrom: "yesterday"}, errCode: "INVALID_PAYMENT_QUERY"},
+		{name: "inverted range", query: ListPaymentsQuery{
+			CreatedFrom: "2026-02-01T00:00:00Z",
+			CreatedTo:   "2026-01-01T00:00:00Z",
+		}, errCode: "INVALID_PAYMENT_QUERY"},
+		{name: "limit too large", query: ListPaymentsQuery{Limit: "101"}, errCode: "INVALID_PAYMENT_QUERY"},
+		{name: "limit not a number", query: ListPaymentsQuery{Limit: "ten"}, errCode: "INVALID_PAYMENT_QUERY"},
+		{name: "bad cursor", query: ListPaymentsQuery{Cursor: "garbage"}, errCode: "INVALID_PAYMENT_QUERY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := parsePaymentFilter(tt.query)
+			if tt.errCode == "" {
+				assert.NoError(t, err)
+				assert.Greater(t, filter.Limit, 0)
+				return
+			}
+			appErr, ok := err.(*apperrors.AppError)
+			require.True(t, ok)
+			assert.Equal(t, tt.errCode, appErr.Code)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package use_cases
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type ListPlansUseCase struct {
+	planRepo domain.SubscriptionPlanRepository
+}
+
+func NewListPlansUseCase(planRepo domain.SubscriptionPlanRepository) *ListPlansUseCase {
+	return &ListPlansUseCase{
+		planRepo: planRepo,
+	}
+}
+
+func (uc *ListPlansUseCase) Execute(ctx context.Context, limitParam string) ([]*domain.SubscriptionPlan, error) {
+	limit := defaultPaymentPageSize
+	if limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed < 1 || parsed > maxPaymentPageSize {
+			return nil, apperrors.ErrInvalidPaymentQuery("limit must be between 1 and 100")
+		}
+		limit = parsed
+	}
+
+	plans, err := uc.planRepo.List(ctx, limit)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if plans == nil {
+		plans = []*domain.SubscriptionPlan{}
+	}
+	return plans, nil
+}
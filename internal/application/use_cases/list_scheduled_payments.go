@@ -0,0 +1,60 @@
+package use_cases
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type ListScheduledPaymentsQuery struct {
+	CustomerID string
+	Status     string
+	Limit      string
+}
+
+type ListScheduledPaymentsUseCase struct {
+	scheduledRepo domain.ScheduledPaymentRepository
+}
+
+func NewListScheduledPaymentsUseCase(scheduledRepo domain.ScheduledPaymentRepository) *ListScheduledPaymentsUseCase {
+	return &ListScheduledPaymentsUseCase{
+		scheduledRepo: scheduledRepo,
+	}
+}
+
+func (uc *ListScheduledPaymentsUseCase) Execute(ctx context.Context, query ListScheduledPaymentsQuery) ([]*domain.ScheduledPayment, error) {
+	filter := domain.ScheduledPaymentFilter{
+		CustomerID: query.CustomerID,
+		Limit:      defaultPaymentPageSize,
+	}
+
+	if query.Status != "" {
+		status := domain.ScheduledPaymentStatus(strings.ToUpper(query.Status))
+		switch status {
+		case domain.ScheduledPaymentScheduled, domain.ScheduledPaymentExecuting, domain.ScheduledPaymentExecuted,
+			domain.ScheduledPaymentCanceled, domain.ScheduledPaymentFailed:
+			filter.Status = status
+		default:
+			return nil, apperrors.ErrInvalidPaymentQuery("status must be one of SCHEDULED, EXECUTING, EXECUTED, CANCELED, FAILED")
+		}
+	}
+	if query.Limit != "" {
+		limit, err := strconv.Atoi(query.Limit)
+		if err != nil || limit < 1 || limit > maxPaymentPageSize {
+			return nil, apperrors.ErrInvalidPaymentQuery("limit must be between 1 and 100")
+		}
+		filter.Limit = limit
+	}
+
+	scheduled, err := uc.scheduledRepo.List(ctx, filter)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if scheduled == nil {
+		scheduled = []*domain.ScheduledPayment{}
+	}
+	return scheduled, nil
+}
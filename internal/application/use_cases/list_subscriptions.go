@@ -0,0 +1,59 @@
+package use_cases
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type ListSubscriptionsQuery struct {
+	CustomerID string
+	Status     string
+	Limit      string
+}
+
+type ListSubscriptionsUseCase struct {
+	subscriptionRepo domain.SubscriptionRepository
+}
+
+func NewListSubscriptionsUseCase(subscriptionRepo domain.SubscriptionRepository) *ListSubscriptionsUseCase {
+	return &ListSubscriptionsUseCase{
+		subscriptionRepo: subscriptionRepo,
+	}
+}
+
+func (uc *ListSubscriptionsUseCase) Execute(ctx context.Context, query ListSubscriptionsQuery) ([]*domain.Subscription, error) {
+	filter := domain.SubscriptionFilter{
+		CustomerID: query.CustomerID,
+		Limit:      defaultPaymentPageSize,
+	}
+
+	if query.Status != "" {
+		status := domain.SubscriptionStatus(strings.ToUpper(query.Status))
+		switch status {
+		case domain.SubscriptionActive, domain.SubscriptionPastDue, domain.SubscriptionPaused, domain.SubscriptionCanceled:
+			filter.Status = status
+		default:
+			return nil, apperrors.ErrInvalidPaymentQuery("status must be one of ACTIVE, PAST_DUE, PAUSED, CANCELED")
+		}
+	}
+	if query.Limit != "" {
+		limit, err := strconv.Atoi(query.Limit)
+		if err != nil || limit < 1 || limit > maxPaymentPageSize {
+			return nil, apperrors.ErrInvalidPaymentQuery("limit must be between 1 and 100")
+		}
+		filter.Limit = limit
+	}
+
+	subs, err := uc.subscriptionRepo.List(ctx, filter)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if subs == nil {
+		subs = []*domain.Subscription{}
+	}
+	return subs, nil
+}
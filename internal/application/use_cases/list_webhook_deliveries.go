@@ -0,0 +1,31 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+const webhookDeliveriesListLimit = 100
+
+type ListWebhookDeliveriesUseCase struct {
+	deliveryRepo domain.WebhookDeliveryRepository
+}
+
+func NewListWebhookDeliveriesUseCase(deliveryRepo domain.WebhookDeliveryRepository) *ListWebhookDeliveriesUseCase {
+	return &ListWebhookDeliveriesUseCase{
+		deliveryRepo: deliveryRepo,
+	}
+}
+
+func (uc *ListWebhookDeliveriesUseCase) Execute(ctx context.Context, status domain.WebhookDeliveryStatus) ([]*domain.WebhookDelivery, error) {
+	if status == "" {
+		status = domain.WebhookDeliveryStatusDead
+	}
+	deliveries, err := uc.deliveryRepo.FindByStatus(ctx, status, webhookDeliveriesListLimit)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,29 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type ListWebhookEndpointsUseCase struct {
+	endpointRepo domain.WebhookEndpointRepository
+}
+
+func NewListWebhookEndpointsUseCase(endpointRepo domain.WebhookEndpointRepository) *ListWebhookEndpointsUseCase {
+	return &ListWebhookEndpointsUseCase{
+		endpointRepo: endpointRepo,
+	}
+}
+
+func (uc *ListWebhookEndpointsUseCase) Execute(ctx context.Context) ([]*domain.WebhookEndpoint, error) {
+	endpoints, err := uc.endpointRepo.List(ctx)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	for _, endpoint := range endpoints {
+		endpoint.Secret = ""
+	}
+	return endpoints, nil
+}
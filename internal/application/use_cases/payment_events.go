@@ -0,0 +1,36 @@
+package use_cases
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+)
+
+func recordPaymentEvent(ctx context.Context, outboxRepo domain.OutboxRepository, payment *domain.Payment) error {
+	if outboxRepo == nil {
+		return nil
+	}
+
+	event := domain.PaymentEvent{
+		ID:        uuid.New().String(),
+		Type:      domain.PaymentEventType(payment.Status),
+		CreatedAt: time.Now(),
+		Data:      payment,
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return outboxRepo.Create(ctx, &domain.OutboxEvent{
+		ID:        event.ID,
+		EventType: event.Type,
+		PaymentID: payment.ID,
+		Payload:   payload,
+		Status:    domain.OutboxStatusPending,
+		CreatedAt: event.CreatedAt,
+	})
+}
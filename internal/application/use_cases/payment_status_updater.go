@@ -0,0 +1,117 @@
+package use_cases
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+)
+
+type paymentStatusUpdater struct {
+	idempotencyRepo domain.IdempotencyRepository
+	paymentRepo     domain.PaymentRepository
+	outboxRepo      domain.OutboxRepository
+	ledger          *LedgerRecorder
+}
+
+type PaymentStatusOption func(*paymentStatusUpdater)
+
+func WithStatusLedger(ledger *LedgerRecorder) PaymentStatusOption {
+	return func(u *paymentStatusUpdater) {
+		u.ledger = ledger
+	}
+}
+
+func newPaymentStatusUpdater(
+	idempotencyRepo domain.IdempotencyRepository,
+	paymentRepo domain.PaymentRepository,
+	outboxRepo domain.OutboxRepository,
+	opts ...PaymentStatusOption,
+) *paymentStatusUpdater {
+	u := &paymentStatusUpdater{
+		idempotencyRepo: idempotencyRepo,
+		paymentRepo:     paymentRepo,
+		outboxRepo:      outboxRepo,
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+func canTransition(from, to domain.PaymentStatus) bool {
+	switch from {
+	case domain.PaymentStatusPending:
+		return to != domain.PaymentStatusPending
+	case domain.PaymentStatusUnknown:
+		return to == domain.PaymentStatusSucceeded || to == domain.PaymentStatusFailed
+	default:
+		return false
+	}
+}
+
+func (u *paymentStatusUpdater) apply(ctx context.Context, paymentID string, update domain.PaymentStatusUpdate) (*domain.Payment, bool, error) {
+	current, err := u.paymentRepo.FindByIDForUpdate(ctx, paymentID)
+	if err != nil {
+		return nil, false, err
+	}
+	if current == nil {
+		return nil, false, nil
+	}
+	if !canTransition(current.Status, update.Status) {
+		return current, false, nil
+	}
+
+	previous := current.Status
+	current.Status = update.Status
+	current.FailReason = update.FailReason
+	current.NextStatusCheckAt = nil
+	if update.Processor != "" {
+		current.Processor = update.Processor
+	}
+	if update.ProcessorReference != "" && update.ProcessorReference != current.ID {
+		current.ProcessorReference = update.ProcessorReference
+	}
+	if err := u.paymentRepo.Update(ctx, current); err != nil {
+		return nil, false, err
+	}
+
+	if err := u.refreshCachedResponse(ctx, current); err != nil {
+		return nil, false, err
+	}
+
+	switch {
+	case previous == domain.PaymentStatusUnknown && current.Status == domain.PaymentStatusSucceeded:
+		if err := u.ledger.recordCharge(ctx, current); err != nil {
+			return nil, false, err
+		}
+	case current.Status == domain.PaymentStatusFailed:
+		if err := u.ledger.recordReversal(ctx, current); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if err := recordPaymentEvent(ctx, u.outboxRepo, current); err != nil {
+		return nil, false, err
+	}
+	return current, true, nil
+}
+
+func (u *paymentStatusUpdater) refreshCachedResponse(ctx context.Context, payment *domain.Payment) error {
+	records, err := u.idempotencyRepo.ListByPaymentID(ctx, payment.ID)
+	if err != nil || len(records) == 0 {
+		return err
+	}
+
+	responseBody, err := json.Marshal(payment)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		record.ResponseBody = responseBody
+		if err := u.idempotencyRepo.Update(ctx, record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package use_cases
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+)
+
+const paymentJobStaleAfter = 5 * time.Minute
+
+type ProcessPaymentJobsUseCase struct {
+	jobRepo       domain.PaymentJobRepository
+	createPayment *CreatePaymentUseCase
+	chunkSize     int
+	concurrency   int
+}
+
+func NewProcessPaymentJobsUseCase(
+	jobRepo domain.PaymentJobRepository,
+	createPayment *CreatePaymentUseCase,
+	chunkSize, concurrency int,
+) *ProcessPaymentJobsUseCase {
+	if chunkSize < 1 {
+		chunkSize = 1
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &ProcessPaymentJobsUseCase{
+		jobRepo:       jobRepo,
+		createPayment: createPayment,
+		chunkSize:     chunkSize,
+		concurrency:   concurrency,
+	}
+}
+
+func (uc *ProcessPaymentJobsUseCase) Execute(ctx context.Context) (int, error) {
+	finished := 0
+	for {
+		job, err := uc.jobRepo.ClaimNext(ctx, time.Now().Add(-paymentJobStaleAfter))
+		if err != nil {
+			return finished, err
+		}
+		if job == nil {
+			return finished, nil
+		}
+		if err := uc.process(ctx, job); err != nil {
+			return finished, err
+		}
+		finished++
+	}
+}
+
+func (uc *ProcessPaymentJobsUseCase) process(ctx context.Context, job *domain.PaymentJob) error {
+	now := time.Now()
+	if job.StartedAt == nil {
+		job.StartedAt = &now
+	}
+
+	rows, err := domain.ParsePaymentJobCSV(strings.NewReader(job.Input))
+	if err != nil {
+		job.Status = domain.PaymentJobFailed
+		job.Error = err.Error()
+		job.CompletedAt = &now
+		job.UpdatedAt = now
+		return uc.jobRepo.Update(ctx, job)
+	}
+
+	for job.ProcessedRows < len(rows) {
+		end := min(job.ProcessedRows+uc.chunkSize, len(rows))
+		chunk := rows[job.ProcessedRows:end]
+
+		items := make([]domain.BatchPaymentItem, len(chunk))
+		for i, row := range chunk {
+			items[i] = domain.BatchPaymentItem{IdempotencyKey: row.IdempotencyKey, PaymentRequest: row.Request}
+		}
+		results := runPayments(ctx, uc.createPayment, items, uc.concurrency)
+
+		response := domain.BatchPaymentResponse{Items: results}
+		response.Tally()
+		for i, result := range results {
+			result.Index = chunk[i].Line
+		}
+
+		var buf bytes.Buffer
+		if err := domain.WritePaymentJobResultCSV(&buf, job.Result == "", results); err != nil {
+			return err
+		}
+
+		job.Result += buf.String()
+		job.ProcessedRows = end
+		job.Summary.Merge(response.Summary)
+		job.UpdatedAt = time.Now()
+		if err := uc.jobRepo.Update(ctx, job); err != nil {
+			return err
+		}
+	}
+
+	completedAt := time.Now()
+	job.Status = domain.PaymentJobCompleted
+	job.CompletedAt = &completedAt
+	job.UpdatedAt = completedAt
+	return uc.jobRepo.Update(ctx, job)
+}
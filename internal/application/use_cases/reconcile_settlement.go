@@ -0,0 +1,169 @@
+package use_cases
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+const reconciliationPageSize = 500
+
+type ReconcileSettlementCommand struct {
+	SettlementDate string
+	FileName       string
+	Content        []byte
+}
+
+type ReconcileSettlementUseCase struct {
+	paymentRepo        domain.PaymentRepository
+	reconciliationRepo domain.ReconciliationRepository
+}
+
+func NewReconcileSettlementUseCase(
+	paymentRepo domain.PaymentRepository,
+	reconciliationRepo domain.ReconciliationRepository,
+) *ReconcileSettlementUseCase {
+	return &ReconcileSettlementUseCase{
+		paymentRepo:        paymentRepo,
+		reconciliationRepo: reconciliationRepo,
+	}
+}
+
+func (uc *ReconcileSettlementUseCase) Execute(ctx context.Context, cmd ReconcileSettlementCommand) (*domain.Reconciliation, error) {
+	day, err := parseSettlementDate(cmd.SettlementDate)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := domain.ParseSettlementCSV(bytes.NewReader(cmd.Content))
+	if err != nil {
+		return nil, apperrors.ErrInvalidSettlementFile(err.Error())
+	}
+
+	ours, err := uc.settledPayments(ctx, day)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+
+	checksum := sha256.Sum256(cmd.Content)
+	reconciliation := &domain.Reconciliation{
+		ID:             uuid.New().String(),
+		SettlementDate: day.Format(domain.SettlementDateLayout),
+		FileName:       cmd.FileName,
+		FileSHA256:     hex.EncodeToString(checksum[:]),
+		Rows:           len(rows),
+		CreatedAt:      time.Now(),
+	}
+
+	byID := make(map[string]*domain.Payment, len(ours))
+	for _, payment := range ours {
+		byID[payment.SettlementReference()] = payment
+	}
+
+	for _, row := range rows {
+		payment, ok := byID[row.ProcessorReference]
+		if ok {
+			delete(byID, row.ProcessorReference)
+		} else {
+			payment, err = uc.findPayment(ctx, row.ProcessorReference)
+			if err != nil {
+				return nil, apperrors.ErrInternal()
+			}
+		}
+		reconciliation.Add(matchSettlementRow(row, payment))
+	}
+
+	for _, payment := range ours {
+		if _, ok := byID[payment.SettlementReference()]; !ok {
+			continue
+		}
+		reconciliation.Add(&domain.ReconciliationItem{
+			Outcome:     domain.ReconciliationMissingInTheirs,
+			PaymentID:   payment.ID,
+			OurAmount:   payment.Amount,
+			OurCurrency: payment.Currency,
+			OurStatus:   payment.Status,
+		})
+	}
+
+	if err := uc.reconciliationRepo.Create(ctx, reconciliation); err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if reconciliation.Items == nil {
+		reconciliation.Items = []*domain.ReconciliationItem{}
+	}
+	return reconciliation, nil
+}
+
+func (uc *ReconcileSettlementUseCase) settledPayments(ctx context.Context, day time.Time) ([]*domain.Payment, error) {
+	to := day.AddDate(0, 0, 1)
+	filter := domain.PaymentFilter{
+		Status:      domain.PaymentStatusSucceeded,
+		CreatedFrom: &day,
+		CreatedTo:   &to,
+		Limit:       reconciliationPageSize,
+	}
+
+	var payments []*domain.Payment
+	for {
+		page, err := uc.paymentRepo.List(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, page...)
+		if len(page) < reconciliationPageSize {
+			return payments, nil
+		}
+		last := page[len(page)-1]
+		filter.After = &domain.PaymentCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+}
+
+func matchSettlementRow(row domain.SettlementRow, payment *domain.Payment) *domain.ReconciliationItem {
+	item := &domain.ReconciliationItem{
+		Outcome:            domain.ReconciliationMatched,
+		ProcessorReference: row.ProcessorReference,
+		TheirAmount:        row.Amount.Amount,
+		TheirCurrency:      row.Amount.Currency,
+	}
+
+	if payment == nil {
+		item.Outcome = domain.ReconciliationMissingInOurs
+		return item
+	}
+
+	item.PaymentID = payment.ID
+	item.OurAmount = payment.Amount
+	item.OurCurrency = payment.Currency
+	item.OurStatus = payment.Status
+
+	switch {
+	case payment.Status != domain.PaymentStatusSucceeded:
+		item.Outcome = domain.ReconciliationStatusMismatch
+	case payment.Money() != row.Amount:
+		item.Outcome = domain.ReconciliationAmountMismatch
+	}
+	return item
+}
+
+func parseSettlementDate(value string) (time.Time, error) {
+	day, err := time.ParseInLocation(domain.SettlementDateLayout, value, time.UTC)
+	if err != nil {
+		return time.Time{}, apperrors.ErrInvalidSettlementDate()
+	}
+	return day, nil
+}
+
+func (uc *ReconcileSettlementUseCase) findPayment(ctx context.Context, reference string) (*domain.Payment, error) {
+	payment, err := uc.paymentRepo.FindByID(ctx, reference)
+	if err != nil || payment != nil {
+		return payment, err
+	}
+	return uc.paymentRepo.FindByProcessorReference(ctx, reference)
+}
@@ -0,0 +1,80 @@
+package use_cases
+
+import (
+	"context"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type RedeliverEventUseCase struct {
+	txManager    domain.TransactionManager
+	outboxRepo   domain.OutboxRepository
+	deliveryRepo domain.WebhookDeliveryRepository
+}
+
+func NewRedeliverEventUseCase(
+	txManager domain.TransactionManager,
+	outboxRepo domain.OutboxRepository,
+	deliveryRepo domain.WebhookDeliveryRepository,
+) *RedeliverEventUseCase {
+	return &RedeliverEventUseCase{
+		txManager:    txManager,
+		outboxRepo:   outboxRepo,
+		deliveryRepo: deliveryRepo,
+	}
+}
+
+func (uc *RedeliverEventUseCase) Execute(ctx context.Context, eventID string) ([]*domain.WebhookDelivery, error) {
+	var deliveries []*domain.WebhookDelivery
+	var returnErr error
+
+	txErr := uc.txManager.RunInTransaction(ctx, func(txCtx context.Context) error {
+		event, err := uc.outboxRepo.FindByID(txCtx, eventID)
+		if err != nil {
+			returnErr = apperrors.ErrInternal()
+			return err
+		}
+		if event == nil {
+			returnErr = apperrors.ErrEventNotFound()
+			return returnErr
+		}
+
+		deliveries, err = uc.deliveryRepo.FindByEventID(txCtx, eventID)
+		if err != nil {
+			returnErr = apperrors.ErrInternal()
+			return err
+		}
+
+		if len(deliveries) == 0 {
+			event.Status = domain.OutboxStatusPending
+			if err := uc.outboxRepo.Update(txCtx, event); err != nil {
+				returnErr = apperrors.ErrInternal()
+				return err
+			}
+			return nil
+		}
+
+		for _, delivery := range deliveries {
+			delivery.Status = domain.WebhookDeliveryStatusPending
+			delivery.Attempts = 0
+			delivery.NextAttemptAt = time.Now()
+			delivery.LastError = ""
+			delivery.DeliveredAt = nil
+			if err := uc.deliveryRepo.Update(txCtx, delivery); err != nil {
+				returnErr = apperrors.ErrInternal()
+				return err
+			}
+		}
+		return nil
+	})
+
+	if txErr != nil && returnErr != nil {
+		return nil, returnErr
+	}
+	if txErr != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,39 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+)
+
+var errNoCurrencies = errors.New("currency table is empty")
+
+type RefreshCurrenciesUseCase struct {
+	currencyRepo domain.CurrencyRepository
+	registry     *domain.CurrencyRegistry
+}
+
+func NewRefreshCurrenciesUseCase(currencyRepo domain.CurrencyRepository, registry *domain.CurrencyRegistry) *RefreshCurrenciesUseCase {
+	return &RefreshCurrenciesUseCase{
+		currencyRepo: currencyRepo,
+		registry:     registry,
+	}
+}
+
+func (uc *RefreshCurrenciesUseCase) Execute(ctx context.Context) (int, error) {
+	stored, err := uc.currencyRepo.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(stored) == 0 {
+		return 0, errNoCurrencies
+	}
+
+	definitions := make([]domain.CurrencyDefinition, 0, len(stored))
+	for _, def := range stored {
+		definitions = append(definitions, *def)
+	}
+	uc.registry.Load(definitions)
+	return len(definitions), nil
+}
@@ -0,0 +1,186 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/utils/fingerprint"
+)
+
+type RefundPaymentResult struct {
+	Refund   *domain.Refund
+	Replayed bool
+}
+
+type RefundPaymentUseCase struct {
+	txManager       domain.TransactionManager
+	paymentRepo     domain.PaymentRepository
+	refundRepo      domain.RefundRepository
+	idempotencyRepo domain.IdempotencyRepository
+	refunder        domain.PaymentRefunder
+	ledger          *LedgerRecorder
+}
+
+func NewRefundPaymentUseCase(
+	txManager domain.TransactionManager,
+	paymentRepo domain.PaymentRepository,
+	refundRepo domain.RefundRepository,
+	idempotencyRepo domain.IdempotencyRepository,
+	processor domain.PaymentProcessor,
+	ledger *LedgerRecorder,
+) *RefundPaymentUseCase {
+	refunder, _ := processor.(domain.PaymentRefunder)
+	return &RefundPaymentUseCase{
+		txManager:       txManager,
+		paymentRepo:     paymentRepo,
+		refundRepo:      refundRepo,
+		idempotencyRepo: idempotencyRepo,
+		refunder:        refunder,
+		ledger:          ledger,
+	}
+}
+
+func (uc *RefundPaymentUseCase) Execute(ctx context.Context, idempotencyKey, paymentID string, req domain.RefundRequest) (*RefundPaymentResult, error) {
+	if err := validateIdempotencyKey(idempotencyKey); err != nil {
+		return nil, err
+	}
+
+	payment, err := uc.paymentRepo.FindByID(ctx, paymentID)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if payment == nil {
+		return nil, apperrors.ErrPaymentNotFound()
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	var requested int64
+	if req.Amount != "" {
+		money, err := domain.ParseMoney(req.Amount.String(), payment.Currency)
+		switch {
+		case errors.Is(err, domain.ErrAmountPrecision):
+			return nil, apperrors.ErrInvalidAmountPrecision(string(payment.Currency), domain.NewMoney(0, payment.Currency).Exponent())
+		case err != nil:
+			return nil, apperrors.ErrInvalidRefund(err.Error())
+		case money.Amount <= 0:
+			return nil, apperrors.ErrInvalidRefund("amount must be greater than 0")
+		}
+		requested = money.Amount
+		req.Amount = money.Number()
+	}
+
+	fp := fingerprint.ComputeRefund(payment.ID, req)
+	if result, err := uc.replay(ctx, idempotencyKey, fp); result != nil || err != nil {
+		return result, err
+	}
+
+	record, err := uc.idempotencyRepo.FindByKey(ctx, idempotencyKey)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if record != nil {
+		return nil, apperrors.ErrIdempotencyKeyConflict()
+	}
+
+	var refund *domain.Refund
+	var returnErr error
+
+	txErr := uc.txManager.RunInTransaction(ctx, func(txCtx context.Context) error {
+		current, err := uc.paymentRepo.FindByIDForUpdate(txCtx, payment.ID)
+		if err != nil {
+			returnErr = apperrors.ErrInternal()
+			return err
+		}
+		if current.Status != domain.PaymentStatusSucceeded {
+			returnErr = apperrors.ErrRefundNotAllowed("only SUCCEEDED payments can be refunded; this one is " + string(current.Status))
+			return returnErr
+		}
+
+		refunded, err := uc.refundRepo.TotalByPayment(txCtx, current.ID)
+		if err != nil {
+			returnErr = apperrors.ErrInternal()
+			return err
+		}
+		remaining := current.Amount - refunded
+		amount := requested
+		if amount == 0 {
+			amount = remaining
+		}
+		switch {
+		case remaining <= 0:
+			returnErr = apperrors.ErrRefundNotAllowed("payment is already fully refunded")
+			return returnErr
+		case amount > remaining:
+			returnErr = apperrors.ErrRefundExceedsPayment(string(current.Currency), domain.NewMoney(remaining, current.Currency).Decimal())
+			return returnErr
+		case uc.refunder == nil:
+			returnErr = apperrors.ErrRefundNotAllowed("the processor does not support refunds")
+			return returnErr
+		}
+
+		reference, err := uc.refunder.Refund(ctx, domain.ProcessorIdempotencyKey("refund:"+idempotencyKey), current, amount)
+		switch {
+		case errors.Is(err, domain.ErrProcessorUnavailable), errors.Is(err, domain.ErrProcessorTimeout):
+			returnErr = apperrors.ErrProcessorUnavailable()
+			return err
+		case errors.Is(err, domain.ErrRefundUnsupported):
+			returnErr = apperrors.ErrRefundNotAllowed("the processor does not support refunds")
+			return err
+		case err != nil:
+			returnErr = apperrors.ErrInternal()
+			return err
+		}
+
+		refund = &domain.Refund{
+			ID:                 uuid.New().String(),
+			IdempotencyKey:     idempotencyKey,
+			RequestFingerprint: fp,
+			PaymentID:          current.ID,
+			Amount:             amount,
+			Currency:           current.Currency,
+			Reason:             req.Reason,
+			ProcessorReference: reference,
+			CreatedAt:          time.Now(),
+		}
+		if err := uc.refundRepo.Create(txCtx, refund); err != nil {
+			return err
+		}
+
+		if err := uc.ledger.recordRefund(txCtx, current, amount); err != nil {
+			returnErr = apperrors.ErrInternal()
+			return err
+		}
+		return nil
+	})
+
+	if txErr != nil && returnErr != nil {
+		return nil, returnErr
+	}
+	if txErr != nil {
+		if result, err := uc.replay(ctx, idempotencyKey, fp); result != nil || err != nil {
+			return result, err
+		}
+		return nil, apperrors.ErrInternal()
+	}
+
+	return &RefundPaymentResult{Refund: refund}, nil
+}
+
+func (uc *RefundPaymentUseCase) replay(ctx context.Context, idempotencyKey, fp string) (*RefundPaymentResult, error) {
+	existing, err := uc.refundRepo.FindByKey(ctx, idempotencyKey)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if existing == nil {
+		return nil, nil
+	}
+	if existing.RequestFingerprint != fp {
+		return nil, apperrors.ErrIdempotencyKeyConflict()
+	}
+	return &RefundPaymentResult{Refund: existing, Replayed: true}, nil
+}
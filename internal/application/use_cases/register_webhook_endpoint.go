@@ -0,0 +1,66 @@
+package use_cases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type RegisterWebhookEndpointUseCase struct {
+	endpointRepo domain.WebhookEndpointRepository
+}
+
+func NewRegisterWebhookEndpointUseCase(endpointRepo domain.WebhookEndpointRepository) *RegisterWebhookEndpointUseCase {
+	return &RegisterWebhookEndpointUseCase{
+		endpointRepo: endpointRepo,
+	}
+}
+
+func (uc *RegisterWebhookEndpointUseCase) Execute(ctx context.Context, req domain.WebhookEndpointRequest) (*domain.WebhookEndpoint, error) {
+	if err := validateWebhookEndpointRequest(req); err != nil {
+		return nil, err
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+
+	endpoint := &domain.WebhookEndpoint{
+		ID:          uuid.New().String(),
+		URL:         req.URL,
+		Description: req.Description,
+		Secret:      secret,
+		Enabled:     true,
+		CreatedAt:   time.Now(),
+	}
+	if err := uc.endpointRepo.Create(ctx, endpoint); err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	return endpoint, nil
+}
+
+func validateWebhookEndpointRequest(req domain.WebhookEndpointRequest) error {
+	if req.URL == "" {
+		return apperrors.ErrInvalidWebhookEndpoint("url is required")
+	}
+	parsed, err := url.Parse(req.URL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return apperrors.ErrInvalidWebhookEndpoint("url must be an absolute http or https URL")
+	}
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
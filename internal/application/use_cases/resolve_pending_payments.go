@@ -0,0 +1,99 @@
+package use_cases
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+)
+
+const pendingTimeoutReason = "pending_timeout"
+
+type ResolvePendingPaymentsUseCase struct {
+	txManager   domain.TransactionManager
+	paymentRepo domain.PaymentRepository
+	processor   domain.PaymentProcessor
+	updater     *paymentStatusUpdater
+	backoffBase time.Duration
+	backoffMax  time.Duration
+	maxAge      time.Duration
+	batchSize   int
+}
+
+func NewResolvePendingPaymentsUseCase(
+	txManager domain.TransactionManager,
+	idempotencyRepo domain.IdempotencyRepository,
+	paymentRepo domain.PaymentRepository,
+	outboxRepo domain.OutboxRepository,
+	processor domain.PaymentProcessor,
+	backoffBase time.Duration,
+	backoffMax time.Duration,
+	maxAge time.Duration,
+	batchSize int,
+	opts ...PaymentStatusOption,
+) *ResolvePendingPaymentsUseCase {
+	return &ResolvePendingPaymentsUseCase{
+		txManager:   txManager,
+		paymentRepo: paymentRepo,
+		processor:   processor,
+		updater:     newPaymentStatusUpdater(idempotencyRepo, paymentRepo, outboxRepo, opts...),
+		backoffBase: backoffBase,
+		backoffMax:  backoffMax,
+		maxAge:      maxAge,
+		batchSize:   batchSize,
+	}
+}
+
+func (uc *ResolvePendingPaymentsUseCase) Execute(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	payments, err := uc.paymentRepo.FindPendingDue(ctx, now, uc.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	resolved := 0
+	for _, payment := range payments {
+		done, err := uc.resolve(ctx, payment, now)
+		if err != nil {
+			log.Printf("pending resolver: payment %s: %v", payment.ID, err)
+			continue
+		}
+		if done {
+			resolved++
+		}
+	}
+	return resolved, nil
+}
+
+func (uc *ResolvePendingPaymentsUseCase) resolve(ctx context.Context, payment *domain.Payment, now time.Time) (bool, error) {
+	update, statusErr := uc.processor.GetStatus(ctx, payment.ID)
+	if statusErr != nil || update == nil {
+		update = &domain.PaymentStatusUpdate{Status: domain.PaymentStatusPending}
+	}
+
+	if update.Status == domain.PaymentStatusPending && now.Sub(payment.CreatedAt) >= uc.maxAge {
+		update = &domain.PaymentStatusUpdate{
+			Status:     domain.PaymentStatusFailed,
+			FailReason: pendingTimeoutReason,
+		}
+	}
+
+	if update.Status == domain.PaymentStatusPending {
+		checks := payment.StatusChecks + 1
+		next := now.Add(exponentialBackoff(uc.backoffBase, uc.backoffMax, checks))
+		if _, err := uc.paymentRepo.ScheduleStatusCheck(ctx, payment.ID, domain.PaymentStatusPending, checks, next); err != nil {
+			return false, err
+		}
+		return false, statusErr
+	}
+
+	applied := false
+	err := uc.txManager.RunInTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		_, applied, err = uc.updater.apply(txCtx, payment.ID, *update)
+		return err
+	})
+	return applied, err
+}
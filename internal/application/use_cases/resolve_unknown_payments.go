@@ -0,0 +1,126 @@
+package use_cases
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+)
+
+const notChargedReason = "not_charged"
+
+type ResolveUnknownPaymentsUseCase struct {
+	txManager   domain.TransactionManager
+	paymentRepo domain.PaymentRepository
+	processor   domain.PaymentProcessor
+	updater     *paymentStatusUpdater
+	backoffBase time.Duration
+	backoffMax  time.Duration
+	grace       time.Duration
+	maxAge      time.Duration
+	batchSize   int
+}
+
+func NewResolveUnknownPaymentsUseCase(
+	txManager domain.TransactionManager,
+	idempotencyRepo domain.IdempotencyRepository,
+	paymentRepo domain.PaymentRepository,
+	outboxRepo domain.OutboxRepository,
+	processor domain.PaymentProcessor,
+	backoffBase time.Duration,
+	backoffMax time.Duration,
+	grace time.Duration,
+	maxAge time.Duration,
+	batchSize int,
+	opts ...PaymentStatusOption,
+) *ResolveUnknownPaymentsUseCase {
+	return &ResolveUnknownPaymentsUseCase{
+		txManager:   txManager,
+		paymentRepo: paymentRepo,
+		processor:   processor,
+		updater:     newPaymentStatusUpdater(idempotencyRepo, paymentRepo, outboxRepo, opts...),
+		backoffBase: backoffBase,
+		backoffMax:  backoffMax,
+		grace:       grace,
+		maxAge:      maxAge,
+		batchSize:   batchSize,
+	}
+}
+
+func (uc *ResolveUnknownPaymentsUseCase) Execute(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	payments, err := uc.paymentRepo.FindUnknownDue(ctx, now, uc.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	resolved := 0
+	for _, payment := range payments {
+		done, err := uc.resolve(ctx, payment, now)
+		if err != nil {
+			log.Printf("unknown resolver: payment %s: %v", payment.ID, err)
+			continue
+		}
+		if done {
+			resolved++
+		}
+	}
+	return resolved, nil
+}
+
+func (uc *ResolveUnknownPaymentsUseCase) resolve(ctx context.Context, payment *domain.Payment, now time.Time) (bool, error) {
+	charged, lookupErr := uc.processor.LookupByKey(ctx, payment.ProcessorKey)
+
+	var update *domain.PaymentStatusUpdate
+	if lookupErr == nil {
+		update = unknownOutcome(charged, now.Sub(payment.CreatedAt) >= uc.grace)
+	}
+
+	if update == nil && now.Sub(payment.CreatedAt) >= uc.maxAge {
+		escalated, err := uc.paymentRepo.Escalate(ctx, payment.ID, domain.PaymentStatusUnknown, now)
+		if err != nil {
+			return false, err
+		}
+		if escalated {
+			log.Printf("unknown resolver: payment %s unresolved after %s, escalated for manual reconciliation", payment.ID, uc.maxAge)
+		}
+		return false, nil
+	}
+
+	if update == nil {
+		checks := payment.StatusChecks + 1
+		next := now.Add(exponentialBackoff(uc.backoffBase, uc.backoffMax, checks))
+		if _, err := uc.paymentRepo.ScheduleStatusCheck(ctx, payment.ID, domain.PaymentStatusUnknown, checks, next); err != nil {
+			return false, err
+		}
+		return false, lookupErr
+	}
+
+	applied := false
+	err := uc.txManager.RunInTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		_, applied, err = uc.updater.apply(txCtx, payment.ID, *update)
+		return err
+	})
+	return applied, err
+}
+
+func unknownOutcome(charged *domain.Payment, graceElapsed bool) *domain.PaymentStatusUpdate {
+	if charged == nil {
+		if !graceElapsed {
+			return nil
+		}
+		return &domain.PaymentStatusUpdate{Status: domain.PaymentStatusFailed, FailReason: notChargedReason}
+	}
+	if charged.Status != domain.PaymentStatusSucceeded && charged.Status != domain.PaymentStatusFailed {
+		return nil
+	}
+	return &domain.PaymentStatusUpdate{
+		Status:             charged.Status,
+		FailReason:         charged.FailReason,
+		Processor:          charged.Processor,
+		ProcessorReference: charged.ID,
+	}
+}
@@ -0,0 +1,77 @@
+package use_cases
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+)
+
+type RiskEngine struct {
+	repo            domain.RiskAssessmentRepository
+	cardFingerprint func(cardNumber string) string
+	rules           []domain.RiskRule
+}
+
+func NewRiskEngine(repo domain.RiskAssessmentRepository, cardFingerprint func(cardNumber string) string, rules ...domain.RiskRule) *RiskEngine {
+	if cardFingerprint == nil {
+		cardFingerprint = sha256Hex
+	}
+	return &RiskEngine{
+		repo:            repo,
+		cardFingerprint: cardFingerprint,
+		rules:           rules,
+	}
+}
+
+func (e *RiskEngine) assess(ctx context.Context, idempotencyKey string, req domain.PaymentRequest, money domain.Money) (*domain.RiskAssessment, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	input := domain.RiskInput{
+		CustomerID:      req.CustomerID,
+		CardNumber:      req.CardNumber,
+		CardFingerprint: e.cardFingerprint(req.CardNumber),
+		Money:           money,
+		Now:             time.Now(),
+	}
+	assessment := &domain.RiskAssessment{
+		ID:              uuid.New().String(),
+		IdempotencyKey:  idempotencyKey,
+		CustomerID:      req.CustomerID,
+		CardFingerprint: input.CardFingerprint,
+		Amount:          money.Amount,
+		Currency:        money.Currency,
+		Decision:        domain.RiskDecisionAllow,
+		Triggers:        []domain.RiskTrigger{},
+		CreatedAt:       input.Now,
+	}
+
+	for _, rule := range e.rules {
+		trigger, err := rule.Evaluate(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		if trigger != nil {
+			assessment.Add(*trigger)
+		}
+	}
+	return assessment, nil
+}
+
+func (e *RiskEngine) record(ctx context.Context, assessment *domain.RiskAssessment, paymentID string) error {
+	if e == nil || assessment == nil {
+		return nil
+	}
+	assessment.PaymentID = paymentID
+	return e.repo.Create(ctx, assessment)
+}
+
+func sha256Hex(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
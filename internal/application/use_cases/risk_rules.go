@@ -0,0 +1,133 @@
+package use_cases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+)
+
+type RiskVelocityScope string
+
+const (
+	RiskVelocityPerCustomer RiskVelocityScope = "customer"
+	RiskVelocityPerCard     RiskVelocityScope = "card"
+)
+
+type velocityRule struct {
+	repo   domain.RiskAssessmentRepository
+	scope  RiskVelocityScope
+	limit  int
+	window time.Duration
+}
+
+func NewVelocityRule(repo domain.RiskAssessmentRepository, scope RiskVelocityScope, limit int, window time.Duration) domain.RiskRule {
+	return &velocityRule{repo: repo, scope: scope, limit: limit, window: window}
+}
+
+func (r *velocityRule) Evaluate(ctx context.Context, input domain.RiskInput) (*domain.RiskTrigger, error) {
+	filter := domain.RiskVelocityFilter{Since: input.Now.Add(-r.window)}
+	rule := domain.RiskRuleCustomerVelocity
+	switch r.scope {
+	case RiskVelocityPerCard:
+		filter.CardFingerprint = input.CardFingerprint
+		rule = domain.RiskRuleCardVelocity
+	default:
+		filter.CustomerID = input.CustomerID
+	}
+
+	attempts, err := r.repo.CountSince(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if attempts < int64(r.limit) {
+		return nil, nil
+	}
+	return &domain.RiskTrigger{
+		Rule:     rule,
+		Decision: domain.RiskDecisionDeny,
+		Reason:   fmt.Sprintf("%d attempts in the last %s, limit is %d", attempts, r.window, r.limit),
+	}, nil
+}
+
+type maxAmountRule struct {
+	limits map[domain.Currency]int64
+}
+
+func NewMaxAmountRule(limits map[domain.Currency]int64) domain.RiskRule {
+	return &maxAmountRule{limits: limits}
+}
+
+func (r *maxAmountRule) Evaluate(_ context.Context, input domain.RiskInput) (*domain.RiskTrigger, error) {
+	limit, ok := r.limits[input.Money.Currency]
+	if !ok || input.Money.Amount <= limit {
+		return nil, nil
+	}
+	return &domain.RiskTrigger{
+		Rule:     domain.RiskRuleMaxAmount,
+		Decision: domain.RiskDecisionDeny,
+		Reason:   fmt.Sprintf("amount exceeds %s %s", domain.NewMoney(limit, input.Money.Currency).Decimal(), input.Money.Currency),
+	}, nil
+}
+
+type blockedBINRule struct {
+	bins []string
+}
+
+func NewBlockedBINRule(bins []string) domain.RiskRule {
+	return &blockedBINRule{bins: bins}
+}
+
+func (r *blockedBINRule) Evaluate(_ context.Context, input domain.RiskInput) (*domain.RiskTrigger, error) {
+	for _, bin := range r.bins {
+		if strings.HasPrefix(input.CardNumber, bin) {
+			return &domain.RiskTrigger{
+				Rule:     domain.RiskRuleBlockedBIN,
+				Decision: domain.RiskDecisionDeny,
+				Reason:   fmt.Sprintf("card BIN %s is blocked", bin),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+type countryMismatchRule struct {
+	binCountries map[string]string
+	customerRepo domain.CustomerRepository
+}
+
+func NewCountryMismatchRule(binCountries map[string]string, customerRepo domain.CustomerRepository) domain.RiskRule {
+	return &countryMismatchRule{binCountries: binCountries, customerRepo: customerRepo}
+}
+
+func (r *countryMismatchRule) Evaluate(ctx context.Context, input domain.RiskInput) (*domain.RiskTrigger, error) {
+	cardCountry := r.cardCountry(input.CardNumber)
+	if cardCountry == "" {
+		return nil, nil
+	}
+
+	customer, err := r.customerRepo.FindByID(ctx, input.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	if customer == nil || customer.Country == "" || strings.EqualFold(customer.Country, cardCountry) {
+		return nil, nil
+	}
+	return &domain.RiskTrigger{
+		Rule:     domain.RiskRuleCountryMismatch,
+		Decision: domain.RiskDecisionReview,
+		Reason:   fmt.Sprintf("card issued in %s, customer registered in %s", cardCountry, strings.ToUpper(customer.Country)),
+	}, nil
+}
+
+func (r *countryMismatchRule) cardCountry(cardNumber string) string {
+	match := ""
+	for prefix := range r.binCountries {
+		if len(prefix) > len(match) && strings.HasPrefix(cardNumber, prefix) {
+			match = prefix
+		}
+	}
+	return r.binCountries[match]
+}
@@ -0,0 +1,116 @@
+package use_cases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/utils/fingerprint"
+)
+
+type SchedulePaymentResult struct {
+	ScheduledPayment *domain.ScheduledPayment
+	Replayed         bool
+}
+
+type SchedulePaymentUseCase struct {
+	scheduledRepo   domain.ScheduledPaymentRepository
+	idempotencyRepo domain.IdempotencyRepository
+	maxAhead        time.Duration
+}
+
+func NewSchedulePaymentUseCase(
+	scheduledRepo domain.ScheduledPaymentRepository,
+	idempotencyRepo domain.IdempotencyRepository,
+	maxAhead time.Duration,
+) *SchedulePaymentUseCase {
+	return &SchedulePaymentUseCase{
+		scheduledRepo:   scheduledRepo,
+		idempotencyRepo: idempotencyRepo,
+		maxAhead:        maxAhead,
+	}
+}
+
+func (uc *SchedulePaymentUseCase) Execute(ctx context.Context, idempotencyKey string, req domain.PaymentRequest) (*SchedulePaymentResult, error) {
+	if err := validateIdempotencyKey(idempotencyKey); err != nil {
+		return nil, err
+	}
+
+	if req.ScheduledAt == nil {
+		return nil, apperrors.ErrInvalidSchedule("scheduled_at is required")
+	}
+	scheduledAt := req.ScheduledAt.UTC()
+	req.ScheduledAt = &scheduledAt
+
+	fp := fingerprint.Compute(req)
+	if result, err := uc.replay(ctx, idempotencyKey, fp); result != nil || err != nil {
+		return result, err
+	}
+
+	record, err := uc.idempotencyRepo.FindByKey(ctx, idempotencyKey)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if record != nil {
+		return nil, apperrors.ErrIdempotencyKeyConflict()
+	}
+
+	now := time.Now()
+	if err := uc.validate(req, now); err != nil {
+		return nil, err
+	}
+
+	scheduled := &domain.ScheduledPayment{
+		ID:                 uuid.New().String(),
+		IdempotencyKey:     idempotencyKey,
+		RequestFingerprint: fp,
+		CustomerID:         req.CustomerID,
+		Request:            req,
+		Status:             domain.ScheduledPaymentScheduled,
+		ScheduledAt:        scheduledAt,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	if err := uc.scheduledRepo.Create(ctx, scheduled); err != nil {
+		if result, replayErr := uc.replay(ctx, idempotencyKey, fp); result != nil || replayErr != nil {
+			return result, replayErr
+		}
+		return nil, apperrors.ErrInternal()
+	}
+
+	return &SchedulePaymentResult{ScheduledPayment: scheduled}, nil
+}
+
+func (uc *SchedulePaymentUseCase) replay(ctx context.Context, idempotencyKey, fp string) (*SchedulePaymentResult, error) {
+	existing, err := uc.scheduledRepo.FindByKey(ctx, idempotencyKey)
+	if err != nil {
+		return nil, apperrors.ErrInternal()
+	}
+	if existing == nil {
+		return nil, nil
+	}
+	if existing.RequestFingerprint != fp {
+		return nil, apperrors.ErrIdempotencyKeyConflict()
+	}
+	return &SchedulePaymentResult{ScheduledPayment: existing, Replayed: true}, nil
+}
+
+func (uc *SchedulePaymentUseCase) validate(req domain.PaymentRequest, now time.Time) error {
+	switch {
+	case !req.ScheduledAt.After(now):
+		return apperrors.ErrInvalidSchedule("scheduled_at must be in the future")
+	case uc.maxAhead > 0 && req.ScheduledAt.After(now.Add(uc.maxAhead)):
+		return apperrors.ErrInvalidSchedule("scheduled_at is too far in the future; at most " + uc.maxAhead.String() + " ahead")
+	case req.ParentPaymentID != "":
+		return apperrors.ErrInvalidSchedule("tips cannot be scheduled")
+	case req.PaymentMethodID == "":
+		return apperrors.ErrInvalidSchedule("payment_method_id is required")
+	case req.CardNumber != "" || req.CVV != "":
+		return apperrors.ErrInvalidSchedule("card details are not stored; charge a payment_method_id without card_number or cvv")
+	}
+
+	_, err := validatePaymentRequest(req)
+	return err
+}
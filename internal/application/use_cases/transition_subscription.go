@@ -0,0 +1,64 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type TransitionSubscriptionUseCase struct {
+	txManager        domain.TransactionManager
+	subscriptionRepo domain.SubscriptionRepository
+	planRepo         domain.SubscriptionPlanRepository
+}
+
+func NewTransitionSubscriptionUseCase(
+	txManager domain.TransactionManager,
+	subscriptionRepo domain.SubscriptionRepository,
+	planRepo domain.SubscriptionPlanRepository,
+) *TransitionSubscriptionUseCase {
+	return &TransitionSubscriptionUseCase{
+		txManager:        txManager,
+		subscriptionRepo: subscriptionRepo,
+		planRepo:         planRepo,
+	}
+}
+
+func (uc *TransitionSubscriptionUseCase) Execute(ctx context.Context, id string, action domain.SubscriptionAction) (*domain.Subscription, error) {
+	var sub *domain.Subscription
+	err := uc.txManager.RunInTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		sub, err = uc.subscriptionRepo.FindByIDForUpdate(txCtx, id)
+		if err != nil {
+			return apperrors.ErrInternal()
+		}
+		if sub == nil {
+			return apperrors.ErrSubscriptionNotFound()
+		}
+
+		plan, err := uc.planRepo.FindByID(txCtx, sub.PlanID)
+		if err != nil || plan == nil {
+			return apperrors.ErrInternal()
+		}
+
+		status := sub.Status
+		if err := sub.Apply(action, plan, time.Now().UTC()); err != nil {
+			return apperrors.ErrInvalidSubscriptionTransition(string(action), string(status))
+		}
+		if err := uc.subscriptionRepo.Update(txCtx, sub); err != nil {
+			return apperrors.ErrInternal()
+		}
+		return nil
+	})
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, apperrors.ErrInternal()
+	}
+	return sub, nil
+}
@@ -0,0 +1,86 @@
+package domain
+
+import "time"
+
+type BatchItemOutcome string
+
+const (
+	BatchItemCreated  BatchItemOutcome = "CREATED"
+	BatchItemReplayed BatchItemOutcome = "REPLAYED"
+	BatchItemConflict BatchItemOutcome = "CONFLICT"
+	BatchItemFailed   BatchItemOutcome = "ERROR"
+)
+
+type BatchPaymentItem struct {
+	IdempotencyKey string `json:"idempotency_key"`
+	PaymentRequest
+}
+
+type BatchPaymentRequest struct {
+	Items []BatchPaymentItem `json:"items"`
+}
+
+type BatchItemError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type BatchItemResult struct {
+	Index          int              `json:"index"`
+	IdempotencyKey string           `json:"idempotency_key"`
+	Outcome        BatchItemOutcome `json:"outcome"`
+	HTTPStatus     int              `json:"http_status"`
+	DuplicateRide  bool             `json:"duplicate_ride,omitempty"`
+	Payment        *Payment         `json:"payment,omitempty"`
+	Error          *BatchItemError  `json:"error,omitempty"`
+}
+
+type BatchSummary struct {
+	Created   int `json:"created"`
+	Replayed  int `json:"replayed"`
+	Conflicts int `json:"conflicts"`
+	Errors    int `json:"errors"`
+}
+
+func (s *BatchSummary) Merge(other BatchSummary) {
+	s.Created += other.Created
+	s.Replayed += other.Replayed
+	s.Conflicts += other.Conflicts
+	s.Errors += other.Errors
+}
+
+type BatchPaymentResponse struct {
+	IdempotencyKey string             `json:"idempotency_key"`
+	Summary        BatchSummary       `json:"summary"`
+	Items          []*BatchItemResult `json:"items"`
+}
+
+func (r *BatchPaymentResponse) Tally() {
+	r.Summary = BatchSummary{}
+	for _, item := range r.Items {
+		switch item.Outcome {
+		case BatchItemCreated:
+			r.Summary.Created++
+		case BatchItemReplayed:
+			r.Summary.Replayed++
+		case BatchItemConflict:
+			r.Summary.Conflicts++
+		default:
+			r.Summary.Errors++
+		}
+	}
+}
+
+type PaymentBatch struct {
+	IdempotencyKey     string            `gorm:"primaryKey;type:varchar(64)"`
+	RequestFingerprint string            `gorm:"type:varchar(64);not null"`
+	Status             IdempotencyStatus `gorm:"type:varchar(20);not null"`
+	ResponseBody       []byte            `gorm:"type:jsonb"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	ExpiresAt          time.Time `gorm:"not null;index"`
+}
+
+func (PaymentBatch) TableName() string {
+	return "payment_batches"
+}
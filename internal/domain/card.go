@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+)
+
+type CardBrand string
+
+const (
+	CardBrandVisa       CardBrand = "VISA"
+	CardBrandMastercard CardBrand = "MASTERCARD"
+	CardBrandAmex       CardBrand = "AMEX"
+	CardBrandJCB        CardBrand = "JCB"
+	CardBrandUnionPay   CardBrand = "UNIONPAY"
+	CardBrandGPN        CardBrand = "GPN"
+	CardBrandNAPAS      CardBrand = "NAPAS"
+	CardBrandUnknown    CardBrand = "UNKNOWN"
+)
+
+type binRange struct {
+	low   int
+	high  int
+	brand CardBrand
+}
+
+var cardBINRanges = []binRange{
+	{low: 9360, high: 9360, brand: CardBrandGPN},
+	{low: 9704, high: 9704, brand: CardBrandNAPAS},
+	{low: 4000, high: 4999, brand: CardBrandVisa},
+	{low: 5100, high: 5599, brand: CardBrandMastercard},
+	{low: 2221, high: 2720, brand: CardBrandMastercard},
+	{low: 3400, high: 3499, brand: CardBrandAmex},
+	{low: 3700, high: 3799, brand: CardBrandAmex},
+	{low: 3528, high: 3589, brand: CardBrandJCB},
+	{low: 6200, high: 6299, brand: CardBrandUnionPay},
+}
+
+func NormalizeCardNumber(number string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(number)
+}
+
+func IsCardNumberFormat(number string) bool {
+	if len(number) < 12 || len(number) > 19 {
+		return false
+	}
+	for _, r := range number {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func LuhnValid(number string) bool {
+	if !IsCardNumberFormat(number) {
+		return false
+	}
+
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		digit := int(number[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+func DetectCardBrand(number string) CardBrand {
+	if len(number) < 4 {
+		return CardBrandUnknown
+	}
+	prefix, err := strconv.Atoi(number[:4])
+	if err != nil {
+		return CardBrandUnknown
+	}
+
+	for _, r := range cardBINRanges {
+		if prefix >= r.low && prefix <= r.high {
+			return r.brand
+		}
+	}
+	return CardBrandUnknown
+}
+
+func CVVLength(brand CardBrand) int {
+	if brand == CardBrandAmex {
+		return 4
+	}
+	return 3
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLuhnValid(t *testing.T) {
+	valid := []string{"4111111111111111", "4242424242424242", "5500000000000004", "378282246310005", "3530111333300000"}
+	for _, number := range valid {
+		assert.True(t, LuhnValid(number), number)
+	}
+
+	invalid := []string{"4111111111111112", "1234567890123", "41111111111", "4111a11111111111", ""}
+	for _, number := range invalid {
+		assert.False(t, LuhnValid(number), number)
+	}
+}
+
+func TestDetectCardBrand(t *testing.T) {
+	tests := map[string]CardBrand{
+		"4111111111111111": CardBrandVisa,
+		"5500000000000004": CardBrandMastercard,
+		"2221000000000009": CardBrandMastercard,
+		"378282246310005":  CardBrandAmex,
+		"3530111333300000": CardBrandJCB,
+		"6200000000000005": CardBrandUnionPay,
+		"9360001234567890": CardBrandGPN,
+		"9704001234567890": CardBrandNAPAS,
+		"6011111111111117": CardBrandUnknown,
+		"12":               CardBrandUnknown,
+	}
+
+	for number, brand := range tests {
+		assert.Equal(t, brand, DetectCardBrand(number), number)
+	}
+}
+
+func TestNormalizeCardNumber(t *testing.T) {
+	assert.Equal(t, "4111111111111111", NormalizeCardNumber("4111 1111-1111 1111"))
+}
+
+func TestCVVLength(t *testing.T) {
+	assert.Equal(t, 4, CVVLength(CardBrandAmex))
+	assert.Equal(t, 3, CVVLength(CardBrandVisa))
+}
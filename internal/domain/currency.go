@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type CurrencyDefinition struct {
+	Code      Currency `json:"code" gorm:"primaryKey;type:varchar(3)"`
+	Exponent  int      `json:"exponent" gorm:"not null"`
+	MinAmount int64    `json:"min_amount" gorm:"not null;default:0"`
+	MaxAmount int64    `json:"max_amount" gorm:"not null;default:0"`
+	Enabled   bool     `json:"enabled" gorm:"not null"`
+}
+
+func (CurrencyDefinition) TableName() string {
+	return "currencies"
+}
+
+func ParseCurrencyDefinitions(spec string) ([]CurrencyDefinition, error) {
+	var definitions []CurrencyDefinition
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, ":")
+		if len(parts) < 2 || len(parts) > 5 || len(parts[0]) != 3 {
+			return nil, fmt.Errorf("currency %q: expected CODE:EXPONENT[:MIN[:MAX[:ENABLED]]]", entry)
+		}
+
+		def := CurrencyDefinition{Code: Currency(strings.ToUpper(parts[0])), Enabled: true}
+
+		exponent, err := strconv.Atoi(parts[1])
+		if err != nil || exponent < 0 || exponent > 4 {
+			return nil, fmt.Errorf("currency %q: exponent must be between 0 and 4", entry)
+		}
+		def.Exponent = exponent
+
+		if len(parts) > 2 && parts[2] != "" {
+			if def.MinAmount, err = strconv.ParseInt(parts[2], 10, 64); err != nil {
+				return nil, fmt.Errorf("currency %q: invalid min amount", entry)
+			}
+		}
+		if len(parts) > 3 && parts[3] != "" {
+			if def.MaxAmount, err = strconv.ParseInt(parts[3], 10, 64); err != nil {
+				return nil, fmt.Errorf("currency %q: invalid max amount", entry)
+			}
+		}
+		if len(parts) > 4 && parts[4] != "" {
+			if def.Enabled, err = strconv.ParseBool(parts[4]); err != nil {
+				return nil, fmt.Errorf("currency %q: invalid enabled flag", entry)
+			}
+		}
+
+		definitions = append(definitions, def)
+	}
+	return definitions, nil
+}
+
+func DefaultCurrencyDefinitions() []CurrencyDefinition {
+	return []CurrencyDefinition{
+		{Code: CurrencyIDR, Exponent: 0, Enabled: true},
+		{Code: CurrencyTHB, Exponent: 2, Enabled: true},
+		{Code: CurrencyVND, Exponent: 0, Enabled: true},
+		{Code: CurrencyPHP, Exponent: 2, Enabled: true},
+	}
+}
+
+type CurrencyRegistry struct {
+	mu          sync.RWMutex
+	definitions map[Currency]CurrencyDefinition
+}
+
+func NewCurrencyRegistry(definitions []CurrencyDefinition) *CurrencyRegistry {
+	r := &CurrencyRegistry{}
+	r.Load(definitions)
+	return r
+}
+
+func (r *CurrencyRegistry) Load(definitions []CurrencyDefinition) {
+	byCode := make(map[Currency]CurrencyDefinition, len(definitions))
+	for _, def := range definitions {
+		byCode[def.Code] = def
+	}
+
+	r.mu.Lock()
+	r.definitions = byCode
+	r.mu.Unlock()
+}
+
+func (r *CurrencyRegistry) Lookup(code Currency) (CurrencyDefinition, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	def, ok := r.definitions[code]
+	return def, ok
+}
+
+func (r *CurrencyRegistry) Supported(code Currency) bool {
+	def, ok := r.Lookup(code)
+	return ok && def.Enabled
+}
+
+func (r *CurrencyRegistry) Exponent(code Currency) int {
+	def, _ := r.Lookup(code)
+	return def.Exponent
+}
+
+func (r *CurrencyRegistry) EnabledCodes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	codes := make([]string, 0, len(r.definitions))
+	for code, def := range r.definitions {
+		if def.Enabled {
+			codes = append(codes, string(code))
+		}
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+var currencies = NewCurrencyRegistry(DefaultCurrencyDefinitions())
+
+func Currencies() *CurrencyRegistry {
+	return currencies
+}
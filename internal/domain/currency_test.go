@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCurrencyDefinitions(t *testing.T) {
+	definitions, err := ParseCurrencyDefinitions("IDR:0, thb:2:2000:5000000, MYR:2::, SGD:2:0:0:false")
+	require.NoError(t, err)
+	require.Len(t, definitions, 4)
+
+	assert.Equal(t, CurrencyDefinition{Code: CurrencyIDR, Exponent: 0, Enabled: true}, definitions[0])
+	assert.Equal(t, CurrencyDefinition{Code: CurrencyTHB, Exponent: 2, MinAmount: 2000, MaxAmount: 5000000, Enabled: true}, definitions[1])
+	assert.Equal(t, CurrencyDefinition{Code: "MYR", Exponent: 2, Enabled: true}, definitions[2])
+	assert.False(t, definitions[3].Enabled)
+}
+
+func TestParseCurrencyDefinitions_Invalid(t *testing.T) {
+	specs := []string{
+		"IDR",
+		"IDRX:0",
+		"IDR:x",
+		"IDR:9",
+		"IDR:0:min",
+		"IDR:0:0:max",
+		"IDR:0:0:0:maybe",
+		"IDR:0:0:0:true:extra",
+	}
+
+	for _, spec := range specs {
+		_, err := ParseCurrencyDefinitions(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestCurrencyRegistry(t *testing.T) {
+	registry := NewCurrencyRegistry([]CurrencyDefinition{
+		{Code: "SGD", Exponent: 2, Enabled: true},
+		{Code: CurrencyIDR, Exponent: 0, Enabled: true},
+		{Code: "MYR", Exponent: 2, Enabled: false},
+	})
+
+	assert.True(t, registry.Supported("SGD"))
+	assert.False(t, registry.Supported("MYR"))
+	assert.False(t, registry.Supported("USD"))
+
+	def, ok := registry.Lookup("MYR")
+	assert.True(t, ok)
+	assert.Equal(t, 2, def.Exponent)
+	assert.Equal(t, 2, registry.Exponent("SGD"))
+	assert.Equal(t, []string{"IDR", "SGD"}, registry.EnabledCodes())
+
+	registry.Load(DefaultCurrencyDefinitions())
+	assert.False(t, registry.Supported("SGD"))
+	assert.Equal(t, []string{"IDR", "PHP", "THB", "VND"}, registry.EnabledCodes())
+}
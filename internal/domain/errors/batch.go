@@ -0,0 +1,20 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func ErrInvalidBatchRequest(detail string) *AppError {
+	return newAppError("INVALID_BATCH_REQUEST", http.StatusBadRequest, Messages{
+		"en": fmt.Sprintf("invalid batch request: %s", detail),
+		"es": fmt.Sprintf("solicitud de lote invalida: %s", detail),
+	})
+}
+
+func ErrBatchProcessing() *AppError {
+	return newAppError("BATCH_PROCESSING", http.StatusConflict, Messages{
+		"en": "a batch with this idempotency key is currently being processed",
+		"es": "un lote con esta clave de idempotencia esta siendo procesado actualmente",
+	})
+}
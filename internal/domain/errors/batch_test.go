@@ -0,0 +1,24 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrInvalidBatchRequestIncludesDetail(t *testing.T) {
+	err := ErrInvalidBatchRequest("items must not be empty")
+
+	assert.Equal(t, "INVALID_BATCH_REQUEST", err.Code)
+	assert.Equal(t, http.StatusBadRequest, err.HTTPCode)
+	assert.Equal(t, "invalid batch request: items must not be empty", err.Message)
+	assert.Contains(t, err.Localize("es").Message, "solicitud de lote invalida")
+}
+
+func TestErrBatchProcessing(t *testing.T) {
+	err := ErrBatchProcessing()
+
+	assert.Equal(t, "BATCH_PROCESSING", err.Code)
+	assert.Equal(t, http.StatusConflict, err.HTTPCode)
+}
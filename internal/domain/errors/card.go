@@ -0,0 +1,41 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func ErrInvalidCardNumber() *AppError {
+	return newAppError("INVALID_CARD_NUMBER", http.StatusBadRequest, Messages{
+		"en": "card_number must contain between 12 and 19 digits",
+		"es": "card_number debe contener entre 12 y 19 digitos",
+	})
+}
+
+func ErrInvalidCardChecksum() *AppError {
+	return newAppError("INVALID_CARD_CHECKSUM", http.StatusBadRequest, Messages{
+		"en": "card_number failed the Luhn checksum",
+		"es": "card_number no supero la verificacion de Luhn",
+	})
+}
+
+func ErrInvalidCardExpiry() *AppError {
+	return newAppError("INVALID_CARD_EXPIRY", http.StatusBadRequest, Messages{
+		"en": "exp_month must be between 1 and 12 and exp_year must be a valid year, sent together",
+		"es": "exp_month debe estar entre 1 y 12 y exp_year debe ser un anio valido, enviados juntos",
+	})
+}
+
+func ErrCardExpired() *AppError {
+	return newAppError("CARD_EXPIRED", http.StatusBadRequest, Messages{
+		"en": "card is expired",
+		"es": "la tarjeta esta vencida",
+	})
+}
+
+func ErrInvalidCVV(length int) *AppError {
+	return newAppError("INVALID_CVV", http.StatusBadRequest, Messages{
+		"en": fmt.Sprintf("cvv must contain exactly %d digits", length),
+		"es": fmt.Sprintf("cvv debe contener exactamente %d digitos", length),
+	})
+}
@@ -0,0 +1,32 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCardErrors(t *testing.T) {
+	tests := []struct {
+		err     *AppError
+		code    string
+		message string
+		es      string
+	}{
+		{ErrInvalidCardNumber(), "INVALID_CARD_NUMBER", "card_number must contain between 12 and 19 digits", "entre 12 y 19 digitos"},
+		{ErrInvalidCardChecksum(), "INVALID_CARD_CHECKSUM", "card_number failed the Luhn checksum", "verificacion de Luhn"},
+		{ErrInvalidCardExpiry(), "INVALID_CARD_EXPIRY", "exp_month must be between 1 and 12 and exp_year must be a valid year, sent together", "enviados juntos"},
+		{ErrCardExpired(), "CARD_EXPIRED", "card is expired", "la tarjeta esta vencida"},
+		{ErrInvalidCVV(4), "INVALID_CVV", "cvv must contain exactly 4 digits", "exactamente 4 digitos"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			assert.Equal(t, tt.code, tt.err.Code)
+			assert.Equal(t, http.StatusBadRequest, tt.err.HTTPCode)
+			assert.Equal(t, tt.message, tt.err.Message)
+			assert.Contains(t, tt.err.Localize("es").Message, tt.es)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func ErrInvalidCustomer(detail string) *AppError {
+	return newAppError("INVALID_CUSTOMER", http.StatusBadRequest, Messages{
+		"en": fmt.Sprintf("invalid customer: %s", detail),
+		"es": fmt.Sprintf("cliente invalido: %s", detail),
+	})
+}
+
+func ErrCustomerNotFound() *AppError {
+	return newAppError("CUSTOMER_NOT_FOUND", http.StatusNotFound, Messages{
+		"en": "customer not found",
+		"es": "cliente no encontrado",
+	})
+}
+
+func ErrCustomerAlreadyExists(id string) *AppError {
+	return newAppError("CUSTOMER_ALREADY_EXISTS", http.StatusConflict, Messages{
+		"en": fmt.Sprintf("customer %s already exists", id),
+		"es": fmt.Sprintf("el cliente %s ya existe", id),
+	})
+}
+
+func ErrUnknownCustomer(id string) *AppError {
+	return newAppError("UNKNOWN_CUSTOMER", http.StatusUnprocessableEntity, Messages{
+		"en": fmt.Sprintf("customer %s does not exist", id),
+		"es": fmt.Sprintf("el cliente %s no existe", id),
+	})
+}
@@ -0,0 +1,40 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrInvalidCustomerIncludesDetail(t *testing.T) {
+	err := ErrInvalidCustomer("name is required")
+
+	assert.Equal(t, "INVALID_CUSTOMER", err.Code)
+	assert.Equal(t, http.StatusBadRequest, err.HTTPCode)
+	assert.Equal(t, "invalid customer: name is required", err.Message)
+	assert.Equal(t, "cliente invalido: name is required", err.Localize("es").Message)
+}
+
+func TestErrCustomerNotFound(t *testing.T) {
+	err := ErrCustomerNotFound()
+
+	assert.Equal(t, "CUSTOMER_NOT_FOUND", err.Code)
+	assert.Equal(t, http.StatusNotFound, err.HTTPCode)
+}
+
+func TestErrCustomerAlreadyExists(t *testing.T) {
+	err := ErrCustomerAlreadyExists("cust-001")
+
+	assert.Equal(t, "CUSTOMER_ALREADY_EXISTS", err.Code)
+	assert.Equal(t, http.StatusConflict, err.HTTPCode)
+	assert.Contains(t, err.Message, "cust-001")
+}
+
+func TestErrUnknownCustomer(t *testing.T) {
+	err := ErrUnknownCustomer("cust-404")
+
+	assert.Equal(t, "UNKNOWN_CUSTOMER", err.Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, err.HTTPCode)
+	assert.Equal(t, "el cliente cust-404 no existe", err.Localize("es").Message)
+}
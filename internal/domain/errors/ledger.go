@@ -0,0 +1,20 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func ErrInvalidLedgerQuery(detail string) *AppError {
+	return newAppError("INVALID_LEDGER_QUERY", http.StatusBadRequest, Messages{
+		"en": fmt.Sprintf("invalid ledger query: %s", detail),
+		"es": fmt.Sprintf("consulta de libro contable invalida: %s", detail),
+	})
+}
+
+func ErrLedgerAccountNotFound() *AppError {
+	return newAppError("LEDGER_ACCOUNT_NOT_FOUND", http.StatusNotFound, Messages{
+		"en": "ledger account not found",
+		"es": "cuenta contable no encontrada",
+	})
+}
@@ -0,0 +1,24 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrInvalidLedgerQueryIncludesDetail(t *testing.T) {
+	err := ErrInvalidLedgerQuery("as_of must be an RFC 3339 timestamp")
+
+	assert.Equal(t, "INVALID_LEDGER_QUERY", err.Code)
+	assert.Equal(t, http.StatusBadRequest, err.HTTPCode)
+	assert.Equal(t, "invalid ledger query: as_of must be an RFC 3339 timestamp", err.Message)
+	assert.Contains(t, err.Localize("es").Message, "consulta de libro contable invalida")
+}
+
+func TestErrLedgerAccountNotFound(t *testing.T) {
+	err := ErrLedgerAccountNotFound()
+
+	assert.Equal(t, "LEDGER_ACCOUNT_NOT_FOUND", err.Code)
+	assert.Equal(t, http.StatusNotFound, err.HTTPCode)
+}
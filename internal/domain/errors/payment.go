@@ -3,6 +3,7 @@ package errors
 import (
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 func ErrIdempotencyKeyMissing() *AppError {
@@ -54,10 +55,39 @@ func ErrInvalidPaymentRequest(detail string) *AppError {
 	})
 }
 
-func ErrInvalidCurrency(currency string) *AppError {
+func ErrInvalidCurrency(currency string, supported []string) *AppError {
+	valid := strings.Join(supported, ", ")
 	return newAppError("INVALID_CURRENCY", http.StatusBadRequest, Messages{
-		"en": fmt.Sprintf("currency is not supported; valid currencies: IDR, THB, VND, PHP: %s", currency),
-		"es": fmt.Sprintf("moneda no soportada; monedas validas: IDR, THB, VND, PHP: %s", currency),
+		"en": fmt.Sprintf("currency is not supported; valid currencies: %s: %s", valid, currency),
+		"es": fmt.Sprintf("moneda no soportada; monedas validas: %s: %s", valid, currency),
+	})
+}
+
+func ErrInvalidAmountPrecision(currency string, exponent int) *AppError {
+	return newAppError("INVALID_AMOUNT_PRECISION", http.StatusBadRequest, Messages{
+		"en": fmt.Sprintf("amount for %s allows at most %d decimal places", currency, exponent),
+		"es": fmt.Sprintf("el monto para %s permite como maximo %d decimales", currency, exponent),
+	})
+}
+
+func ErrAmountBelowMinimum(currency, minimum string) *AppError {
+	return newAppError("AMOUNT_BELOW_MINIMUM", http.StatusBadRequest, Messages{
+		"en": fmt.Sprintf("amount for %s must be at least %s", currency, minimum),
+		"es": fmt.Sprintf("el monto para %s debe ser al menos %s", currency, minimum),
+	})
+}
+
+func ErrAmountAboveMaximum(currency, maximum string) *AppError {
+	return newAppError("AMOUNT_ABOVE_MAXIMUM", http.StatusBadRequest, Messages{
+		"en": fmt.Sprintf("amount for %s must be at most %s", currency, maximum),
+		"es": fmt.Sprintf("el monto para %s debe ser como maximo %s", currency, maximum),
+	})
+}
+
+func ErrDuplicateRidePayment(paymentID string) *AppError {
+	return newAppError("DUPLICATE_RIDE_PAYMENT", http.StatusConflict, Messages{
+		"en": fmt.Sprintf("ride was already charged the same amount by payment %s; set allow_duplicate to charge again", paymentID),
+		"es": fmt.Sprintf("el viaje ya fue cobrado por el mismo monto en el pago %s; use allow_duplicate para cobrar de nuevo", paymentID),
 	})
 }
 
@@ -67,3 +97,17 @@ func ErrInternal() *AppError {
 		"es": "ocurrio un error interno",
 	})
 }
+
+func ErrInvalidPaymentQuery(detail string) *AppError {
+	return newAppError("INVALID_PAYMENT_QUERY", http.StatusBadRequest, Messages{
+		"en": fmt.Sprintf("invalid payment query: %s", detail),
+		"es": fmt.Sprintf("consulta de pagos invalida: %s", detail),
+	})
+}
+
+func ErrInvalidSplit(detail string) *AppError {
+	return newAppError("INVALID_SPLIT", http.StatusBadRequest, Messages{
+		"en": fmt.Sprintf("invalid split: %s", detail),
+		"es": fmt.Sprintf("division invalida: %s", detail),
+	})
+}
@@ -0,0 +1,27 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func ErrInvalidPaymentJobFile(detail string) *AppError {
+	return newAppError("INVALID_PAYMENT_JOB_FILE", http.StatusBadRequest, Messages{
+		"en": fmt.Sprintf("invalid payment file: %s", detail),
+		"es": fmt.Sprintf("archivo de pagos invalido: %s", detail),
+	})
+}
+
+func ErrPaymentJobNotFound() *AppError {
+	return newAppError("PAYMENT_JOB_NOT_FOUND", http.StatusNotFound, Messages{
+		"en": "payment job not found",
+		"es": "trabajo de pagos no encontrado",
+	})
+}
+
+func ErrPaymentJobNotFinished() *AppError {
+	return newAppError("PAYMENT_JOB_NOT_FINISHED", http.StatusConflict, Messages{
+		"en": "the payment job has not finished; its result file is not available yet",
+		"es": "el trabajo de pagos no ha terminado; su archivo de resultados aun no esta disponible",
+	})
+}
@@ -0,0 +1,24 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrInvalidPaymentJobFileIncludesDetail(t *testing.T) {
+	err := ErrInvalidPaymentJobFile("file is empty")
+
+	assert.Equal(t, "INVALID_PAYMENT_JOB_FILE", err.Code)
+	assert.Equal(t, http.StatusBadRequest, err.HTTPCode)
+	assert.Equal(t, "invalid payment file: file is empty", err.Message)
+	assert.Contains(t, err.Localize("es").Message, "archivo de pagos invalido")
+}
+
+func TestErrPaymentJobNotFinished(t *testing.T) {
+	err := ErrPaymentJobNotFinished()
+
+	assert.Equal(t, "PAYMENT_JOB_NOT_FINISHED", err.Code)
+	assert.Equal(t, http.StatusConflict, err.HTTPCode)
+}
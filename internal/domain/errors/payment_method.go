@@ -0,0 +1,20 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func ErrInvalidPaymentMethod(detail string) *AppError {
+	return newAppError("INVALID_PAYMENT_METHOD", http.StatusBadRequest, Messages{
+		"en": fmt.Sprintf("invalid payment method: %s", detail),
+		"es": fmt.Sprintf("metodo de pago invalido: %s", detail),
+	})
+}
+
+func ErrPaymentMethodNotFound() *AppError {
+	return newAppError("PAYMENT_METHOD_NOT_FOUND", http.StatusNotFound, Messages{
+		"en": "payment method not found",
+		"es": "metodo de pago no encontrado",
+	})
+}
@@ -0,0 +1,25 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrInvalidPaymentMethodIncludesDetail(t *testing.T) {
+	err := ErrInvalidPaymentMethod("exp_month is required")
+
+	assert.Equal(t, "INVALID_PAYMENT_METHOD", err.Code)
+	assert.Equal(t, http.StatusBadRequest, err.HTTPCode)
+	assert.Equal(t, "invalid payment method: exp_month is required", err.Message)
+	assert.Contains(t, err.Localize("es").Message, "metodo de pago invalido")
+}
+
+func TestErrPaymentMethodNotFound(t *testing.T) {
+	err := ErrPaymentMethodNotFound()
+
+	assert.Equal(t, "PAYMENT_METHOD_NOT_FOUND", err.Code)
+	assert.Equal(t, http.StatusNotFound, err.HTTPCode)
+	assert.Equal(t, "metodo de pago no encontrado", err.Localize("es").Message)
+}
@@ -88,13 +88,31 @@ func TestErrInvalidPaymentRequest_Localize(t *testing.T) {
 }
 
 func TestErrInvalidCurrencyIncludesCurrencyName(t *testing.T) {
-	err := ErrInvalidCurrency("USD")
+	err := ErrInvalidCurrency("USD", []string{"IDR", "THB"})
 
 	assert.Equal(t, "INVALID_CURRENCY", err.Code)
 	assert.Equal(t, http.StatusBadRequest, err.HTTPCode)
 	assert.Contains(t, err.Message, "USD")
 }
 
+func TestErrInvalidCurrencyListsSupportedCurrencies(t *testing.T) {
+	err := ErrInvalidCurrency("USD", []string{"IDR", "MYR", "SGD"})
+
+	assert.Equal(t, "currency is not supported; valid currencies: IDR, MYR, SGD: USD", err.Message)
+	assert.Contains(t, err.Localize("es").Message, "monedas validas: IDR, MYR, SGD")
+}
+
+func TestErrAmountBounds(t *testing.T) {
+	below := ErrAmountBelowMinimum("THB", "20")
+	assert.Equal(t, "AMOUNT_BELOW_MINIMUM", below.Code)
+	assert.Equal(t, "amount for THB must be at least 20", below.Message)
+
+	above := ErrAmountAboveMaximum("THB", "50000")
+	assert.Equal(t, "AMOUNT_ABOVE_MAXIMUM", above.Code)
+	assert.Equal(t, http.StatusBadRequest, above.HTTPCode)
+	assert.Contains(t, above.Localize("es").Message, "como maximo 50000")
+}
+
 func TestErrInternal(t *testing.T) {
 	err := ErrInternal()
 
@@ -109,6 +127,47 @@ func TestErrInternal_Localize(t *testing.T) {
 	assert.Equal(t, "ocurrio un error interno", err.Message)
 }
 
+func TestErrInvalidPaymentQueryIncludesDetail(t *testing.T) {
+	err := ErrInvalidPaymentQuery("limit must be between 1 and 100")
+
+	assert.Equal(t, "INVALID_PAYMENT_QUERY", err.Code)
+	assert.Equal(t, http.StatusBadRequest, err.HTTPCode)
+	assert.Equal(t, "invalid payment query: limit must be between 1 and 100", err.Message)
+}
+
+func TestErrInvalidPaymentQuery_Localize(t *testing.T) {
+	err := ErrInvalidPaymentQuery("bad cursor").Localize("es")
+
+	assert.Contains(t, err.Message, "consulta de pagos invalida")
+}
+
+func TestErrInvalidSplitIncludesDetail(t *testing.T) {
+	err := ErrInvalidSplit("splits must sum to the payment amount")
+
+	assert.Equal(t, "INVALID_SPLIT", err.Code)
+	assert.Equal(t, http.StatusBadRequest, err.HTTPCode)
+	assert.Equal(t, "invalid split: splits must sum to the payment amount", err.Message)
+	assert.Contains(t, err.Localize("es").Message, "division invalida")
+}
+
+func TestErrInvalidAmountPrecision(t *testing.T) {
+	err := ErrInvalidAmountPrecision("THB", 2)
+
+	assert.Equal(t, "INVALID_AMOUNT_PRECISION", err.Code)
+	assert.Equal(t, http.StatusBadRequest, err.HTTPCode)
+	assert.Equal(t, "amount for THB allows at most 2 decimal places", err.Message)
+	assert.Contains(t, err.Localize("es").Message, "como maximo 2 decimales")
+}
+
+func TestErrDuplicateRidePayment(t *testing.T) {
+	err := ErrDuplicateRidePayment("pay-123")
+
+	assert.Equal(t, "DUPLICATE_RIDE_PAYMENT", err.Code)
+	assert.Equal(t, http.StatusConflict, err.HTTPCode)
+	assert.Contains(t, err.Message, "pay-123")
+	assert.Contains(t, err.Localize("es").Message, "allow_duplicate")
+}
+
 func TestAllErrors_DefaultToEnglish(t *testing.T) {
 	errors := []*AppError{
 		ErrIdempotencyKeyMissing(),
@@ -118,7 +177,14 @@ func TestAllErrors_DefaultToEnglish(t *testing.T) {
 		ErrPaymentNotFound(),
 		ErrIdempotencyKeyNotFound(),
 		ErrInvalidPaymentRequest("test"),
-		ErrInvalidCurrency("USD"),
+		ErrInvalidCurrency("USD", []string{"IDR"}),
+		ErrAmountBelowMinimum("IDR", "1000"),
+		ErrAmountAboveMaximum("IDR", "1000000"),
+		ErrDuplicateRidePayment("pay-1"),
+		ErrInvalidPaymentQuery("test"),
+		ErrInvalidAmountPrecision("IDR", 0),
+		ErrUnknownCustomer("cust-1"),
+		ErrInvalidSplit("test"),
 		ErrInternal(),
 	}
 
@@ -0,0 +1,27 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func ErrInvalidProcessorSignature() *AppError {
+	return newAppError("INVALID_PROCESSOR_SIGNATURE", http.StatusUnauthorized, Messages{
+		"en": "processor event signature is missing, invalid or expired",
+		"es": "la firma del evento del procesador falta, es invalida o expiro",
+	})
+}
+
+func ErrInvalidProcessorEvent(detail string) *AppError {
+	return newAppError("INVALID_PROCESSOR_EVENT", http.StatusBadRequest, Messages{
+		"en": fmt.Sprintf("invalid processor event: %s", detail),
+		"es": fmt.Sprintf("evento del procesador invalido: %s", detail),
+	})
+}
+
+func ErrProcessorUnavailable() *AppError {
+	return newAppError("PROCESSOR_UNAVAILABLE", http.StatusServiceUnavailable, Messages{
+		"en": "payment processor is temporarily unavailable, retry with the same idempotency key",
+		"es": "el procesador de pagos no esta disponible temporalmente, reintente con la misma clave de idempotencia",
+	})
+}
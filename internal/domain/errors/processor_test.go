@@ -0,0 +1,38 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrInvalidProcessorSignature(t *testing.T) {
+	err := ErrInvalidProcessorSignature()
+
+	assert.Equal(t, "INVALID_PROCESSOR_SIGNATURE", err.Code)
+	assert.Equal(t, http.StatusUnauthorized, err.HTTPCode)
+	assert.Equal(t, "processor event signature is missing, invalid or expired", err.Message)
+}
+
+func TestErrInvalidProcessorEventIncludesDetail(t *testing.T) {
+	err := ErrInvalidProcessorEvent("event_id is required")
+
+	assert.Equal(t, "INVALID_PROCESSOR_EVENT", err.Code)
+	assert.Equal(t, http.StatusBadRequest, err.HTTPCode)
+	assert.Equal(t, "invalid processor event: event_id is required", err.Message)
+}
+
+func TestErrInvalidProcessorEvent_Localize(t *testing.T) {
+	err := ErrInvalidProcessorEvent("event_id is required").Localize("es")
+
+	assert.Contains(t, err.Message, "evento del procesador invalido")
+}
+
+func TestErrProcessorUnavailable(t *testing.T) {
+	err := ErrProcessorUnavailable()
+
+	assert.Equal(t, "PROCESSOR_UNAVAILABLE", err.Code)
+	assert.Equal(t, http.StatusServiceUnavailable, err.HTTPCode)
+	assert.Contains(t, err.Localize("es").Message, "no esta disponible")
+}
@@ -0,0 +1,13 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func ErrRateLimited(retryAfterSeconds int) *AppError {
+	return newAppError("RATE_LIMITED", http.StatusTooManyRequests, Messages{
+		"en": fmt.Sprintf("too many requests, retry in %d seconds", retryAfterSeconds),
+		"es": fmt.Sprintf("demasiadas solicitudes, reintente en %d segundos", retryAfterSeconds),
+	})
+}
@@ -0,0 +1,17 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrRateLimitedIncludesRetryAfter(t *testing.T) {
+	err := ErrRateLimited(12)
+
+	assert.Equal(t, "RATE_LIMITED", err.Code)
+	assert.Equal(t, http.StatusTooManyRequests, err.HTTPCode)
+	assert.Equal(t, "too many requests, retry in 12 seconds", err.Message)
+	assert.Equal(t, "demasiadas solicitudes, reintente en 12 segundos", err.Localize("es").Message)
+}
@@ -0,0 +1,20 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func ErrInvalidReceiptRequest(detail string) *AppError {
+	return newAppError("INVALID_RECEIPT_REQUEST", http.StatusBadRequest, Messages{
+		"en": fmt.Sprintf("invalid receipt request: %s", detail),
+		"es": fmt.Sprintf("solicitud de recibo invalida: %s", detail),
+	})
+}
+
+func ErrReceiptUnavailable(status string) *AppError {
+	return newAppError("RECEIPT_UNAVAILABLE", http.StatusConflict, Messages{
+		"en": fmt.Sprintf("no receipt is issued for a %s payment", status),
+		"es": fmt.Sprintf("no se emite recibo para un pago en estado %s", status),
+	})
+}
@@ -0,0 +1,24 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrInvalidReceiptRequestIncludesDetail(t *testing.T) {
+	err := ErrInvalidReceiptRequest("format must be html or text")
+
+	assert.Equal(t, "INVALID_RECEIPT_REQUEST", err.Code)
+	assert.Equal(t, http.StatusBadRequest, err.HTTPCode)
+	assert.Equal(t, "invalid receipt request: format must be html or text", err.Message)
+}
+
+func TestErrReceiptUnavailableLocalizes(t *testing.T) {
+	err := ErrReceiptUnavailable("FAILED")
+
+	assert.Equal(t, "RECEIPT_UNAVAILABLE", err.Code)
+	assert.Equal(t, http.StatusConflict, err.HTTPCode)
+	assert.Equal(t, "no se emite recibo para un pago en estado FAILED", err.Localize("es-MX").Message)
+}
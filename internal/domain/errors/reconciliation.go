@@ -0,0 +1,34 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func ErrInvalidSettlementFile(detail string) *AppError {
+	return newAppError("INVALID_SETTLEMENT_FILE", http.StatusBadRequest, Messages{
+		"en": fmt.Sprintf("invalid settlement file: %s", detail),
+		"es": fmt.Sprintf("archivo de liquidacion invalido: %s", detail),
+	})
+}
+
+func ErrInvalidSettlementDate() *AppError {
+	return newAppError("INVALID_SETTLEMENT_DATE", http.StatusBadRequest, Messages{
+		"en": "settlement_date must be a date in YYYY-MM-DD format",
+		"es": "settlement_date debe ser una fecha con formato YYYY-MM-DD",
+	})
+}
+
+func ErrReconciliationNotFound() *AppError {
+	return newAppError("RECONCILIATION_NOT_FOUND", http.StatusNotFound, Messages{
+		"en": "reconciliation not found",
+		"es": "conciliacion no encontrada",
+	})
+}
+
+func ErrSettlementFileUnavailable() *AppError {
+	return newAppError("SETTLEMENT_FILE_UNAVAILABLE", http.StatusNotFound, Messages{
+		"en": "the configured processor does not produce settlement files",
+		"es": "el procesador configurado no genera archivos de liquidacion",
+	})
+}
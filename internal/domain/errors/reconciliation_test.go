@@ -0,0 +1,38 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrInvalidSettlementFileIncludesDetail(t *testing.T) {
+	err := ErrInvalidSettlementFile("line 3: processor_reference is required")
+
+	assert.Equal(t, "INVALID_SETTLEMENT_FILE", err.Code)
+	assert.Equal(t, http.StatusBadRequest, err.HTTPCode)
+	assert.Equal(t, "invalid settlement file: line 3: processor_reference is required", err.Message)
+	assert.Contains(t, err.Localize("es").Message, "archivo de liquidacion invalido")
+}
+
+func TestErrInvalidSettlementDate(t *testing.T) {
+	err := ErrInvalidSettlementDate()
+
+	assert.Equal(t, "INVALID_SETTLEMENT_DATE", err.Code)
+	assert.Equal(t, http.StatusBadRequest, err.HTTPCode)
+}
+
+func TestErrReconciliationNotFound(t *testing.T) {
+	err := ErrReconciliationNotFound()
+
+	assert.Equal(t, "RECONCILIATION_NOT_FOUND", err.Code)
+	assert.Equal(t, http.StatusNotFound, err.HTTPCode)
+}
+
+func TestErrSettlementFileUnavailable(t *testing.T) {
+	err := ErrSettlementFileUnavailable()
+
+	assert.Equal(t, "SETTLEMENT_FILE_UNAVAILABLE", err.Code)
+	assert.Equal(t, http.StatusNotFound, err.HTTPCode)
+}
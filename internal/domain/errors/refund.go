@@ -0,0 +1,27 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func ErrInvalidRefund(detail string) *AppError {
+	return newAppError("INVALID_REFUND", http.StatusBadRequest, Messages{
+		"en": fmt.Sprintf("invalid refund: %s", detail),
+		"es": fmt.Sprintf("reembolso invalido: %s", detail),
+	})
+}
+
+func ErrRefundNotAllowed(detail string) *AppError {
+	return newAppError("REFUND_NOT_ALLOWED", http.StatusConflict, Messages{
+		"en": fmt.Sprintf("refund not allowed: %s", detail),
+		"es": fmt.Sprintf("reembolso no permitido: %s", detail),
+	})
+}
+
+func ErrRefundExceedsPayment(currency, remaining string) *AppError {
+	return newAppError("REFUND_EXCEEDS_PAYMENT", http.StatusUnprocessableEntity, Messages{
+		"en": fmt.Sprintf("refund exceeds the refundable amount; at most %s %s can still be refunded", remaining, currency),
+		"es": fmt.Sprintf("el reembolso supera el monto reembolsable; como maximo se pueden reembolsar %s %s", remaining, currency),
+	})
+}
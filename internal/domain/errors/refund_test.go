@@ -0,0 +1,32 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrInvalidRefundIncludesDetail(t *testing.T) {
+	err := ErrInvalidRefund("amount must be greater than 0")
+
+	assert.Equal(t, "INVALID_REFUND", err.Code)
+	assert.Equal(t, http.StatusBadRequest, err.HTTPCode)
+	assert.Equal(t, "invalid refund: amount must be greater than 0", err.Message)
+}
+
+func TestErrRefundNotAllowed(t *testing.T) {
+	err := ErrRefundNotAllowed("payment has not succeeded")
+
+	assert.Equal(t, "REFUND_NOT_ALLOWED", err.Code)
+	assert.Equal(t, http.StatusConflict, err.HTTPCode)
+	assert.Contains(t, err.Localize("es").Message, "reembolso no permitido")
+}
+
+func TestErrRefundExceedsPayment(t *testing.T) {
+	err := ErrRefundExceedsPayment("THB", "12.5")
+
+	assert.Equal(t, "REFUND_EXCEEDS_PAYMENT", err.Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, err.HTTPCode)
+	assert.Contains(t, err.Message, "12.5 THB")
+}
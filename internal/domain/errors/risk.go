@@ -0,0 +1,10 @@
+package errors
+
+import "net/http"
+
+func ErrRiskAssessmentNotFound() *AppError {
+	return newAppError("RISK_ASSESSMENT_NOT_FOUND", http.StatusNotFound, Messages{
+		"en": "no risk assessment recorded for this payment",
+		"es": "no hay evaluacion de riesgo registrada para este pago",
+	})
+}
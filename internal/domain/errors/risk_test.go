@@ -0,0 +1,16 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrRiskAssessmentNotFound(t *testing.T) {
+	err := ErrRiskAssessmentNotFound()
+
+	assert.Equal(t, "RISK_ASSESSMENT_NOT_FOUND", err.Code)
+	assert.Equal(t, http.StatusNotFound, err.HTTPCode)
+	assert.Contains(t, err.Localize("es").Message, "evaluacion de riesgo")
+}
@@ -0,0 +1,27 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func ErrInvalidSchedule(detail string) *AppError {
+	return newAppError("INVALID_SCHEDULE", http.StatusBadRequest, Messages{
+		"en": fmt.Sprintf("invalid scheduled payment: %s", detail),
+		"es": fmt.Sprintf("pago programado invalido: %s", detail),
+	})
+}
+
+func ErrScheduledPaymentNotFound() *AppError {
+	return newAppError("SCHEDULED_PAYMENT_NOT_FOUND", http.StatusNotFound, Messages{
+		"en": "scheduled payment not found",
+		"es": "pago programado no encontrado",
+	})
+}
+
+func ErrScheduledPaymentNotCancelable(status string) *AppError {
+	return newAppError("SCHEDULED_PAYMENT_NOT_CANCELABLE", http.StatusConflict, Messages{
+		"en": fmt.Sprintf("only SCHEDULED payments can be canceled; this one is %s", status),
+		"es": fmt.Sprintf("solo los pagos en estado SCHEDULED pueden cancelarse; este esta en %s", status),
+	})
+}
@@ -0,0 +1,25 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrInvalidScheduleIncludesDetail(t *testing.T) {
+	err := ErrInvalidSchedule("scheduled_at must be in the future")
+
+	assert.Equal(t, "INVALID_SCHEDULE", err.Code)
+	assert.Equal(t, http.StatusBadRequest, err.HTTPCode)
+	assert.Equal(t, "invalid scheduled payment: scheduled_at must be in the future", err.Message)
+	assert.Contains(t, err.Localize("es").Message, "pago programado invalido")
+}
+
+func TestErrScheduledPaymentNotCancelableIncludesStatus(t *testing.T) {
+	err := ErrScheduledPaymentNotCancelable("EXECUTED")
+
+	assert.Equal(t, "SCHEDULED_PAYMENT_NOT_CANCELABLE", err.Code)
+	assert.Equal(t, http.StatusConflict, err.HTTPCode)
+	assert.Contains(t, err.Message, "EXECUTED")
+}
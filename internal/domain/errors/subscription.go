@@ -0,0 +1,48 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func ErrInvalidPlan(detail string) *AppError {
+	return newAppError("INVALID_PLAN", http.StatusBadRequest, Messages{
+		"en": fmt.Sprintf("invalid plan: %s", detail),
+		"es": fmt.Sprintf("plan invalido: %s", detail),
+	})
+}
+
+func ErrPlanNotFound() *AppError {
+	return newAppError("PLAN_NOT_FOUND", http.StatusNotFound, Messages{
+		"en": "plan not found",
+		"es": "plan no encontrado",
+	})
+}
+
+func ErrInvalidSubscription(detail string) *AppError {
+	return newAppError("INVALID_SUBSCRIPTION", http.StatusBadRequest, Messages{
+		"en": fmt.Sprintf("invalid subscription: %s", detail),
+		"es": fmt.Sprintf("suscripcion invalida: %s", detail),
+	})
+}
+
+func ErrSubscriptionNotFound() *AppError {
+	return newAppError("SUBSCRIPTION_NOT_FOUND", http.StatusNotFound, Messages{
+		"en": "subscription not found",
+		"es": "suscripcion no encontrada",
+	})
+}
+
+func ErrSubscriptionExists(id string) *AppError {
+	return newAppError("SUBSCRIPTION_EXISTS", http.StatusConflict, Messages{
+		"en": fmt.Sprintf("the customer already has subscription %s to this plan", id),
+		"es": fmt.Sprintf("el cliente ya tiene la suscripcion %s a este plan", id),
+	})
+}
+
+func ErrInvalidSubscriptionTransition(action, status string) *AppError {
+	return newAppError("INVALID_SUBSCRIPTION_TRANSITION", http.StatusConflict, Messages{
+		"en": fmt.Sprintf("cannot %s a %s subscription", action, status),
+		"es": fmt.Sprintf("no se puede aplicar %s a una suscripcion en estado %s", action, status),
+	})
+}
@@ -0,0 +1,33 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrInvalidPlanIncludesDetail(t *testing.T) {
+	err := ErrInvalidPlan("name is required")
+
+	assert.Equal(t, "INVALID_PLAN", err.Code)
+	assert.Equal(t, http.StatusBadRequest, err.HTTPCode)
+	assert.Equal(t, "invalid plan: name is required", err.Message)
+	assert.Contains(t, err.Localize("es").Message, "plan invalido")
+}
+
+func TestErrInvalidSubscriptionTransition(t *testing.T) {
+	err := ErrInvalidSubscriptionTransition("resume", "CANCELED")
+
+	assert.Equal(t, "INVALID_SUBSCRIPTION_TRANSITION", err.Code)
+	assert.Equal(t, http.StatusConflict, err.HTTPCode)
+	assert.Equal(t, "cannot resume a CANCELED subscription", err.Message)
+}
+
+func TestErrSubscriptionExists(t *testing.T) {
+	err := ErrSubscriptionExists("sub-1")
+
+	assert.Equal(t, "SUBSCRIPTION_EXISTS", err.Code)
+	assert.Equal(t, http.StatusConflict, err.HTTPCode)
+	assert.Contains(t, err.Message, "sub-1")
+}
@@ -0,0 +1,20 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func ErrTipNotAllowed(detail string) *AppError {
+	return newAppError("TIP_NOT_ALLOWED", http.StatusConflict, Messages{
+		"en": fmt.Sprintf("tip not allowed: %s", detail),
+		"es": fmt.Sprintf("propina no permitida: %s", detail),
+	})
+}
+
+func ErrTipLimitExceeded(currency, remaining string) *AppError {
+	return newAppError("TIP_LIMIT_EXCEEDED", http.StatusUnprocessableEntity, Messages{
+		"en": fmt.Sprintf("tip exceeds the maximum for this payment; at most %s %s can still be tipped", remaining, currency),
+		"es": fmt.Sprintf("la propina supera el maximo para este pago; como maximo se pueden dar %s %s", remaining, currency),
+	})
+}
@@ -0,0 +1,25 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrTipNotAllowedIncludesDetail(t *testing.T) {
+	err := ErrTipNotAllowed("parent payment has not succeeded")
+
+	assert.Equal(t, "TIP_NOT_ALLOWED", err.Code)
+	assert.Equal(t, http.StatusConflict, err.HTTPCode)
+	assert.Equal(t, "tip not allowed: parent payment has not succeeded", err.Message)
+	assert.Contains(t, err.Localize("es").Message, "propina no permitida")
+}
+
+func TestErrTipLimitExceeded(t *testing.T) {
+	err := ErrTipLimitExceeded("THB", "12.5")
+
+	assert.Equal(t, "TIP_LIMIT_EXCEEDED", err.Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, err.HTTPCode)
+	assert.Contains(t, err.Message, "12.5 THB")
+}
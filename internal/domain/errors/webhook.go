@@ -0,0 +1,27 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func ErrInvalidWebhookEndpoint(detail string) *AppError {
+	return newAppError("INVALID_WEBHOOK_ENDPOINT", http.StatusBadRequest, Messages{
+		"en": fmt.Sprintf("invalid webhook endpoint: %s", detail),
+		"es": fmt.Sprintf("endpoint de webhook invalido: %s", detail),
+	})
+}
+
+func ErrWebhookEndpointNotFound() *AppError {
+	return newAppError("WEBHOOK_ENDPOINT_NOT_FOUND", http.StatusNotFound, Messages{
+		"en": "webhook endpoint not found",
+		"es": "endpoint de webhook no encontrado",
+	})
+}
+
+func ErrEventNotFound() *AppError {
+	return newAppError("EVENT_NOT_FOUND", http.StatusNotFound, Messages{
+		"en": "event not found",
+		"es": "evento no encontrado",
+	})
+}
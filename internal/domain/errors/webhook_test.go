@@ -0,0 +1,44 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrInvalidWebhookEndpointIncludesDetail(t *testing.T) {
+	err := ErrInvalidWebhookEndpoint("url is required")
+
+	assert.Equal(t, "INVALID_WEBHOOK_ENDPOINT", err.Code)
+	assert.Equal(t, http.StatusBadRequest, err.HTTPCode)
+	assert.Equal(t, "invalid webhook endpoint: url is required", err.Message)
+}
+
+func TestErrInvalidWebhookEndpoint_Localize(t *testing.T) {
+	err := ErrInvalidWebhookEndpoint("url is required").Localize("es")
+
+	assert.Contains(t, err.Message, "endpoint de webhook invalido")
+}
+
+func TestErrWebhookEndpointNotFound(t *testing.T) {
+	err := ErrWebhookEndpointNotFound()
+
+	assert.Equal(t, "WEBHOOK_ENDPOINT_NOT_FOUND", err.Code)
+	assert.Equal(t, http.StatusNotFound, err.HTTPCode)
+	assert.Equal(t, "webhook endpoint not found", err.Message)
+}
+
+func TestErrEventNotFound(t *testing.T) {
+	err := ErrEventNotFound()
+
+	assert.Equal(t, "EVENT_NOT_FOUND", err.Code)
+	assert.Equal(t, http.StatusNotFound, err.HTTPCode)
+	assert.Equal(t, "event not found", err.Message)
+}
+
+func TestErrEventNotFound_Localize(t *testing.T) {
+	err := ErrEventNotFound().Localize("es")
+
+	assert.Equal(t, "evento no encontrado", err.Message)
+}
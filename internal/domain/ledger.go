@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptyJournalEntry      = errors.New("journal entry needs at least two postings")
+	ErrZeroPosting            = errors.New("posting amount must not be zero")
+	ErrUnbalancedJournalEntry = errors.New("journal entry postings must balance per currency")
+)
+
+type LedgerAccountType string
+
+const (
+	LedgerAccountAsset     LedgerAccountType = "ASSET"
+	LedgerAccountLiability LedgerAccountType = "LIABILITY"
+	LedgerAccountRevenue   LedgerAccountType = "REVENUE"
+	LedgerAccountExpense   LedgerAccountType = "EXPENSE"
+)
+
+type JournalEntryType string
+
+const (
+	JournalEntryCharge   JournalEntryType = "CHARGE"
+	JournalEntryFee      JournalEntryType = "FEE"
+	JournalEntryRefund   JournalEntryType = "REFUND"
+	JournalEntryReversal JournalEntryType = "REVERSAL"
+)
+
+const (
+	AccountProcessorReceivable = "processor_receivable"
+	AccountMerchantPayable     = "merchant_payable"
+	AccountTipsPayable         = "tips_payable"
+	AccountProcessingFees      = "processing_fees"
+	AccountRecipientPrefix     = "recipient:"
+)
+
+type LedgerAccount struct {
+	ID        string            `json:"id" gorm:"primaryKey;type:varchar(150)"`
+	Code      string            `json:"code" gorm:"type:varchar(120);not null"`
+	Type      LedgerAccountType `json:"type" gorm:"type:varchar(20);not null"`
+	Currency  Currency          `json:"currency" gorm:"type:varchar(3);not null"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+func (LedgerAccount) TableName() string {
+	return "ledger_accounts"
+}
+
+func NewLedgerAccount(code string, accountType LedgerAccountType, currency Currency) *LedgerAccount {
+	return &LedgerAccount{
+		ID:       LedgerAccountID(code, currency),
+		Code:     code,
+		Type:     accountType,
+		Currency: currency,
+	}
+}
+
+func LedgerAccountID(code string, currency Currency) string {
+	return fmt.Sprintf("%s:%s", code, currency)
+}
+
+type JournalEntry struct {
+	ID          string           `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	PaymentID   string           `json:"payment_id" gorm:"type:varchar(36);index"`
+	Type        JournalEntryType `json:"type" gorm:"type:varchar(20);not null"`
+	Description string           `json:"description,omitempty" gorm:"type:text"`
+	CreatedAt   time.Time        `json:"created_at" gorm:"index"`
+	Postings    []*Posting       `json:"postings" gorm:"foreignKey:EntryID"`
+}
+
+func (JournalEntry) TableName() string {
+	return "ledger_entries"
+}
+
+type Posting struct {
+	EntryID   string    `json:"-" gorm:"primaryKey;type:varchar(36)"`
+	Position  int       `json:"-" gorm:"primaryKey"`
+	AccountID string    `json:"account_id" gorm:"type:varchar(150);not null;index:idx_ledger_postings_account_time,priority:1"`
+	Amount    int64     `json:"amount_minor" gorm:"type:bigint;not null"`
+	Currency  Currency  `json:"currency" gorm:"type:varchar(3);not null"`
+	CreatedAt time.Time `json:"-" gorm:"index:idx_ledger_postings_account_time,priority:2"`
+}
+
+func (Posting) TableName() string {
+	return "ledger_postings"
+}
+
+func (e *JournalEntry) Debit(account *LedgerAccount, amount int64) {
+	e.post(account, amount)
+}
+
+func (e *JournalEntry) Credit(account *LedgerAccount, amount int64) {
+	e.post(account, -amount)
+}
+
+func (e *JournalEntry) post(account *LedgerAccount, amount int64) {
+	e.Postings = append(e.Postings, &Posting{
+		EntryID:   e.ID,
+		Position:  len(e.Postings),
+		AccountID: account.ID,
+		Amount:    amount,
+		Currency:  account.Currency,
+		CreatedAt: e.CreatedAt,
+	})
+}
+
+func (e *JournalEntry) RefundedAmount() int64 {
+	if e.Type != JournalEntryRefund && e.Type != JournalEntryReversal {
+		return 0
+	}
+
+	var refunded int64
+	for _, p := range e.Postings {
+		if IsPayableAccount(p.AccountID) && p.Amount > 0 {
+			refunded += p.Amount
+		}
+	}
+	return refunded
+}
+
+func IsPayableAccount(accountID string) bool {
+	return strings.HasPrefix(accountID, AccountMerchantPayable+":") ||
+		strings.HasPrefix(accountID, AccountTipsPayable+":") ||
+		strings.HasPrefix(accountID, AccountRecipientPrefix)
+}
+
+func (e *JournalEntry) Validate() error {
+	if len(e.Postings) < 2 {
+		return ErrEmptyJournalEntry
+	}
+
+	sums := make(map[Currency]int64)
+	for _, p := range e.Postings {
+		if p.Amount == 0 {
+			return ErrZeroPosting
+		}
+		sums[p.Currency] += p.Amount
+	}
+	for _, sum := range sums {
+		if sum != 0 {
+			return ErrUnbalancedJournalEntry
+		}
+	}
+	return nil
+}
+
+type LedgerBalanceFilter struct {
+	AccountID string
+	Currency  Currency
+	AsOf      time.Time
+}
+
+type AccountBalance struct {
+	AccountID string            `json:"account_id"`
+	Type      LedgerAccountType `json:"type"`
+	Currency  Currency          `json:"currency"`
+	Debits    int64             `json:"debits_minor"`
+	Credits   int64             `json:"credits_minor"`
+	Balance   int64             `json:"balance_minor"`
+	AsOf      time.Time         `json:"as_of"`
+}
+
+func (b *AccountBalance) ApplyNormalSide() {
+	switch b.Type {
+	case LedgerAccountAsset, LedgerAccountExpense:
+		b.Balance = b.Debits - b.Credits
+	default:
+		b.Balance = b.Credits - b.Debits
+	}
+}
+
+type accountBalanceJSON AccountBalance
+
+func (b AccountBalance) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		accountBalanceJSON
+		DecimalBalance json.Number `json:"balance"`
+	}{
+		accountBalanceJSON: accountBalanceJSON(b),
+		DecimalBalance:     NewMoney(b.Balance, b.Currency).Number(),
+	})
+}
+
+type postingJSON Posting
+
+func (p Posting) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		postingJSON
+		DecimalAmount json.Number `json:"amount"`
+	}{
+		postingJSON:   postingJSON(p),
+		DecimalAmount: NewMoney(p.Amount, p.Currency).Number(),
+	})
+}
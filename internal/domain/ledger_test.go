@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournalEntryValidate(t *testing.T) {
+	receivable := NewLedgerAccount(AccountProcessorReceivable, LedgerAccountAsset, CurrencyIDR)
+	merchant := NewLedgerAccount(AccountMerchantPayable, LedgerAccountLiability, CurrencyIDR)
+	thbMerchant := NewLedgerAccount(AccountMerchantPayable, LedgerAccountLiability, CurrencyTHB)
+
+	balanced := &JournalEntry{ID: "e1"}
+	balanced.Debit(receivable, 1000)
+	balanced.Credit(merchant, 1000)
+	assert.NoError(t, balanced.Validate())
+	assert.Equal(t, 1, balanced.Postings[1].Position)
+	assert.Equal(t, "merchant_payable:IDR", balanced.Postings[1].AccountID)
+
+	unbalanced := &JournalEntry{ID: "e2"}
+	unbalanced.Debit(receivable, 1000)
+	unbalanced.Credit(merchant, 900)
+	assert.ErrorIs(t, unbalanced.Validate(), ErrUnbalancedJournalEntry)
+
+	mixed := &JournalEntry{ID: "e3"}
+	mixed.Debit(receivable, 1000)
+	mixed.Credit(thbMerchant, 1000)
+	assert.ErrorIs(t, mixed.Validate(), ErrUnbalancedJournalEntry)
+
+	single := &JournalEntry{ID: "e4"}
+	single.Debit(receivable, 1000)
+	assert.ErrorIs(t, single.Validate(), ErrEmptyJournalEntry)
+
+	zero := &JournalEntry{ID: "e5"}
+	zero.Debit(receivable, 0)
+	zero.Credit(merchant, 0)
+	assert.ErrorIs(t, zero.Validate(), ErrZeroPosting)
+}
+
+func TestAccountBalanceNormalSide(t *testing.T) {
+	asset := &AccountBalance{Type: LedgerAccountAsset, Debits: 1000, Credits: 250}
+	asset.ApplyNormalSide()
+	assert.Equal(t, int64(750), asset.Balance)
+
+	liability := &AccountBalance{Type: LedgerAccountLiability, Debits: 250, Credits: 1000}
+	liability.ApplyNormalSide()
+	assert.Equal(t, int64(750), liability.Balance)
+}
+
+func TestAccountBalanceJSON(t *testing.T) {
+	balance := AccountBalance{
+		AccountID: "merchant_payable:THB",
+		Type:      LedgerAccountLiability,
+		Currency:  CurrencyTHB,
+		Credits:   1050,
+		Balance:   1050,
+		AsOf:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	data, err := json.Marshal(balance)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"account_id":"merchant_payable:THB","type":"LIABILITY","currency":"THB",
+		"debits_minor":0,"credits_minor":1050,"balance_minor":1050,"balance":10.5,
+		"as_of":"2026-01-01T00:00:00Z"
+	}`, string(data))
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type PaymentStatus string
 
@@ -8,6 +11,8 @@ const (
 	PaymentStatusSucceeded PaymentStatus = "SUCCEEDED"
 	PaymentStatusFailed    PaymentStatus = "FAILED"
 	PaymentStatusPending   PaymentStatus = "PENDING"
+	PaymentStatusBlocked   PaymentStatus = "BLOCKED"
+	PaymentStatusUnknown   PaymentStatus = "UNKNOWN"
 )
 
 type Currency string
@@ -19,13 +24,6 @@ const (
 	CurrencyPHP Currency = "PHP"
 )
 
-var ValidCurrencies = map[Currency]bool{
-	CurrencyIDR: true,
-	CurrencyTHB: true,
-	CurrencyVND: true,
-	CurrencyPHP: true,
-}
-
 type IdempotencyStatus string
 
 const (
@@ -33,38 +31,207 @@ const (
 	IdempotencyStatusCompleted  IdempotencyStatus = "COMPLETED"
 )
 
+type OutboxStatus string
+
+const (
+	OutboxStatusPending    OutboxStatus = "PENDING"
+	OutboxStatusDispatched OutboxStatus = "DISPATCHED"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "DELIVERED"
+	WebhookDeliveryStatusDead      WebhookDeliveryStatus = "DEAD"
+)
+
+const (
+	EventPaymentSucceeded = "payment.succeeded"
+	EventPaymentFailed    = "payment.failed"
+	EventPaymentPending   = "payment.pending"
+	EventPaymentBlocked   = "payment.blocked"
+	EventPaymentUnknown   = "payment.unknown"
+)
+
+func PaymentEventType(status PaymentStatus) string {
+	switch status {
+	case PaymentStatusSucceeded:
+		return EventPaymentSucceeded
+	case PaymentStatusFailed:
+		return EventPaymentFailed
+	case PaymentStatusBlocked:
+		return EventPaymentBlocked
+	case PaymentStatusUnknown:
+		return EventPaymentUnknown
+	default:
+		return EventPaymentPending
+	}
+}
+
+type ProcessorEventOutcome string
+
+const (
+	ProcessorEventOutcomeApplied ProcessorEventOutcome = "APPLIED"
+	ProcessorEventOutcomeIgnored ProcessorEventOutcome = "IGNORED"
+)
+
 type PaymentRequest struct {
-	Amount      float64  `json:"amount"`
-	Currency    Currency `json:"currency"`
-	CustomerID  string   `json:"customer_id"`
-	RideID      string   `json:"ride_id"`
-	CardNumber  string   `json:"card_number"`
-	Description string   `json:"description,omitempty"`
+	Amount      json.Number `json:"amount"`
+	Currency    Currency    `json:"currency"`
+	CustomerID  string      `json:"customer_id"`
+	RideID      string      `json:"ride_id"`
+	CardNumber  string      `json:"card_number,omitempty"`
+	ExpMonth    int         `json:"exp_month,omitempty"`
+	ExpYear     int         `json:"exp_year,omitempty"`
+	CVV         string      `json:"cvv,omitempty"`
+	Description string      `json:"description,omitempty"`
+
+	AllowDuplicate  bool           `json:"allow_duplicate,omitempty"`
+	PaymentMethodID string         `json:"payment_method_id,omitempty"`
+	Splits          []SplitRequest `json:"splits,omitempty"`
+	ParentPaymentID string         `json:"parent_payment_id,omitempty"`
+	ScheduledAt     *time.Time     `json:"scheduled_at,omitempty"`
+}
+
+type TipRequest struct {
+	Amount      json.Number `json:"amount"`
+	CVV         string      `json:"cvv,omitempty"`
+	Description string      `json:"description,omitempty"`
+}
+
+func (r PaymentRequest) Money() (Money, error) {
+	return ParseMoney(r.Amount.String(), r.Currency)
 }
 
 type Payment struct {
-	ID          string        `json:"id" gorm:"primaryKey;type:varchar(36)"`
-	Amount      float64       `json:"amount" gorm:"not null"`
-	Currency    Currency      `json:"currency" gorm:"type:varchar(3);not null"`
-	CustomerID  string        `json:"customer_id" gorm:"type:varchar(100);not null"`
-	RideID      string        `json:"ride_id" gorm:"type:varchar(100);not null"`
-	Status      PaymentStatus `json:"status" gorm:"type:varchar(20);not null"`
-	CardLast4   string        `json:"card_last_4" gorm:"type:varchar(4)"`
-	Description string        `json:"description,omitempty" gorm:"type:text"`
-	FailReason  string        `json:"fail_reason,omitempty" gorm:"type:text"`
-	CreatedAt   time.Time     `json:"created_at" gorm:"autoCreateTime"`
+	ID         string        `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	Amount     int64         `json:"amount_minor" gorm:"type:bigint;not null"`
+	Currency   Currency      `json:"currency" gorm:"type:varchar(3);not null"`
+	CustomerID string        `json:"customer_id" gorm:"type:varchar(100);not null"`
+	RideID     string        `json:"ride_id" gorm:"type:varchar(100);not null"`
+	Status     PaymentStatus `json:"status" gorm:"type:varchar(20);not null"`
+	CardLast4  string        `json:"card_last_4" gorm:"type:varchar(4)"`
+	CardBrand  CardBrand     `json:"card_brand,omitempty" gorm:"type:varchar(20)"`
+
+	PaymentMethodID string    `json:"payment_method_id,omitempty" gorm:"type:varchar(40);index"`
+	Description     string    `json:"description,omitempty" gorm:"type:text"`
+	FailReason      string    `json:"fail_reason,omitempty" gorm:"type:text"`
+	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime"`
+
+	RiskDecision RiskDecision `json:"risk_decision,omitempty" gorm:"type:varchar(10)"`
+	Processor    string       `json:"processor,omitempty" gorm:"type:varchar(50)"`
+
+	ProcessorKey       string `json:"-" gorm:"type:varchar(40);index"`
+	ProcessorReference string `json:"processor_reference,omitempty" gorm:"type:varchar(100);index"`
+
+	ParentPaymentID string          `json:"parent_payment_id,omitempty" gorm:"type:varchar(36);index"`
+	Splits          []*PaymentSplit `json:"splits,omitempty" gorm:"foreignKey:PaymentID"`
+	Tips            []*Payment      `json:"tips,omitempty" gorm:"foreignKey:ParentPaymentID"`
+
+	StatusChecks      int        `json:"-" gorm:"not null;default:0"`
+	NextStatusCheckAt *time.Time `json:"-" gorm:"index"`
+	EscalatedAt       *time.Time `json:"escalated_at,omitempty"`
+}
+
+type PaymentCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+type PaymentFilter struct {
+	CustomerID  string
+	RideID      string
+	Status      PaymentStatus
+	Currency    Currency
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	After       *PaymentCursor
+	Limit       int
+}
+
+type PaymentPage struct {
+	Data       []*Payment `json:"data"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+	HasMore    bool       `json:"has_more"`
+}
+
+type PaymentStatusUpdate struct {
+	Status             PaymentStatus
+	FailReason         string
+	Processor          string
+	ProcessorReference string
 }
 
 type IdempotencyRecord struct {
 	Key                string            `json:"key" gorm:"primaryKey;type:varchar(64)"`
 	RequestFingerprint string            `json:"request_fingerprint" gorm:"type:varchar(64);not null"`
-	PaymentID          string            `json:"payment_id,omitempty" gorm:"type:varchar(36)"`
+	PaymentID          string            `json:"payment_id,omitempty" gorm:"type:varchar(36);index"`
 	ResponseBody       []byte            `json:"-" gorm:"type:jsonb"`
 	Status             IdempotencyStatus `json:"status" gorm:"type:varchar(20);not null"`
 	CreatedAt          time.Time         `json:"created_at" gorm:"autoCreateTime"`
 	ExpiresAt          time.Time         `json:"expires_at" gorm:"index;not null"`
 }
 
+type PaymentEvent struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      *Payment  `json:"data"`
+}
+
+type OutboxEvent struct {
+	ID        string       `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	EventType string       `json:"type" gorm:"type:varchar(50);not null"`
+	PaymentID string       `json:"payment_id,omitempty" gorm:"type:varchar(36);index"`
+	Payload   []byte       `json:"-" gorm:"type:jsonb;not null"`
+	Status    OutboxStatus `json:"status" gorm:"type:varchar(20);not null;index"`
+	CreatedAt time.Time    `json:"created_at" gorm:"autoCreateTime"`
+}
+
+type WebhookEndpointRequest struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+type WebhookEndpoint struct {
+	ID          string    `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	URL         string    `json:"url" gorm:"type:text;not null"`
+	Description string    `json:"description,omitempty" gorm:"type:text"`
+	Secret      string    `json:"secret,omitempty" gorm:"type:varchar(100);not null"`
+	Enabled     bool      `json:"enabled" gorm:"not null;default:true"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+type WebhookDelivery struct {
+	ID             string                `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	EventID        string                `json:"event_id" gorm:"type:varchar(36);not null;index"`
+	EndpointID     string                `json:"endpoint_id" gorm:"type:varchar(36);not null;index"`
+	Status         WebhookDeliveryStatus `json:"status" gorm:"type:varchar(20);not null;index"`
+	Attempts       int                   `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at" gorm:"index;not null"`
+	LastStatusCode int                   `json:"last_status_code,omitempty"`
+	LastError      string                `json:"last_error,omitempty" gorm:"type:text"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time             `json:"created_at" gorm:"autoCreateTime"`
+}
+
+type ProcessorEventRequest struct {
+	EventID    string        `json:"event_id"`
+	PaymentID  string        `json:"payment_id"`
+	Status     PaymentStatus `json:"status"`
+	FailReason string        `json:"fail_reason,omitempty"`
+}
+
+type ProcessorEvent struct {
+	EventID    string                `json:"event_id" gorm:"primaryKey;type:varchar(100)"`
+	PaymentID  string                `json:"payment_id" gorm:"type:varchar(36);not null;index"`
+	Status     PaymentStatus         `json:"status" gorm:"type:varchar(20);not null"`
+	FailReason string                `json:"fail_reason,omitempty" gorm:"type:text"`
+	Outcome    ProcessorEventOutcome `json:"outcome" gorm:"type:varchar(20);not null"`
+	ReceivedAt time.Time             `json:"received_at" gorm:"autoCreateTime"`
+}
+
 func (Payment) TableName() string {
 	return "payments"
 }
@@ -72,3 +239,71 @@ func (Payment) TableName() string {
 func (IdempotencyRecord) TableName() string {
 	return "idempotency_records"
 }
+
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+func (WebhookEndpoint) TableName() string {
+	return "webhook_endpoints"
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+func (ProcessorEvent) TableName() string {
+	return "processor_events"
+}
+
+type PaymentMethodRequest struct {
+	CardNumber string `json:"card_number"`
+	ExpMonth   int    `json:"exp_month"`
+	ExpYear    int    `json:"exp_year"`
+}
+
+type PaymentMethod struct {
+	ID              string    `json:"id" gorm:"primaryKey;type:varchar(40)"`
+	CustomerID      string    `json:"customer_id" gorm:"type:varchar(100);not null;uniqueIndex:idx_payment_methods_customer_card"`
+	CardBrand       CardBrand `json:"card_brand" gorm:"type:varchar(20);not null"`
+	CardLast4       string    `json:"card_last_4" gorm:"type:varchar(4);not null"`
+	ExpMonth        int       `json:"exp_month" gorm:"not null"`
+	ExpYear         int       `json:"exp_year" gorm:"not null"`
+	CardFingerprint string    `json:"-" gorm:"type:varchar(64);not null;uniqueIndex:idx_payment_methods_customer_card"`
+	EncryptedCard   []byte    `json:"-" gorm:"not null"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+func (PaymentMethod) TableName() string {
+	return "payment_methods"
+}
+
+type CustomerRequest struct {
+	ID      string `json:"id,omitempty"`
+	Name    string `json:"name"`
+	Email   string `json:"email,omitempty"`
+	Country string `json:"country,omitempty"`
+}
+
+type Customer struct {
+	ID        string    `json:"id" gorm:"primaryKey;type:varchar(100)"`
+	Name      string    `json:"name" gorm:"type:varchar(200);not null"`
+	Email     string    `json:"email,omitempty" gorm:"type:varchar(320)"`
+	Country   string    `json:"country,omitempty" gorm:"type:varchar(2)"`
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+}
+
+func (Customer) TableName() string {
+	return "customers"
+}
+
+type CurrencyTotal struct {
+	Currency Currency `json:"currency"`
+	Amount   int64    `json:"amount_minor"`
+	Count    int64    `json:"count"`
+}
+
+type CustomerPayments struct {
+	PaymentPage
+	Totals []*CurrencyTotal `json:"totals"`
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidAmount    = errors.New("amount must be a decimal number")
+	ErrAmountPrecision  = errors.New("amount has more decimal places than the currency allows")
+	ErrAmountOutOfRange = errors.New("amount is out of range")
+	ErrUnknownCurrency  = errors.New("currency is not registered")
+)
+
+type Money struct {
+	Amount   int64    `json:"amount"`
+	Currency Currency `json:"currency"`
+}
+
+func NewMoney(amount int64, currency Currency) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+func ParseMoney(value string, currency Currency) (Money, error) {
+	def, ok := Currencies().Lookup(currency)
+	if !ok {
+		return Money{}, ErrUnknownCurrency
+	}
+	exponent := def.Exponent
+
+	value = strings.TrimSpace(value)
+	if value == "" || strings.ContainsAny(value, "/") {
+		return Money{}, ErrInvalidAmount
+	}
+
+	rat, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return Money{}, ErrInvalidAmount
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+	rat.Mul(rat, new(big.Rat).SetInt(scale))
+	if !rat.IsInt() {
+		return Money{}, ErrAmountPrecision
+	}
+
+	minor := rat.Num()
+	if !minor.IsInt64() {
+		return Money{}, ErrAmountOutOfRange
+	}
+	return Money{Amount: minor.Int64(), Currency: currency}, nil
+}
+
+func (m Money) Exponent() int {
+	return Currencies().Exponent(m.Currency)
+}
+
+func (m Money) Decimal() string {
+	return formatScaled(m.Amount, m.Exponent())
+}
+
+func formatScaled(value int64, exponent int) string {
+	if exponent == 0 {
+		return strconv.FormatInt(value, 10)
+	}
+
+	sign := ""
+	abs := uint64(value)
+	if value < 0 {
+		sign = "-"
+		abs = uint64(-value)
+	}
+
+	digits := strconv.FormatUint(abs, 10)
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+
+	whole := digits[:len(digits)-exponent]
+	fraction := strings.TrimRight(digits[len(digits)-exponent:], "0")
+	if fraction == "" {
+		return sign + whole
+	}
+	return sign + whole + "." + fraction
+}
+
+func (m Money) Number() json.Number {
+	return json.Number(m.Decimal())
+}
+
+func legacyMinorUnits(value string, currency Currency) int64 {
+	if money, err := ParseMoney(value, currency); err == nil {
+		return money.Amount
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return int64(math.Round(f * math.Pow10(Currencies().Exponent(currency))))
+}
+
+type paymentJSON Payment
+
+func (p Payment) Money() Money {
+	return Money{Amount: p.Amount, Currency: p.Currency}
+}
+
+func (p Payment) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		paymentJSON
+		DecimalAmount json.Number `json:"amount"`
+	}{
+		paymentJSON:   paymentJSON(p),
+		DecimalAmount: p.Money().Number(),
+	})
+}
+
+func (p *Payment) UnmarshalJSON(data []byte) error {
+	var amounts struct {
+		Amount      json.Number `json:"amount"`
+		AmountMinor *int64      `json:"amount_minor"`
+	}
+	if err := json.Unmarshal(data, &amounts); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, (*paymentJSON)(p)); err != nil {
+		return err
+	}
+
+	if amounts.AmountMinor == nil && amounts.Amount != "" {
+		p.Amount = legacyMinorUnits(amounts.Amount.String(), p.Currency)
+	}
+	return nil
+}
+
+type currencyTotalJSON CurrencyTotal
+
+func (t CurrencyTotal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		currencyTotalJSON
+		DecimalAmount json.Number `json:"amount"`
+	}{
+		currencyTotalJSON: currencyTotalJSON(t),
+		DecimalAmount:     NewMoney(t.Amount, t.Currency).Number(),
+	})
+}
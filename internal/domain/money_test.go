@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		value    string
+		currency Currency
+		minor    int64
+		err      error
+	}{
+		{"150000", CurrencyIDR, 150000, nil},
+		{"25000", CurrencyVND, 25000, nil},
+		{"45.5", CurrencyTHB, 4550, nil},
+		{"45.50", CurrencyTHB, 4550, nil},
+		{"0.01", CurrencyPHP, 1, nil},
+		{"1.5e2", CurrencyIDR, 150, nil},
+		{"-10", CurrencyIDR, -10, nil},
+		{"150000.0", CurrencyIDR, 150000, nil},
+		{"100.5", CurrencyIDR, 0, ErrAmountPrecision},
+		{"1.001", CurrencyTHB, 0, ErrAmountPrecision},
+		{"abc", CurrencyIDR, 0, ErrInvalidAmount},
+		{"1/2", CurrencyIDR, 0, ErrInvalidAmount},
+		{"", CurrencyIDR, 0, ErrInvalidAmount},
+		{"99999999999999999999", CurrencyIDR, 0, ErrAmountOutOfRange},
+		{"10", "USD", 0, ErrUnknownCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value+"_"+string(tt.currency), func(t *testing.T) {
+			money, err := ParseMoney(tt.value, tt.currency)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.minor, money.Amount)
+			assert.Equal(t, tt.currency, money.Currency)
+		})
+	}
+}
+
+func TestMoneyDecimal(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{NewMoney(150000, CurrencyIDR), "150000"},
+		{NewMoney(4550, CurrencyTHB), "45.5"},
+		{NewMoney(4500, CurrencyTHB), "45"},
+		{NewMoney(1, CurrencyPHP), "0.01"},
+		{NewMoney(-1999, CurrencyPHP), "-19.99"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.money.Decimal())
+	}
+}
+
+func TestPaymentJSON_RoundTrip(t *testing.T) {
+	payment := Payment{ID: "pay-1", Amount: 12050, Currency: CurrencyTHB}
+
+	body, err := json.Marshal(payment)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"amount":120.5`)
+	assert.Contains(t, string(body), `"amount_minor":12050`)
+
+	var decoded Payment
+	require.NoError(t, json.Unmarshal(body, &decoded))
+	assert.Equal(t, payment.Amount, decoded.Amount)
+}
+
+func TestPaymentJSON_LegacyFloatAmount(t *testing.T) {
+	var decoded Payment
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"pay-1","amount":120.5,"currency":"THB"}`), &decoded))
+	assert.Equal(t, int64(12050), decoded.Amount)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"pay-2","amount":12.345,"currency":"PHP"}`), &decoded))
+	assert.Equal(t, int64(1235), decoded.Amount)
+}
@@ -0,0 +1,197 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type PaymentJobStatus string
+
+const (
+	PaymentJobQueued    PaymentJobStatus = "QUEUED"
+	PaymentJobRunning   PaymentJobStatus = "RUNNING"
+	PaymentJobCompleted PaymentJobStatus = "COMPLETED"
+	PaymentJobFailed    PaymentJobStatus = "FAILED"
+)
+
+var (
+	ErrPaymentJobHeader      = errors.New("payment file must have amount, currency, customer_id, ride_id and payment_method_id columns")
+	ErrPaymentJobCardDetails = errors.New("card details are not accepted in payment files; charge a payment_method_id instead")
+)
+
+var paymentJobColumns = []string{
+	"amount", "currency", "customer_id", "ride_id", "payment_method_id",
+	"idempotency_key", "description",
+}
+
+var paymentJobCardColumns = []string{"card_number", "exp_month", "exp_year", "cvv"}
+
+var paymentJobResultColumns = []string{
+	"line", "idempotency_key", "outcome", "http_status", "payment_id", "payment_status", "error_code", "error_message",
+}
+
+type PaymentJob struct {
+	ID            string           `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	FileName      string           `json:"file_name,omitempty" gorm:"type:varchar(255)"`
+	Status        PaymentJobStatus `json:"status" gorm:"type:varchar(20);not null;index"`
+	TotalRows     int              `json:"total_rows" gorm:"not null"`
+	ProcessedRows int              `json:"processed_rows" gorm:"not null"`
+	Summary       BatchSummary     `json:"summary" gorm:"embedded;embeddedPrefix:summary_"`
+	Error         string           `json:"error,omitempty" gorm:"type:text"`
+	Input         string           `json:"-" gorm:"type:text;not null"`
+	Result        string           `json:"-" gorm:"type:text"`
+	ResultURL     string           `json:"result_url,omitempty" gorm:"-"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+	StartedAt     *time.Time       `json:"started_at,omitempty"`
+	CompletedAt   *time.Time       `json:"completed_at,omitempty"`
+}
+
+func (PaymentJob) TableName() string {
+	return "payment_jobs"
+}
+
+func (j *PaymentJob) Finished() bool {
+	return j.Status == PaymentJobCompleted || j.Status == PaymentJobFailed
+}
+
+type PaymentJobRow struct {
+	Line           int
+	IdempotencyKey string
+	Request        PaymentRequest
+}
+
+func ParsePaymentJobCSV(r io.Reader) ([]PaymentJobRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, ErrPaymentJobHeader
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, card := range paymentJobCardColumns {
+		if _, ok := columns[card]; ok {
+			return nil, ErrPaymentJobCardDetails
+		}
+	}
+	for _, required := range paymentJobColumns[:5] {
+		if _, ok := columns[required]; !ok {
+			return nil, ErrPaymentJobHeader
+		}
+	}
+
+	var rows []PaymentJobRow
+	keys := make(map[string]int)
+	occurrences := make(map[string]int)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+
+		values := make([]string, len(paymentJobColumns))
+		for i, name := range paymentJobColumns {
+			if index, ok := columns[name]; ok && index < len(record) {
+				values[i] = strings.TrimSpace(record[index])
+			}
+		}
+
+		row, err := newPaymentJobRow(line, values)
+		if err != nil {
+			return nil, err
+		}
+
+		if row.IdempotencyKey == "" {
+			content := strings.Join(append(values[:5:5], values[6:]...), "\x1f")
+			occurrences[content]++
+			row.IdempotencyKey = paymentJobRowKey(content, occurrences[content])
+		}
+		if first, ok := keys[row.IdempotencyKey]; ok {
+			return nil, fmt.Errorf("line %d: idempotency_key %s already appears on line %d", line, row.IdempotencyKey, first)
+		}
+		keys[row.IdempotencyKey] = line
+
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func newPaymentJobRow(line int, values []string) (PaymentJobRow, error) {
+	row := PaymentJobRow{
+		Line:           line,
+		IdempotencyKey: values[5],
+		Request: PaymentRequest{
+			Amount:          json.Number(values[0]),
+			Currency:        Currency(strings.ToUpper(values[1])),
+			CustomerID:      values[2],
+			RideID:          values[3],
+			PaymentMethodID: values[4],
+			Description:     values[6],
+		},
+	}
+	if row.Request.PaymentMethodID == "" {
+		return row, fmt.Errorf("line %d: payment_method_id is required", line)
+	}
+	if len(row.IdempotencyKey) > 64 {
+		return row, fmt.Errorf("line %d: idempotency_key must be at most 64 characters", line)
+	}
+	return row, nil
+}
+
+func paymentJobRowKey(content string, occurrence int) string {
+	sum := sha256.Sum256([]byte(content + "\x1f" + strconv.Itoa(occurrence)))
+	return "csv_" + hex.EncodeToString(sum[:])[:60]
+}
+
+func WritePaymentJobResultCSV(w io.Writer, header bool, results []*BatchItemResult) error {
+	writer := csv.NewWriter(w)
+	if header {
+		if err := writer.Write(paymentJobResultColumns); err != nil {
+			return err
+		}
+	}
+	for _, result := range results {
+		record := make([]string, len(paymentJobResultColumns))
+		record[0] = strconv.Itoa(result.Index)
+		record[1] = result.IdempotencyKey
+		record[2] = string(result.Outcome)
+		record[3] = strconv.Itoa(result.HTTPStatus)
+		if result.Payment != nil {
+			record[4] = result.Payment.ID
+			record[5] = string(result.Payment.Status)
+		}
+		if result.Error != nil {
+			record[6] = result.Error.Code
+			record[7] = result.Error.Message
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
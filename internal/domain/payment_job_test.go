@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const paymentJobCSV = "amount,currency,customer_id,ride_id,payment_method_id,description\n" +
+	"100,idr,cust-001,ride-001,pm-001,October invoice\n" +
+	"\n" +
+	"250,IDR,cust-002,ride-002,pm-002,\n" +
+	"100,IDR,cust-001,ride-001,pm-001,October invoice\n"
+
+func TestParsePaymentJobCSV_DerivesDeterministicKeys(t *testing.T) {
+	rows, err := ParsePaymentJobCSV(strings.NewReader(paymentJobCSV))
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, 2, rows[0].Line)
+	assert.Equal(t, 4, rows[1].Line)
+	assert.Equal(t, CurrencyIDR, rows[0].Request.Currency)
+	assert.Equal(t, "pm-001", rows[0].Request.PaymentMethodID)
+	assert.Equal(t, "October invoice", rows[0].Request.Description)
+	assert.Equal(t, "cust-002", rows[1].Request.CustomerID)
+
+	for _, row := range rows {
+		assert.True(t, strings.HasPrefix(row.IdempotencyKey, "csv_"))
+		assert.Len(t, row.IdempotencyKey, 64)
+	}
+	assert.NotEqual(t, rows[0].IdempotencyKey, rows[2].IdempotencyKey)
+
+	again, err := ParsePaymentJobCSV(strings.NewReader(paymentJobCSV))
+	require.NoError(t, err)
+	for i := range rows {
+		assert.Equal(t, rows[i].IdempotencyKey, again[i].IdempotencyKey)
+	}
+
+	reordered := "ride_id,customer_id,currency,amount,description,payment_method_id\n" +
+		"ride-002,cust-002,IDR,250,,pm-002\n"
+	moved, err := ParsePaymentJobCSV(strings.NewReader(reordered))
+	require.NoError(t, err)
+	assert.Equal(t, rows[1].IdempotencyKey, moved[0].IdempotencyKey)
+}
+
+func TestParsePaymentJobCSV_ExplicitKeys(t *testing.T) {
+	rows, err := ParsePaymentJobCSV(strings.NewReader(
+		"idempotency_key,amount,currency,customer_id,ride_id,payment_method_id\ninv-1,100,IDR,cust-001,ride-001,pm-001\n"))
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "inv-1", rows[0].IdempotencyKey)
+
+	_, err = ParsePaymentJobCSV(strings.NewReader(
+		"idempotency_key,amount,currency,customer_id,ride_id,payment_method_id\ninv-1,100,IDR,cust-001,ride-001,pm-001\ninv-1,200,IDR,cust-002,ride-002,pm-002\n"))
+	assert.ErrorContains(t, err, "line 3: idempotency_key inv-1 already appears on line 2")
+}
+
+func TestParsePaymentJobCSV_Rejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{"empty", "", ErrPaymentJobHeader.Error()},
+		{"missing column", "amount,currency,customer_id\n100,IDR,cust-001\n", ErrPaymentJobHeader.Error()},
+		{"missing payment method column", "amount,currency,customer_id,ride_id\n100,IDR,c,r\n", ErrPaymentJobHeader.Error()},
+		{"card number", "amount,currency,customer_id,ride_id,payment_method_id,card_number\n100,IDR,c,r,,4242424242424242\n", ErrPaymentJobCardDetails.Error()},
+		{"cvv", "amount,currency,customer_id,ride_id,payment_method_id,CVV\n100,IDR,c,r,pm-001,123\n", ErrPaymentJobCardDetails.Error()},
+		{"empty payment method", "amount,currency,customer_id,ride_id,payment_method_id\n100,IDR,c,r,\n", "line 2: payment_method_id is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePaymentJobCSV(strings.NewReader(tt.content))
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestWritePaymentJobResultCSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := WritePaymentJobResultCSV(&buf, true, []*BatchItemResult{
+		{Index: 2, IdempotencyKey: "k1", Outcome: BatchItemCreated, HTTPStatus: 201, Payment: &Payment{ID: "pay-1", Status: PaymentStatusSucceeded}},
+		{Index: 3, IdempotencyKey: "k2", Outcome: BatchItemFailed, HTTPStatus: 400, Error: &BatchItemError{Code: "INVALID_CURRENCY", Message: "bad, currency"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"line,idempotency_key,outcome,http_status,payment_id,payment_status,error_code,error_message\n"+
+			"2,k1,CREATED,201,pay-1,SUCCEEDED,,\n"+
+			"3,k2,ERROR,400,,,INVALID_CURRENCY,\"bad, currency\"\n",
+		buf.String())
+}
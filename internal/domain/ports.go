@@ -1,6 +1,10 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"io"
+	"time"
+)
 
 type TransactionManager interface {
 	RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
@@ -10,6 +14,7 @@ type IdempotencyRepository interface {
 	FindByKey(ctx context.Context, key string) (*IdempotencyRecord, error)
 	FindByKeyForUpdate(ctx context.Context, key string) (*IdempotencyRecord, error)
 	Create(ctx context.Context, record *IdempotencyRecord) error
+	ListByPaymentID(ctx context.Context, paymentID string) ([]*IdempotencyRecord, error)
 	Update(ctx context.Context, record *IdempotencyRecord) error
 	DeleteExpired(ctx context.Context) (int64, error)
 }
@@ -17,8 +22,164 @@ type IdempotencyRepository interface {
 type PaymentRepository interface {
 	Create(ctx context.Context, payment *Payment) error
 	FindByID(ctx context.Context, id string) (*Payment, error)
+	FindByIDForUpdate(ctx context.Context, id string) (*Payment, error)
+	FindPendingDue(ctx context.Context, now time.Time, limit int) ([]*Payment, error)
+	FindUnknownDue(ctx context.Context, now time.Time, limit int) ([]*Payment, error)
+	ScheduleStatusCheck(ctx context.Context, id string, status PaymentStatus, checks int, next time.Time) (bool, error)
+	Escalate(ctx context.Context, id string, status PaymentStatus, at time.Time) (bool, error)
+	FindByProcessorReference(ctx context.Context, reference string) (*Payment, error)
+	FindByProcessorKey(ctx context.Context, key string) (*Payment, error)
+	FindRecentCharge(ctx context.Context, customerID, rideID string, amount Money, since time.Time) (*Payment, error)
+	LockRide(ctx context.Context, customerID, rideID string) error
+	List(ctx context.Context, filter PaymentFilter) ([]*Payment, error)
+	TotalsByCurrency(ctx context.Context, customerID string, status PaymentStatus) ([]*CurrencyTotal, error)
+	TipTotal(ctx context.Context, parentPaymentID string) (int64, error)
+	Update(ctx context.Context, payment *Payment) error
 }
 
 type PaymentProcessor interface {
-	Process(ctx context.Context, req PaymentRequest) (*Payment, error)
+	Process(ctx context.Context, idempotencyKey string, req PaymentRequest) (*Payment, error)
+	GetStatus(ctx context.Context, paymentID string) (*PaymentStatusUpdate, error)
+	LookupByKey(ctx context.Context, idempotencyKey string) (*Payment, error)
+}
+
+type OutboxRepository interface {
+	Create(ctx context.Context, event *OutboxEvent) error
+	FindByID(ctx context.Context, id string) (*OutboxEvent, error)
+	FindPending(ctx context.Context, limit int) ([]*OutboxEvent, error)
+	MarkDispatched(ctx context.Context, id string) (bool, error)
+	Update(ctx context.Context, event *OutboxEvent) error
+}
+
+type WebhookEndpointRepository interface {
+	Create(ctx context.Context, endpoint *WebhookEndpoint) error
+	FindByID(ctx context.Context, id string) (*WebhookEndpoint, error)
+	FindEnabled(ctx context.Context) ([]*WebhookEndpoint, error)
+	List(ctx context.Context) ([]*WebhookEndpoint, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, delivery *WebhookDelivery) error
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*WebhookDelivery, error)
+	FindByEventID(ctx context.Context, eventID string) ([]*WebhookDelivery, error)
+	FindByStatus(ctx context.Context, status WebhookDeliveryStatus, limit int) ([]*WebhookDelivery, error)
+	Update(ctx context.Context, delivery *WebhookDelivery) error
+}
+
+type WebhookSender interface {
+	Send(ctx context.Context, endpoint *WebhookEndpoint, eventID string, payload []byte) (int, error)
+}
+
+type ProcessorEventRepository interface {
+	FindByEventID(ctx context.Context, eventID string) (*ProcessorEvent, error)
+	CreateIfAbsent(ctx context.Context, event *ProcessorEvent) (bool, error)
+	Update(ctx context.Context, event *ProcessorEvent) error
+}
+
+type CurrencyRepository interface {
+	List(ctx context.Context) ([]*CurrencyDefinition, error)
+}
+
+type PaymentMethodRepository interface {
+	Create(ctx context.Context, method *PaymentMethod) error
+	FindByID(ctx context.Context, id string) (*PaymentMethod, error)
+	FindByFingerprint(ctx context.Context, customerID, fingerprint string) (*PaymentMethod, error)
+	ListByCustomer(ctx context.Context, customerID string) ([]*PaymentMethod, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type CardVault interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+	Decrypt(ciphertext []byte) ([]byte, error)
+	Fingerprint(cardNumber string) string
+}
+
+type LedgerRepository interface {
+	EnsureAccounts(ctx context.Context, accounts ...*LedgerAccount) error
+	CreateEntry(ctx context.Context, entry *JournalEntry) error
+	ListEntriesByPayment(ctx context.Context, paymentID string) ([]*JournalEntry, error)
+	Balances(ctx context.Context, filter LedgerBalanceFilter) ([]*AccountBalance, error)
+}
+
+type ReconciliationRepository interface {
+	Create(ctx context.Context, reconciliation *Reconciliation) error
+	FindByID(ctx context.Context, id string) (*Reconciliation, error)
+}
+
+type ReceiptRenderer interface {
+	Render(tenant string, format ReceiptFormat, receipt *Receipt) ([]byte, error)
+}
+
+type PaymentRefunder interface {
+	Refund(ctx context.Context, idempotencyKey string, payment *Payment, amount int64) (string, error)
+}
+
+type RefundRepository interface {
+	Create(ctx context.Context, refund *Refund) error
+	FindByKey(ctx context.Context, key string) (*Refund, error)
+	TotalByPayment(ctx context.Context, paymentID string) (int64, error)
+}
+
+type SettlementFileGenerator interface {
+	GenerateSettlementFile(ctx context.Context, date time.Time, w io.Writer) error
+}
+
+type RiskAssessmentRepository interface {
+	Create(ctx context.Context, assessment *RiskAssessment) error
+	FindByPaymentID(ctx context.Context, paymentID string) (*RiskAssessment, error)
+	CountSince(ctx context.Context, filter RiskVelocityFilter) (int64, error)
+}
+
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, limit RateLimit, now time.Time) (RateLimitDecision, error)
+	Release(ctx context.Context, key string, limit RateLimit) error
+	DeleteIdle(ctx context.Context, before time.Time) (int64, error)
+}
+
+type PaymentBatchRepository interface {
+	CreateIfAbsent(ctx context.Context, batch *PaymentBatch) (bool, error)
+	FindByKey(ctx context.Context, key string) (*PaymentBatch, error)
+	ClaimStale(ctx context.Context, key string, staleBefore time.Time) (bool, error)
+	Update(ctx context.Context, batch *PaymentBatch) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type PaymentJobRepository interface {
+	Create(ctx context.Context, job *PaymentJob) error
+	FindByID(ctx context.Context, id string) (*PaymentJob, error)
+	ClaimNext(ctx context.Context, staleBefore time.Time) (*PaymentJob, error)
+	Update(ctx context.Context, job *PaymentJob) error
+}
+
+type ScheduledPaymentRepository interface {
+	Create(ctx context.Context, scheduled *ScheduledPayment) error
+	FindByID(ctx context.Context, id string) (*ScheduledPayment, error)
+	FindByKey(ctx context.Context, key string) (*ScheduledPayment, error)
+	List(ctx context.Context, filter ScheduledPaymentFilter) ([]*ScheduledPayment, error)
+	ClaimDue(ctx context.Context, now, staleBefore time.Time, limit int) ([]*ScheduledPayment, error)
+	Cancel(ctx context.Context, id string, now time.Time) (bool, error)
+	Update(ctx context.Context, scheduled *ScheduledPayment) error
+}
+
+type SubscriptionPlanRepository interface {
+	Create(ctx context.Context, plan *SubscriptionPlan) error
+	FindByID(ctx context.Context, id string) (*SubscriptionPlan, error)
+	List(ctx context.Context, limit int) ([]*SubscriptionPlan, error)
+}
+
+type SubscriptionRepository interface {
+	Create(ctx context.Context, sub *Subscription) error
+	FindByID(ctx context.Context, id string) (*Subscription, error)
+	FindByIDForUpdate(ctx context.Context, id string) (*Subscription, error)
+	FindLive(ctx context.Context, customerID, planID string) (*Subscription, error)
+	List(ctx context.Context, filter SubscriptionFilter) ([]*Subscription, error)
+	ClaimDue(ctx context.Context, now, lockedUntil time.Time, limit int) ([]*Subscription, error)
+	Update(ctx context.Context, sub *Subscription) error
+}
+
+type CustomerRepository interface {
+	Create(ctx context.Context, customer *Customer) error
+	FindByID(ctx context.Context, id string) (*Customer, error)
+	List(ctx context.Context, limit int) ([]*Customer, error)
 }
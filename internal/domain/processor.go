@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrProcessorUnavailable = errors.New("payment processor unavailable")
+	ErrProcessorTimeout     = errors.New("payment processor timed out")
+)
+
+var processorNamePattern = regexp.MustCompile(`^[a-z0-9_-]{1,50}$`)
+
+func ProcessorIdempotencyKey(key string) string {
+	sum := sha256.Sum256([]byte("processor:" + key))
+	return "idem_" + hex.EncodeToString(sum[:16])
+}
+
+type ProcessorRouteKind string
+
+const (
+	ProcessorRouteCurrency ProcessorRouteKind = "currency"
+	ProcessorRouteBIN      ProcessorRouteKind = "bin"
+)
+
+type ProcessorRoute struct {
+	Kind       ProcessorRouteKind
+	Value      string
+	Processors []string
+}
+
+func (r ProcessorRoute) Matches(req PaymentRequest) bool {
+	switch r.Kind {
+	case ProcessorRouteCurrency:
+		return Currency(r.Value) == req.Currency
+	case ProcessorRouteBIN:
+		return strings.HasPrefix(req.CardNumber, r.Value)
+	default:
+		return false
+	}
+}
+
+type ProcessorWeight struct {
+	Processor string
+	Weight    int
+}
+
+func ParseProcessorNames(spec string) ([]string, error) {
+	var names []string
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(spec, ",") {
+		name := strings.TrimSpace(entry)
+		if name == "" {
+			continue
+		}
+		if !processorNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("processor %q: names use lowercase letters, digits, '-' and '_'", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("processor %q: listed twice", name)
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func ParseProcessorRoutes(spec string) ([]ProcessorRoute, error) {
+	var routes []ProcessorRoute
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		selector, targets, ok := strings.Cut(entry, "=")
+		kind, value, hasValue := strings.Cut(selector, ":")
+		if !ok || !hasValue || targets == "" {
+			return nil, fmt.Errorf("processor route %q: expected KIND:VALUE=PROCESSOR[>FALLBACK...]", entry)
+		}
+
+		route := ProcessorRoute{Kind: ProcessorRouteKind(strings.ToLower(kind)), Value: strings.TrimSpace(value)}
+		switch route.Kind {
+		case ProcessorRouteCurrency:
+			route.Value = strings.ToUpper(route.Value)
+			if len(route.Value) != 3 {
+				return nil, fmt.Errorf("processor route %q: currency must be a 3-letter code", entry)
+			}
+		case ProcessorRouteBIN:
+			if !isDigits(route.Value) {
+				return nil, fmt.Errorf("processor route %q: bin must contain only digits", entry)
+			}
+		default:
+			return nil, fmt.Errorf("processor route %q: kind must be currency or bin", entry)
+		}
+
+		for _, target := range strings.Split(targets, ">") {
+			route.Processors = append(route.Processors, strings.TrimSpace(target))
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+func ParseProcessorWeights(spec string) ([]ProcessorWeight, error) {
+	var weights []ProcessorWeight
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(entry, ":")
+		weight, err := strconv.Atoi(value)
+		if !ok || err != nil || weight < 0 {
+			return nil, fmt.Errorf("processor weight %q: expected PROCESSOR:WEIGHT", entry)
+		}
+		weights = append(weights, ProcessorWeight{Processor: strings.TrimSpace(name), Weight: weight})
+	}
+	return weights, nil
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProcessorNames(t *testing.T) {
+	names, err := ParseProcessorNames(" sim_a, sim-b ,")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sim_a", "sim-b"}, names)
+
+	for _, spec := range []string{"Sim_A", "sim a", "sim_a,sim_a"} {
+		_, err := ParseProcessorNames(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestParseProcessorRoutes(t *testing.T) {
+	routes, err := ParseProcessorRoutes("currency:thb=sim_b>sim_a, bin:400000=sim_c")
+	require.NoError(t, err)
+	assert.Equal(t, []ProcessorRoute{
+		{Kind: ProcessorRouteCurrency, Value: "THB", Processors: []string{"sim_b", "sim_a"}},
+		{Kind: ProcessorRouteBIN, Value: "400000", Processors: []string{"sim_c"}},
+	}, routes)
+
+	assert.True(t, routes[0].Matches(PaymentRequest{Currency: CurrencyTHB}))
+	assert.False(t, routes[0].Matches(PaymentRequest{Currency: CurrencyIDR}))
+	assert.True(t, routes[1].Matches(PaymentRequest{CardNumber: "4000000000000002"}))
+	assert.False(t, routes[1].Matches(PaymentRequest{CardNumber: "4111111111111111"}))
+
+	for _, spec := range []string{"currency:THB", "country:TH=sim_a", "bin:40x=sim_a", "currency:TH=sim_a", "currency=sim_a"} {
+		_, err := ParseProcessorRoutes(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestParseProcessorWeights(t *testing.T) {
+	weights, err := ParseProcessorWeights("sim_a:80, sim_b:20")
+	require.NoError(t, err)
+	assert.Equal(t, []ProcessorWeight{{Processor: "sim_a", Weight: 80}, {Processor: "sim_b", Weight: 20}}, weights)
+
+	for _, spec := range []string{"sim_a", "sim_a:x", "sim_a:-1"} {
+		_, err := ParseProcessorWeights(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestProcessorIdempotencyKey(t *testing.T) {
+	key := ProcessorIdempotencyKey("ride-789-attempt-1")
+	assert.Equal(t, key, ProcessorIdempotencyKey("ride-789-attempt-1"))
+	assert.NotEqual(t, key, ProcessorIdempotencyKey("ride-789-attempt-2"))
+	assert.Len(t, key, 37)
+	assert.NotContains(t, key, "ride-789")
+}
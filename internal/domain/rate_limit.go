@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+type RateLimitScope string
+
+const (
+	RateLimitScopeAPIKey   RateLimitScope = "api_key"
+	RateLimitScopeCustomer RateLimitScope = "customer"
+	RateLimitScopeIP       RateLimitScope = "ip"
+)
+
+type RateLimit struct {
+	Limit  int
+	Period time.Duration
+}
+
+func (l RateLimit) Enabled() bool {
+	return l.Limit > 0 && l.Period > 0
+}
+
+func (l RateLimit) perSecond() float64 {
+	return float64(l.Limit) / l.Period.Seconds()
+}
+
+type RateLimitDecision struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration
+	RetryAfter time.Duration
+}
+
+type TokenBucket struct {
+	Key        string    `gorm:"primaryKey;type:varchar(200)"`
+	Tokens     float64   `gorm:"not null"`
+	RefilledAt time.Time `gorm:"not null;index"`
+}
+
+func (TokenBucket) TableName() string {
+	return "rate_limit_buckets"
+}
+
+func (b *TokenBucket) Take(limit RateLimit, now time.Time) RateLimitDecision {
+	capacity := float64(limit.Limit)
+	rate := limit.perSecond()
+
+	if b.RefilledAt.IsZero() {
+		b.Tokens = capacity
+	} else if elapsed := now.Sub(b.RefilledAt).Seconds(); elapsed > 0 {
+		b.Tokens = math.Min(capacity, b.Tokens+elapsed*rate)
+	}
+	b.RefilledAt = now
+
+	decision := RateLimitDecision{Limit: limit.Limit}
+	if b.Tokens >= 1 {
+		b.Tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = secondsToDuration((1 - b.Tokens) / rate)
+	}
+	decision.Remaining = int(math.Floor(b.Tokens))
+	decision.ResetAfter = secondsToDuration((capacity - b.Tokens) / rate)
+	return decision
+}
+
+func (b *TokenBucket) Release(limit RateLimit) {
+	b.Tokens = math.Min(float64(limit.Limit), b.Tokens+1)
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucketTake(t *testing.T) {
+	limit := RateLimit{Limit: 2, Period: time.Minute}
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	bucket := &TokenBucket{Key: "ip:127.0.0.1"}
+
+	first := bucket.Take(limit, now)
+	assert.True(t, first.Allowed)
+	assert.Equal(t, 1, first.Remaining)
+	assert.Equal(t, 30*time.Second, first.ResetAfter)
+
+	second := bucket.Take(limit, now)
+	assert.True(t, second.Allowed)
+	assert.Equal(t, 0, second.Remaining)
+
+	third := bucket.Take(limit, now.Add(15*time.Second))
+	assert.False(t, third.Allowed)
+	assert.Equal(t, 0, third.Remaining)
+	assert.Equal(t, 15*time.Second, third.RetryAfter)
+
+	refilled := bucket.Take(limit, now.Add(30*time.Second))
+	assert.True(t, refilled.Allowed)
+
+	full := bucket.Take(limit, now.Add(time.Hour))
+	assert.True(t, full.Allowed)
+	assert.Equal(t, 1, full.Remaining)
+}
+
+func TestTokenBucketRelease(t *testing.T) {
+	limit := RateLimit{Limit: 2, Period: time.Minute}
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	bucket := &TokenBucket{Key: "customer:cust-001"}
+
+	bucket.Take(limit, now)
+	bucket.Take(limit, now)
+	bucket.Release(limit)
+	assert.True(t, bucket.Take(limit, now).Allowed)
+
+	bucket.Release(limit)
+	bucket.Release(limit)
+	bucket.Release(limit)
+	assert.Equal(t, float64(2), bucket.Tokens)
+}
+
+func TestRateLimitEnabled(t *testing.T) {
+	assert.True(t, RateLimit{Limit: 10, Period: time.Second}.Enabled())
+	assert.False(t, RateLimit{Limit: 0, Period: time.Second}.Enabled())
+	assert.False(t, RateLimit{Limit: 10}.Enabled())
+}
@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+type ReceiptFormat string
+
+const (
+	ReceiptFormatHTML ReceiptFormat = "html"
+	ReceiptFormatText ReceiptFormat = "text"
+)
+
+type amountFormat struct {
+	prefix    string
+	suffix    string
+	thousands string
+	decimal   string
+}
+
+var currencyFormats = map[Currency]amountFormat{
+	CurrencyIDR: {prefix: "Rp ", thousands: ".", decimal: ","},
+	CurrencyTHB: {prefix: "฿", thousands: ",", decimal: "."},
+	CurrencyVND: {suffix: " ₫", thousands: ".", decimal: ","},
+	CurrencyPHP: {prefix: "₱", thousands: ",", decimal: "."},
+}
+
+var receiptLabels = map[string]map[string]string{
+	"en": {
+		"title":       "Payment receipt",
+		"payment_id":  "Payment ID",
+		"ride_id":     "Ride",
+		"date":        "Date",
+		"card":        "Card",
+		"status":      "Status",
+		"description": "Description",
+		"amount":      "Amount charged",
+		"refunds":     "Refunds",
+		"refunded":    "Total refunded",
+		"net":         "Net paid",
+		"REFUND":      "Refund",
+		"REVERSAL":    "Reversal",
+		"SUCCEEDED":   "Paid",
+		"PENDING":     "Processing",
+		"FAILED":      "Failed",
+		"BLOCKED":     "Blocked",
+	},
+	"es": {
+		"title":       "Recibo de pago",
+		"payment_id":  "ID de pago",
+		"ride_id":     "Viaje",
+		"date":        "Fecha",
+		"card":        "Tarjeta",
+		"status":      "Estado",
+		"description": "Descripcion",
+		"amount":      "Monto cobrado",
+		"refunds":     "Reembolsos",
+		"refunded":    "Total reembolsado",
+		"net":         "Total pagado",
+		"REFUND":      "Reembolso",
+		"REVERSAL":    "Reverso",
+		"SUCCEEDED":   "Pagado",
+		"PENDING":     "En proceso",
+		"FAILED":      "Fallido",
+		"BLOCKED":     "Bloqueado",
+	},
+}
+
+type ReceiptRefund struct {
+	Type   JournalEntryType
+	Label  string
+	Amount string
+	Date   time.Time
+}
+
+type Receipt struct {
+	Language    string
+	Labels      map[string]string
+	PaymentID   string
+	RideID      string
+	CustomerID  string
+	Description string
+	Status      PaymentStatus
+	StatusLabel string
+	Card        string
+	Currency    Currency
+	Amount      string
+	Refunds     []ReceiptRefund
+	Refunded    string
+	Net         string
+	PaidAt      time.Time
+	IssuedAt    time.Time
+}
+
+func NewReceipt(payment *Payment, entries []*JournalEntry, lang string, now time.Time) *Receipt {
+	labels, ok := receiptLabels[lang]
+	if !ok {
+		lang = "en"
+		labels = receiptLabels[lang]
+	}
+
+	receipt := &Receipt{
+		Language:    lang,
+		Labels:      labels,
+		PaymentID:   payment.ID,
+		RideID:      payment.RideID,
+		CustomerID:  payment.CustomerID,
+		Description: payment.Description,
+		Status:      payment.Status,
+		StatusLabel: labels[string(payment.Status)],
+		Card:        MaskCard(payment.CardBrand, payment.CardLast4),
+		Currency:    payment.Currency,
+		Amount:      FormatAmount(NewMoney(payment.Amount, payment.Currency)),
+		PaidAt:      payment.CreatedAt.UTC(),
+		IssuedAt:    now.UTC(),
+	}
+
+	var refunded int64
+	for _, entry := range entries {
+		amount := entry.RefundedAmount()
+		if amount == 0 {
+			continue
+		}
+		refunded += amount
+		receipt.Refunds = append(receipt.Refunds, ReceiptRefund{
+			Type:   entry.Type,
+			Label:  labels[string(entry.Type)],
+			Amount: FormatAmount(NewMoney(amount, payment.Currency)),
+			Date:   entry.CreatedAt.UTC(),
+		})
+	}
+	receipt.Refunded = FormatAmount(NewMoney(refunded, payment.Currency))
+	receipt.Net = FormatAmount(NewMoney(payment.Amount-refunded, payment.Currency))
+	return receipt
+}
+
+func (r *Receipt) Available() bool {
+	return r.Status == PaymentStatusSucceeded || r.Status == PaymentStatusPending || len(r.Refunds) > 0
+}
+
+func MaskCard(brand CardBrand, last4 string) string {
+	if last4 == "" {
+		return ""
+	}
+	masked := "•••• " + last4
+	if brand != "" && brand != CardBrandUnknown {
+		masked = string(brand) + " " + masked
+	}
+	return masked
+}
+
+func FormatAmount(m Money) string {
+	format, ok := currencyFormats[m.Currency]
+	if !ok {
+		format = amountFormat{prefix: string(m.Currency) + " ", thousands: ",", decimal: "."}
+	}
+
+	value := m.Decimal()
+	sign := ""
+	if strings.HasPrefix(value, "-") {
+		sign, value = "-", value[1:]
+	}
+	integer, fraction, _ := strings.Cut(value, ".")
+	if exponent := m.Exponent(); len(fraction) < exponent {
+		fraction += strings.Repeat("0", exponent-len(fraction))
+	}
+
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteString(format.thousands)
+		}
+		grouped.WriteRune(digit)
+	}
+	if fraction != "" {
+		grouped.WriteString(format.decimal + fraction)
+	}
+	return sign + format.prefix + grouped.String() + format.suffix
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatAmount_PerCurrency(t *testing.T) {
+	assert.Equal(t, "Rp 1.250.000", FormatAmount(NewMoney(1250000, CurrencyIDR)))
+	assert.Equal(t, "฿1,234.50", FormatAmount(NewMoney(123450, CurrencyTHB)))
+	assert.Equal(t, "75.000 ₫", FormatAmount(NewMoney(75000, CurrencyVND)))
+	assert.Equal(t, "₱0.99", FormatAmount(NewMoney(99, CurrencyPHP)))
+	assert.Equal(t, "₱10.00", FormatAmount(NewMoney(1000, CurrencyPHP)))
+	assert.Equal(t, "-Rp 500", FormatAmount(NewMoney(-500, CurrencyIDR)))
+}
+
+func TestMaskCard(t *testing.T) {
+	assert.Equal(t, "VISA •••• 4242", MaskCard(CardBrandVisa, "4242"))
+	assert.Equal(t, "•••• 4242", MaskCard(CardBrandUnknown, "4242"))
+	assert.Empty(t, MaskCard(CardBrandVisa, ""))
+}
+
+func TestNewReceipt_SubtractsRefunds(t *testing.T) {
+	paidAt := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+	payment := &Payment{
+		ID: "pay-1", Amount: 150000, Currency: CurrencyIDR, RideID: "ride-1",
+		Status: PaymentStatusSucceeded, CardBrand: CardBrandVisa, CardLast4: "4242", CreatedAt: paidAt,
+	}
+
+	receivable := NewLedgerAccount(AccountProcessorReceivable, LedgerAccountAsset, CurrencyIDR)
+	merchant := NewLedgerAccount(AccountMerchantPayable, LedgerAccountLiability, CurrencyIDR)
+	charge := &JournalEntry{ID: "e1", Type: JournalEntryCharge, CreatedAt: paidAt}
+	charge.Debit(receivable, 150000)
+	charge.Credit(merchant, 150000)
+	refund := &JournalEntry{ID: "e2", Type: JournalEntryRefund, CreatedAt: paidAt.Add(time.Hour)}
+	refund.Debit(merchant, 50000)
+	refund.Credit(receivable, 50000)
+
+	receipt := NewReceipt(payment, []*JournalEntry{charge, refund}, "es", paidAt)
+
+	assert.Equal(t, "es", receipt.Language)
+	assert.Equal(t, "Recibo de pago", receipt.Labels["title"])
+	assert.Equal(t, "Pagado", receipt.StatusLabel)
+	assert.Equal(t, "VISA •••• 4242", receipt.Card)
+	assert.Equal(t, "Rp 150.000", receipt.Amount)
+	require.Len(t, receipt.Refunds, 1)
+	assert.Equal(t, "Reembolso", receipt.Refunds[0].Label)
+	assert.Equal(t, "Rp 50.000", receipt.Refunds[0].Amount)
+	assert.Equal(t, "Rp 100.000", receipt.Net)
+	assert.True(t, receipt.Available())
+}
+
+func TestNewReceipt_FallsBackToEnglish(t *testing.T) {
+	payment := &Payment{ID: "pay-1", Amount: 100, Currency: CurrencyIDR, Status: PaymentStatusFailed}
+
+	receipt := NewReceipt(payment, nil, "th", time.Now())
+
+	assert.Equal(t, "en", receipt.Language)
+	assert.Equal(t, "Payment receipt", receipt.Labels["title"])
+	assert.False(t, receipt.Available())
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var ErrRefundUnsupported = errors.New("processor: refunds are not supported")
+
+type RefundRequest struct {
+	Amount json.Number `json:"amount,omitempty"`
+	Reason string      `json:"reason,omitempty"`
+}
+
+type Refund struct {
+	ID                 string    `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	IdempotencyKey     string    `json:"-" gorm:"type:varchar(64);uniqueIndex;not null"`
+	RequestFingerprint string    `json:"-" gorm:"type:varchar(64);not null"`
+	PaymentID          string    `json:"payment_id" gorm:"type:varchar(36);not null;index"`
+	Amount             int64     `json:"amount_minor" gorm:"type:bigint;not null"`
+	Currency           Currency  `json:"currency" gorm:"type:varchar(3);not null"`
+	Reason             string    `json:"reason,omitempty" gorm:"type:text"`
+	ProcessorReference string    `json:"processor_reference,omitempty" gorm:"type:varchar(100)"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+func (Refund) TableName() string {
+	return "payment_refunds"
+}
+
+type refundJSON Refund
+
+func (r Refund) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		refundJSON
+		DecimalAmount json.Number `json:"amount"`
+	}{
+		refundJSON:    refundJSON(r),
+		DecimalAmount: NewMoney(r.Amount, r.Currency).Number(),
+	})
+}
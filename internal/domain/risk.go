@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type RiskDecision string
+
+const (
+	RiskDecisionAllow  RiskDecision = "ALLOW"
+	RiskDecisionReview RiskDecision = "REVIEW"
+	RiskDecisionDeny   RiskDecision = "DENY"
+)
+
+func (d RiskDecision) severity() int {
+	switch d {
+	case RiskDecisionDeny:
+		return 2
+	case RiskDecisionReview:
+		return 1
+	default:
+		return 0
+	}
+}
+
+const (
+	RiskRuleCustomerVelocity = "customer_velocity"
+	RiskRuleCardVelocity     = "card_velocity"
+	RiskRuleMaxAmount        = "max_amount"
+	RiskRuleBlockedBIN       = "blocked_bin"
+	RiskRuleCountryMismatch  = "country_mismatch"
+)
+
+type RiskInput struct {
+	CustomerID      string
+	CardNumber      string
+	CardFingerprint string
+	Money           Money
+	Now             time.Time
+}
+
+type RiskRule interface {
+	Evaluate(ctx context.Context, input RiskInput) (*RiskTrigger, error)
+}
+
+type RiskTrigger struct {
+	Rule     string       `json:"rule"`
+	Decision RiskDecision `json:"decision"`
+	Reason   string       `json:"reason"`
+}
+
+type RiskAssessment struct {
+	ID              string        `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	PaymentID       string        `json:"payment_id" gorm:"type:varchar(36);index"`
+	IdempotencyKey  string        `json:"idempotency_key" gorm:"type:varchar(64)"`
+	CustomerID      string        `json:"customer_id" gorm:"type:varchar(100);not null;index:idx_risk_assessments_customer,priority:1"`
+	CardFingerprint string        `json:"-" gorm:"type:varchar(64);index:idx_risk_assessments_card,priority:1"`
+	Amount          int64         `json:"amount_minor" gorm:"type:bigint;not null"`
+	Currency        Currency      `json:"currency" gorm:"type:varchar(3);not null"`
+	Decision        RiskDecision  `json:"decision" gorm:"type:varchar(10);not null"`
+	Triggers        []RiskTrigger `json:"triggers" gorm:"type:text;serializer:json"`
+	CreatedAt       time.Time     `json:"created_at" gorm:"index:idx_risk_assessments_customer,priority:2;index:idx_risk_assessments_card,priority:2"`
+}
+
+func (RiskAssessment) TableName() string {
+	return "risk_assessments"
+}
+
+func (a *RiskAssessment) Add(trigger RiskTrigger) {
+	a.Triggers = append(a.Triggers, trigger)
+	if trigger.Decision.severity() > a.Decision.severity() {
+		a.Decision = trigger.Decision
+	}
+}
+
+func (a *RiskAssessment) DenyReason() string {
+	for _, trigger := range a.Triggers {
+		if trigger.Decision == RiskDecisionDeny {
+			return trigger.Rule
+		}
+	}
+	return ""
+}
+
+type RiskVelocityFilter struct {
+	CustomerID      string
+	CardFingerprint string
+	Since           time.Time
+}
+
+func ParseRiskAmountLimits(spec string) (map[Currency]int64, error) {
+	limits := make(map[Currency]int64)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		code, amount, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("risk amount limit %q: expected CODE:AMOUNT", entry)
+		}
+		money, err := ParseMoney(amount, Currency(strings.ToUpper(code)))
+		if err != nil || money.Amount <= 0 {
+			return nil, fmt.Errorf("risk amount limit %q: amount must be a positive decimal", entry)
+		}
+		limits[money.Currency] = money.Amount
+	}
+	return limits, nil
+}
+
+func ParseBINCountries(spec string) (map[string]string, error) {
+	countries := make(map[string]string)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		prefix, country, ok := strings.Cut(entry, ":")
+		if !ok || !isDigits(prefix) || len(country) != 2 {
+			return nil, fmt.Errorf("bin country %q: expected BIN_PREFIX:COUNTRY", entry)
+		}
+		countries[prefix] = strings.ToUpper(country)
+	}
+	return countries, nil
+}
+
+func ParseBINList(spec string) ([]string, error) {
+	var bins []string
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !isDigits(entry) {
+			return nil, fmt.Errorf("bin %q: must contain only digits", entry)
+		}
+		bins = append(bins, entry)
+	}
+	return bins, nil
+}
+
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
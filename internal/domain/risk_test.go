@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRiskAssessmentAddKeepsMostSevereDecision(t *testing.T) {
+	assessment := &RiskAssessment{Decision: RiskDecisionAllow}
+
+	assessment.Add(RiskTrigger{Rule: RiskRuleCountryMismatch, Decision: RiskDecisionReview})
+	assert.Equal(t, RiskDecisionReview, assessment.Decision)
+	assert.Empty(t, assessment.DenyReason())
+
+	assessment.Add(RiskTrigger{Rule: RiskRuleBlockedBIN, Decision: RiskDecisionDeny})
+	assessment.Add(RiskTrigger{Rule: RiskRuleMaxAmount, Decision: RiskDecisionReview})
+	assert.Equal(t, RiskDecisionDeny, assessment.Decision)
+	assert.Equal(t, RiskRuleBlockedBIN, assessment.DenyReason())
+	assert.Len(t, assessment.Triggers, 3)
+}
+
+func TestParseRiskAmountLimits(t *testing.T) {
+	limits, err := ParseRiskAmountLimits(" idr:5000000, THB:200.50 ,")
+	require.NoError(t, err)
+	assert.Equal(t, map[Currency]int64{CurrencyIDR: 5000000, CurrencyTHB: 20050}, limits)
+
+	for _, spec := range []string{"IDR", "IDR:abc", "THB:0", "THB:1.234"} {
+		_, err := ParseRiskAmountLimits(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestParseBINCountries(t *testing.T) {
+	countries, err := ParseBINCountries("4111:us, 550000:TH")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"4111": "US", "550000": "TH"}, countries)
+
+	for _, spec := range []string{"4111", "41x1:US", "4111:USA"} {
+		_, err := ParseBINCountries(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestParseBINList(t *testing.T) {
+	bins, err := ParseBINList("400000, 411112,")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"400000", "411112"}, bins)
+
+	_, err = ParseBINList("4000-00")
+	assert.Error(t, err)
+}
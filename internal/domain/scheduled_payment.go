@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+type ScheduledPaymentStatus string
+
+const (
+	ScheduledPaymentScheduled ScheduledPaymentStatus = "SCHEDULED"
+	ScheduledPaymentExecuting ScheduledPaymentStatus = "EXECUTING"
+	ScheduledPaymentExecuted  ScheduledPaymentStatus = "EXECUTED"
+	ScheduledPaymentCanceled  ScheduledPaymentStatus = "CANCELED"
+	ScheduledPaymentFailed    ScheduledPaymentStatus = "FAILED"
+)
+
+type ScheduledPayment struct {
+	ID                 string                 `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	IdempotencyKey     string                 `json:"idempotency_key" gorm:"type:varchar(64);uniqueIndex;not null"`
+	RequestFingerprint string                 `json:"-" gorm:"type:varchar(64);not null"`
+	CustomerID         string                 `json:"customer_id" gorm:"type:varchar(100);not null;index"`
+	Request            PaymentRequest         `json:"request" gorm:"type:text;serializer:json"`
+	Status             ScheduledPaymentStatus `json:"status" gorm:"type:varchar(20);not null;index"`
+	ScheduledAt        time.Time              `json:"scheduled_at" gorm:"not null;index"`
+	Attempts           int                    `json:"attempts" gorm:"not null;default:0"`
+	PaymentID          *string                `json:"payment_id,omitempty" gorm:"type:varchar(36)"`
+	ErrorCode          string                 `json:"error_code,omitempty" gorm:"type:varchar(50)"`
+	ErrorMessage       string                 `json:"error_message,omitempty" gorm:"type:text"`
+	CreatedAt          time.Time              `json:"created_at"`
+	UpdatedAt          time.Time              `json:"updated_at"`
+	ExecutedAt         *time.Time             `json:"executed_at,omitempty"`
+	CanceledAt         *time.Time             `json:"canceled_at,omitempty"`
+}
+
+func (ScheduledPayment) TableName() string {
+	return "scheduled_payments"
+}
+
+type ScheduledPaymentFilter struct {
+	CustomerID string
+	Status     ScheduledPaymentStatus
+	Limit      int
+}
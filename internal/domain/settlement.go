@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const SettlementDateLayout = "2006-01-02"
+
+var (
+	ErrSettlementHeader      = errors.New("settlement file must have processor_reference, amount and currency columns")
+	ErrSettlementUnsupported = errors.New("processor: settlement files are not supported")
+)
+
+type ReconciliationOutcome string
+
+const (
+	ReconciliationMatched         ReconciliationOutcome = "MATCHED"
+	ReconciliationMissingInOurs   ReconciliationOutcome = "MISSING_IN_OURS"
+	ReconciliationMissingInTheirs ReconciliationOutcome = "MISSING_IN_THEIRS"
+	ReconciliationAmountMismatch  ReconciliationOutcome = "AMOUNT_MISMATCH"
+	ReconciliationStatusMismatch  ReconciliationOutcome = "STATUS_MISMATCH"
+)
+
+func (p Payment) SettlementReference() string {
+	if p.ProcessorReference != "" {
+		return p.ProcessorReference
+	}
+	return p.ID
+}
+
+type SettlementRow struct {
+	ProcessorReference string
+	Amount             Money
+	SettledAt          *time.Time
+}
+
+var settlementColumns = []string{"processor_reference", "amount", "currency", "settled_at"}
+
+func ParseSettlementCSV(r io.Reader) ([]SettlementRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, ErrSettlementHeader
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, required := range settlementColumns[:3] {
+		if _, ok := columns[required]; !ok {
+			return nil, ErrSettlementHeader
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []SettlementRow
+	seen := make(map[string]int)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		reference := field(record, "processor_reference")
+		if reference == "" {
+			return nil, fmt.Errorf("line %d: processor_reference is required", line)
+		}
+		if first, ok := seen[reference]; ok {
+			return nil, fmt.Errorf("line %d: processor_reference %s already appears on line %d", line, reference, first)
+		}
+		seen[reference] = line
+
+		currency := Currency(strings.ToUpper(field(record, "currency")))
+		amount, err := ParseMoney(field(record, "amount"), currency)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		row := SettlementRow{ProcessorReference: reference, Amount: amount}
+		if value := field(record, "settled_at"); value != "" {
+			settledAt, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: settled_at must be an RFC 3339 timestamp", line)
+			}
+			row.SettledAt = &settledAt
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func WriteSettlementCSV(w io.Writer, rows []SettlementRow) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(settlementColumns); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		settledAt := ""
+		if row.SettledAt != nil {
+			settledAt = row.SettledAt.UTC().Format(time.RFC3339)
+		}
+		record := []string{row.ProcessorReference, row.Amount.Decimal(), string(row.Amount.Currency), settledAt}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+type Reconciliation struct {
+	ID               string                `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	SettlementDate   string                `json:"settlement_date" gorm:"type:varchar(10);not null;index"`
+	FileName         string                `json:"file_name,omitempty" gorm:"type:varchar(255)"`
+	FileSHA256       string                `json:"file_sha256" gorm:"type:varchar(64);not null"`
+	Rows             int                   `json:"rows" gorm:"not null"`
+	Matched          int                   `json:"matched" gorm:"not null"`
+	MissingInOurs    int                   `json:"missing_in_ours" gorm:"not null"`
+	MissingInTheirs  int                   `json:"missing_in_theirs" gorm:"not null"`
+	AmountMismatches int                   `json:"amount_mismatches" gorm:"not null"`
+	StatusMismatches int                   `json:"status_mismatches" gorm:"not null"`
+	CreatedAt        time.Time             `json:"created_at"`
+	Items            []*ReconciliationItem `json:"items" gorm:"foreignKey:ReconciliationID"`
+}
+
+func (Reconciliation) TableName() string {
+	return "reconciliations"
+}
+
+type ReconciliationItem struct {
+	ReconciliationID   string                `json:"-" gorm:"primaryKey;type:varchar(36)"`
+	Position           int                   `json:"-" gorm:"primaryKey"`
+	Outcome            ReconciliationOutcome `json:"outcome" gorm:"type:varchar(20);not null"`
+	ProcessorReference string                `json:"processor_reference,omitempty" gorm:"type:varchar(100)"`
+	PaymentID          string                `json:"payment_id,omitempty" gorm:"type:varchar(36);index"`
+	TheirAmount        int64                 `json:"their_amount_minor,omitempty" gorm:"type:bigint"`
+	TheirCurrency      Currency              `json:"their_currency,omitempty" gorm:"type:varchar(3)"`
+	OurAmount          int64                 `json:"our_amount_minor,omitempty" gorm:"type:bigint"`
+	OurCurrency        Currency              `json:"our_currency,omitempty" gorm:"type:varchar(3)"`
+	OurStatus          PaymentStatus         `json:"our_status,omitempty" gorm:"type:varchar(20)"`
+}
+
+func (ReconciliationItem) TableName() string {
+	return "reconciliation_items"
+}
+
+func (r *Reconciliation) Add(item *ReconciliationItem) {
+	item.ReconciliationID = r.ID
+	item.Position = len(r.Items)
+	r.Items = append(r.Items, item)
+
+	switch item.Outcome {
+	case ReconciliationMatched:
+		r.Matched++
+	case ReconciliationMissingInOurs:
+		r.MissingInOurs++
+	case ReconciliationMissingInTheirs:
+		r.MissingInTheirs++
+	case ReconciliationAmountMismatch:
+		r.AmountMismatches++
+	case ReconciliationStatusMismatch:
+		r.StatusMismatches++
+	}
+}
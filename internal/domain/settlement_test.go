@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSettlementCSV(t *testing.T) {
+	input := "\ufeffCurrency,Processor_Reference,Amount,Settled_At\n" +
+		"idr,ref-1,15000,2026-10-18T10:00:00Z\n" +
+		"\n" +
+		"THB,ref-2,12.50,\n"
+
+	rows, err := ParseSettlementCSV(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "ref-1", rows[0].ProcessorReference)
+	assert.Equal(t, Money{Amount: 15000, Currency: CurrencyIDR}, rows[0].Amount)
+	require.NotNil(t, rows[0].SettledAt)
+	assert.Equal(t, time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC), *rows[0].SettledAt)
+	assert.Equal(t, Money{Amount: 1250, Currency: CurrencyTHB}, rows[1].Amount)
+	assert.Nil(t, rows[1].SettledAt)
+}
+
+func TestParseSettlementCSV_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "empty file", input: "", wantErr: ErrSettlementHeader.Error()},
+		{name: "missing column", input: "processor_reference,amount\nref-1,100\n", wantErr: ErrSettlementHeader.Error()},
+		{name: "missing reference", input: "processor_reference,amount,currency\n,100,IDR\n", wantErr: "line 2: processor_reference is required"},
+		{name: "duplicate reference", input: "processor_reference,amount,currency\nref-1,100,IDR\nref-1,100,IDR\n", wantErr: "line 3: processor_reference ref-1 already appears on line 2"},
+		{name: "invalid amount", input: "processor_reference,amount,currency\nref-1,abc,IDR\n", wantErr: "line 2:"},
+		{name: "invalid settled_at", input: "processor_reference,amount,currency,settled_at\nref-1,100,IDR,yesterday\n", wantErr: "line 2: settled_at must be an RFC 3339 timestamp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSettlementCSV(strings.NewReader(tt.input))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestWriteSettlementCSV_RoundTrips(t *testing.T) {
+	settledAt := time.Date(2026, 10, 18, 12, 30, 0, 0, time.UTC)
+	rows := []SettlementRow{
+		{ProcessorReference: "ref-1", Amount: Money{Amount: 1250, Currency: CurrencyTHB}, SettledAt: &settledAt},
+		{ProcessorReference: "ref-2", Amount: Money{Amount: 15000, Currency: CurrencyIDR}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSettlementCSV(&buf, rows))
+	assert.Contains(t, buf.String(), "processor_reference,amount,currency,settled_at\nref-1,12.5,THB,2026-10-18T12:30:00Z\n")
+
+	parsed, err := ParseSettlementCSV(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, rows, parsed)
+}
+
+func TestReconciliationAddCountsOutcomes(t *testing.T) {
+	reconciliation := &Reconciliation{ID: "rec-1"}
+	for _, outcome := range []ReconciliationOutcome{
+		ReconciliationMatched, ReconciliationMatched, ReconciliationMissingInOurs,
+		ReconciliationMissingInTheirs, ReconciliationAmountMismatch, ReconciliationStatusMismatch,
+	} {
+		reconciliation.Add(&ReconciliationItem{Outcome: outcome})
+	}
+
+	assert.Equal(t, 2, reconciliation.Matched)
+	assert.Equal(t, 1, reconciliation.MissingInOurs)
+	assert.Equal(t, 1, reconciliation.MissingInTheirs)
+	assert.Equal(t, 1, reconciliation.AmountMismatches)
+	assert.Equal(t, 1, reconciliation.StatusMismatches)
+	assert.Equal(t, "rec-1", reconciliation.Items[5].ReconciliationID)
+	assert.Equal(t, 5, reconciliation.Items[5].Position)
+}
+
+func TestPaymentSettlementReference(t *testing.T) {
+	assert.Equal(t, "pay-1", Payment{ID: "pay-1"}.SettlementReference())
+	assert.Equal(t, "proc-1", Payment{ID: "pay-1", ProcessorReference: "proc-1"}.SettlementReference())
+}
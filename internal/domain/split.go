@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+const MaxPaymentSplits = 10
+
+var (
+	ErrTooManySplits           = errors.New("at most 10 splits are allowed")
+	ErrSplitRecipientRequired  = errors.New("split recipient is required")
+	ErrSplitDuplicateRecipient = errors.New("split recipients must be unique")
+	ErrSplitAmountOrPercentage = errors.New("split must set exactly one of amount or percentage")
+	ErrSplitAmountNotPositive  = errors.New("split amount must be greater than 0")
+	ErrSplitPercentage         = errors.New("split percentage must be greater than 0 and at most 100 with up to 2 decimals")
+	ErrSplitTotalMismatch      = errors.New("splits must sum to the payment amount")
+	ErrSplitShareTooSmall      = errors.New("split percentage resolves to less than one minor unit")
+)
+
+type SplitRequest struct {
+	Recipient  string      `json:"recipient"`
+	Amount     json.Number `json:"amount,omitempty"`
+	Percentage json.Number `json:"percentage,omitempty"`
+}
+
+type PaymentSplit struct {
+	PaymentID  string      `json:"-" gorm:"primaryKey;type:varchar(36)"`
+	Position   int         `json:"-" gorm:"primaryKey"`
+	Recipient  string      `json:"recipient" gorm:"type:varchar(100);not null"`
+	Amount     int64       `json:"amount_minor" gorm:"type:bigint;not null"`
+	Currency   Currency    `json:"currency" gorm:"type:varchar(3);not null"`
+	Percentage json.Number `json:"percentage,omitempty" gorm:"type:varchar(10)"`
+}
+
+func (PaymentSplit) TableName() string {
+	return "payment_splits"
+}
+
+type paymentSplitJSON PaymentSplit
+
+func (s PaymentSplit) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		paymentSplitJSON
+		DecimalAmount json.Number `json:"amount"`
+	}{
+		paymentSplitJSON: paymentSplitJSON(s),
+		DecimalAmount:    NewMoney(s.Amount, s.Currency).Number(),
+	})
+}
+
+func ResolveSplits(total Money, requests []SplitRequest) ([]*PaymentSplit, error) {
+	if len(requests) == 0 {
+		return nil, nil
+	}
+	if len(requests) > MaxPaymentSplits {
+		return nil, ErrTooManySplits
+	}
+
+	splits := make([]*PaymentSplit, len(requests))
+	basisPoints := make([]int64, len(requests))
+	seen := make(map[string]bool, len(requests))
+	var fixed, totalBasisPoints int64
+
+	for i, req := range requests {
+		recipient := strings.TrimSpace(req.Recipient)
+		if recipient == "" {
+			return nil, ErrSplitRecipientRequired
+		}
+		if seen[recipient] {
+			return nil, ErrSplitDuplicateRecipient
+		}
+		seen[recipient] = true
+
+		split := &PaymentSplit{Position: i, Recipient: recipient, Currency: total.Currency}
+		switch {
+		case req.Amount != "" && req.Percentage == "":
+			money, err := ParseMoney(req.Amount.String(), total.Currency)
+			if err != nil {
+				return nil, err
+			}
+			if money.Amount <= 0 {
+				return nil, ErrSplitAmountNotPositive
+			}
+			split.Amount = money.Amount
+			fixed += money.Amount
+		case req.Percentage != "" && req.Amount == "":
+			bp, err := parseBasisPoints(req.Percentage.String())
+			if err != nil {
+				return nil, err
+			}
+			basisPoints[i] = bp
+			totalBasisPoints += bp
+			split.Percentage = json.Number(formatScaled(bp, 2))
+		default:
+			return nil, ErrSplitAmountOrPercentage
+		}
+		splits[i] = split
+	}
+
+	remaining := total.Amount - fixed
+	if remaining < 0 {
+		return nil, ErrSplitTotalMismatch
+	}
+
+	amount := big.NewInt(total.Amount)
+	percentShare := new(big.Int).Mul(amount, big.NewInt(totalBasisPoints))
+	if percentShare.Cmp(new(big.Int).Mul(big.NewInt(remaining), big.NewInt(10000))) != 0 {
+		return nil, ErrSplitTotalMismatch
+	}
+
+	allocated := int64(0)
+	for i, bp := range basisPoints {
+		if bp == 0 {
+			continue
+		}
+		share := new(big.Int).Mul(amount, big.NewInt(bp))
+		share.Quo(share, big.NewInt(10000))
+		splits[i].Amount = share.Int64()
+		allocated += splits[i].Amount
+	}
+
+	for i := 0; allocated < remaining; i = (i + 1) % len(splits) {
+		if basisPoints[i] == 0 {
+			continue
+		}
+		splits[i].Amount++
+		allocated++
+	}
+
+	for _, split := range splits {
+		if split.Amount <= 0 {
+			return nil, ErrSplitShareTooSmall
+		}
+	}
+	return splits, nil
+}
+
+func parseBasisPoints(value string) (int64, error) {
+	rat, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok || strings.Contains(value, "/") {
+		return 0, ErrSplitPercentage
+	}
+	rat.Mul(rat, big.NewRat(100, 1))
+	if !rat.IsInt() || !rat.Num().IsInt64() {
+		return 0, ErrSplitPercentage
+	}
+	bp := rat.Num().Int64()
+	if bp <= 0 || bp > 10000 {
+		return 0, ErrSplitPercentage
+	}
+	return bp, nil
+}
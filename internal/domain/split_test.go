@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func splitAmounts(splits []*PaymentSplit) []int64 {
+	amounts := make([]int64, len(splits))
+	for i, s := range splits {
+		amounts[i] = s.Amount
+	}
+	return amounts
+}
+
+func TestResolveSplits(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    Money
+		requests []SplitRequest
+		amounts  []int64
+		err      error
+	}{
+		{
+			name:  "percentages",
+			total: NewMoney(150000, CurrencyIDR),
+			requests: []SplitRequest{
+				{Recipient: "driver", Percentage: "80"},
+				{Recipient: "platform", Percentage: "20"},
+			},
+			amounts: []int64{120000, 30000},
+		},
+		{
+			name:  "fixed and percentage",
+			total: NewMoney(10000, CurrencyTHB),
+			requests: []SplitRequest{
+				{Recipient: "platform", Amount: "20"},
+				{Recipient: "driver", Percentage: "80"},
+			},
+			amounts: []int64{2000, 8000},
+		},
+		{
+			name:  "rounding remainder goes to percentage splits in order",
+			total: NewMoney(100, CurrencyIDR),
+			requests: []SplitRequest{
+				{Recipient: "a", Percentage: "33.33"},
+				{Recipient: "b", Percentage: "33.33"},
+				{Recipient: "c", Percentage: "33.34"},
+			},
+			amounts: []int64{34, 33, 33},
+		},
+		{
+			name:  "fixed amounts",
+			total: NewMoney(1050, CurrencyTHB),
+			requests: []SplitRequest{
+				{Recipient: "driver", Amount: "8.5"},
+				{Recipient: "platform", Amount: "2"},
+			},
+			amounts: []int64{850, 200},
+		},
+		{
+			name:     "sum below total",
+			total:    NewMoney(1000, CurrencyIDR),
+			requests: []SplitRequest{{Recipient: "driver", Percentage: "90"}},
+			err:      ErrSplitTotalMismatch,
+		},
+		{
+			name:  "fixed above total",
+			total: NewMoney(1000, CurrencyIDR),
+			requests: []SplitRequest{
+				{Recipient: "driver", Amount: "900"},
+				{Recipient: "platform", Amount: "200"},
+			},
+			err: ErrSplitTotalMismatch,
+		},
+		{
+			name:     "both amount and percentage",
+			total:    NewMoney(1000, CurrencyIDR),
+			requests: []SplitRequest{{Recipient: "driver", Amount: "1000", Percentage: "100"}},
+			err:      ErrSplitAmountOrPercentage,
+		},
+		{
+			name:     "missing recipient",
+			total:    NewMoney(1000, CurrencyIDR),
+			requests: []SplitRequest{{Percentage: "100"}},
+			err:      ErrSplitRecipientRequired,
+		},
+		{
+			name:  "duplicate recipient",
+			total: NewMoney(1000, CurrencyIDR),
+			requests: []SplitRequest{
+				{Recipient: "driver", Percentage: "50"},
+				{Recipient: "driver", Percentage: "50"},
+			},
+			err: ErrSplitDuplicateRecipient,
+		},
+		{
+			name:     "percentage precision",
+			total:    NewMoney(1000, CurrencyIDR),
+			requests: []SplitRequest{{Recipient: "driver", Percentage: "99.999"}},
+			err:      ErrSplitPercentage,
+		},
+		{
+			name:     "amount precision",
+			total:    NewMoney(1000, CurrencyIDR),
+			requests: []SplitRequest{{Recipient: "driver", Amount: "999.5"}},
+			err:      ErrAmountPrecision,
+		},
+		{
+			name:  "percentage share rounds down to zero",
+			total: NewMoney(1000, CurrencyIDR),
+			requests: []SplitRequest{
+				{Recipient: "driver", Percentage: "99.99"},
+				{Recipient: "platform", Percentage: "0.01"},
+			},
+			err: ErrSplitShareTooSmall,
+		},
+		{
+			name:     "zero amount",
+			total:    NewMoney(1000, CurrencyIDR),
+			requests: []SplitRequest{{Recipient: "driver", Amount: "0"}},
+			err:      ErrSplitAmountNotPositive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			splits, err := ResolveSplits(tt.total, tt.requests)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.amounts, splitAmounts(splits))
+		})
+	}
+}
+
+func TestResolveSplits_Empty(t *testing.T) {
+	splits, err := ResolveSplits(NewMoney(1000, CurrencyIDR), nil)
+	require.NoError(t, err)
+	assert.Nil(t, splits)
+}
+
+func TestResolveSplits_TooMany(t *testing.T) {
+	requests := make([]SplitRequest, MaxPaymentSplits+1)
+	_, err := ResolveSplits(NewMoney(1000, CurrencyIDR), requests)
+	assert.ErrorIs(t, err, ErrTooManySplits)
+}
+
+func TestPaymentSplitJSON(t *testing.T) {
+	splits, err := ResolveSplits(NewMoney(1050, CurrencyTHB), []SplitRequest{
+		{Recipient: "driver", Percentage: "80.00"},
+		{Recipient: "platform", Amount: "2.10"},
+	})
+	require.NoError(t, err)
+
+	data, err := json.Marshal(splits)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"recipient":"driver","amount":8.4,"amount_minor":840,"currency":"THB","percentage":80},
+		{"recipient":"platform","amount":2.1,"amount_minor":210,"currency":"THB"}
+	]`, string(data))
+}
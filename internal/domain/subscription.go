@@ -0,0 +1,268 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type BillingInterval string
+
+const (
+	BillingIntervalDay   BillingInterval = "DAY"
+	BillingIntervalWeek  BillingInterval = "WEEK"
+	BillingIntervalMonth BillingInterval = "MONTH"
+	BillingIntervalYear  BillingInterval = "YEAR"
+)
+
+type SubscriptionStatus string
+
+const (
+	SubscriptionActive   SubscriptionStatus = "ACTIVE"
+	SubscriptionPastDue  SubscriptionStatus = "PAST_DUE"
+	SubscriptionPaused   SubscriptionStatus = "PAUSED"
+	SubscriptionCanceled SubscriptionStatus = "CANCELED"
+)
+
+type SubscriptionAction string
+
+const (
+	SubscriptionActionPause  SubscriptionAction = "pause"
+	SubscriptionActionResume SubscriptionAction = "resume"
+	SubscriptionActionCancel SubscriptionAction = "cancel"
+)
+
+const (
+	SubscriptionCancelRequested        = "requested"
+	SubscriptionCancelDunningExhausted = "dunning_exhausted"
+)
+
+var ErrSubscriptionTransition = errors.New("subscription cannot make this transition")
+
+type PlanRequest struct {
+	Name          string          `json:"name"`
+	Amount        json.Number     `json:"amount"`
+	Currency      Currency        `json:"currency"`
+	Interval      BillingInterval `json:"interval"`
+	IntervalCount int             `json:"interval_count,omitempty"`
+}
+
+type SubscriptionPlan struct {
+	ID            string          `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	Name          string          `json:"name" gorm:"type:varchar(200);not null"`
+	Amount        int64           `json:"amount_minor" gorm:"type:bigint;not null"`
+	Currency      Currency        `json:"currency" gorm:"type:varchar(3);not null"`
+	Interval      BillingInterval `json:"interval" gorm:"type:varchar(10);not null"`
+	IntervalCount int             `json:"interval_count" gorm:"not null"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+func (SubscriptionPlan) TableName() string {
+	return "subscription_plans"
+}
+
+func (p SubscriptionPlan) Money() Money {
+	return Money{Amount: p.Amount, Currency: p.Currency}
+}
+
+type subscriptionPlanJSON SubscriptionPlan
+
+func (p SubscriptionPlan) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		subscriptionPlanJSON
+		DecimalAmount json.Number `json:"amount"`
+	}{
+		subscriptionPlanJSON: subscriptionPlanJSON(p),
+		DecimalAmount:        p.Money().Number(),
+	})
+}
+
+func (p SubscriptionPlan) PeriodStart(anchor time.Time, periods int) time.Time {
+	return AddBillingInterval(anchor, p.Interval, p.IntervalCount*periods)
+}
+
+func AddBillingInterval(t time.Time, interval BillingInterval, n int) time.Time {
+	switch interval {
+	case BillingIntervalDay:
+		return t.AddDate(0, 0, n)
+	case BillingIntervalWeek:
+		return t.AddDate(0, 0, 7*n)
+	case BillingIntervalYear:
+		n *= 12
+	}
+
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(day, lastDay)-1)
+}
+
+type SubscriptionRequest struct {
+	CustomerID      string     `json:"customer_id"`
+	PlanID          string     `json:"plan_id"`
+	PaymentMethodID string     `json:"payment_method_id"`
+	StartAt         *time.Time `json:"start_at,omitempty"`
+}
+
+type Subscription struct {
+	ID              string             `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	CustomerID      string             `json:"customer_id" gorm:"type:varchar(100);not null;index"`
+	PlanID          string             `json:"plan_id" gorm:"type:varchar(36);not null;index"`
+	PaymentMethodID string             `json:"payment_method_id" gorm:"type:varchar(40);not null"`
+	Status          SubscriptionStatus `json:"status" gorm:"type:varchar(20);not null;index"`
+
+	Period         int        `json:"current_period" gorm:"not null"`
+	PeriodStart    time.Time  `json:"current_period_start" gorm:"not null"`
+	PeriodEnd      time.Time  `json:"current_period_end" gorm:"not null"`
+	PaidThrough    *time.Time `json:"paid_through,omitempty"`
+	NextBillingAt  *time.Time `json:"next_billing_at,omitempty" gorm:"index"`
+	FailedAttempts int        `json:"failed_attempts" gorm:"not null;default:0"`
+	LastPaymentID  string     `json:"last_payment_id,omitempty" gorm:"type:varchar(36)"`
+	LastError      string     `json:"last_error,omitempty" gorm:"type:text"`
+	CancelReason   string     `json:"cancel_reason,omitempty" gorm:"type:varchar(50)"`
+
+	BillingAnchor time.Time  `json:"-" gorm:"not null"`
+	AnchorPeriod  int        `json:"-" gorm:"not null"`
+	LockedUntil   *time.Time `json:"-"`
+
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	PausedAt   *time.Time `json:"paused_at,omitempty"`
+	CanceledAt *time.Time `json:"canceled_at,omitempty"`
+}
+
+func (Subscription) TableName() string {
+	return "subscriptions"
+}
+
+type SubscriptionFilter struct {
+	CustomerID string
+	Status     SubscriptionStatus
+	Limit      int
+}
+
+func NewSubscription(id string, req SubscriptionRequest, plan *SubscriptionPlan, start, now time.Time) *Subscription {
+	sub := &Subscription{
+		ID:              id,
+		CustomerID:      req.CustomerID,
+		PlanID:          plan.ID,
+		PaymentMethodID: req.PaymentMethodID,
+		Status:          SubscriptionActive,
+		Period:          1,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	sub.startPeriod(plan, start)
+	return sub
+}
+
+func (s *Subscription) Live() bool {
+	return s.Status == SubscriptionActive || s.Status == SubscriptionPastDue
+}
+
+func (s *Subscription) BillingKey() string {
+	return fmt.Sprintf("sub_%s_p%d_a%d", s.ID, s.Period, s.FailedAttempts)
+}
+
+func (s *Subscription) startPeriod(plan *SubscriptionPlan, start time.Time) {
+	s.BillingAnchor = start
+	s.AnchorPeriod = s.Period
+	s.PeriodStart = start
+	s.PeriodEnd = plan.PeriodStart(start, 1)
+	s.NextBillingAt = &start
+}
+
+func (s *Subscription) Advance(plan *SubscriptionPlan, paymentID string) {
+	paidThrough := s.PeriodEnd
+	s.PaidThrough = &paidThrough
+	s.LastPaymentID = paymentID
+	s.LastError = ""
+	s.FailedAttempts = 0
+
+	s.Period++
+	s.PeriodStart = paidThrough
+	s.PeriodEnd = plan.PeriodStart(s.BillingAnchor, s.Period-s.AnchorPeriod+1)
+	next := s.PeriodStart
+	s.NextBillingAt = &next
+	if s.Status == SubscriptionPastDue {
+		s.Status = SubscriptionActive
+	}
+}
+
+func (s *Subscription) Fail(reason string, dunning []time.Duration, now time.Time) {
+	s.FailedAttempts++
+	s.LastError = reason
+	if s.FailedAttempts > len(dunning) {
+		s.cancel(SubscriptionCancelDunningExhausted, now)
+		return
+	}
+
+	next := now.Add(dunning[s.FailedAttempts-1])
+	s.NextBillingAt = &next
+	s.Status = SubscriptionPastDue
+}
+
+func (s *Subscription) Apply(action SubscriptionAction, plan *SubscriptionPlan, now time.Time) error {
+	switch action {
+	case SubscriptionActionPause:
+		switch {
+		case s.Status == SubscriptionPaused:
+		case s.Live():
+			s.Status = SubscriptionPaused
+			s.PausedAt = &now
+			s.NextBillingAt = nil
+		default:
+			return ErrSubscriptionTransition
+		}
+	case SubscriptionActionResume:
+		switch s.Status {
+		case SubscriptionActive, SubscriptionPastDue:
+		case SubscriptionPaused:
+			s.Status = SubscriptionActive
+			if s.FailedAttempts > 0 {
+				s.Status = SubscriptionPastDue
+			}
+			s.PausedAt = nil
+			start := now
+			if s.PaidThrough != nil && s.PaidThrough.After(now) {
+				start = *s.PaidThrough
+			}
+			s.startPeriod(plan, start)
+		default:
+			return ErrSubscriptionTransition
+		}
+	case SubscriptionActionCancel:
+		if s.Status != SubscriptionCanceled {
+			s.cancel(SubscriptionCancelRequested, now)
+		}
+	default:
+		return ErrSubscriptionTransition
+	}
+	s.UpdatedAt = now
+	return nil
+}
+
+func (s *Subscription) cancel(reason string, now time.Time) {
+	s.Status = SubscriptionCanceled
+	s.CancelReason = reason
+	s.CanceledAt = &now
+	s.NextBillingAt = nil
+}
+
+func ParseDunningSchedule(value string) ([]time.Duration, error) {
+	var schedule []time.Duration
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		delay, err := time.ParseDuration(part)
+		if err != nil || delay <= 0 {
+			return nil, fmt.Errorf("invalid dunning delay %q", part)
+		}
+		schedule = append(schedule, delay)
+	}
+	return schedule, nil
+}
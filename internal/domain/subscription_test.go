@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func monthlyPlan() *SubscriptionPlan {
+	return &SubscriptionPlan{ID: "plan-1", Name: "Rider Pass", Amount: 50000, Currency: CurrencyIDR, Interval: BillingIntervalMonth, IntervalCount: 1}
+}
+
+func TestAddBillingInterval_ClampsToMonthEnd(t *testing.T) {
+	jan31 := time.Date(2026, time.January, 31, 9, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2026, time.February, 28, 9, 0, 0, 0, time.UTC), AddBillingInterval(jan31, BillingIntervalMonth, 1))
+	assert.Equal(t, time.Date(2026, time.March, 31, 9, 0, 0, 0, time.UTC), AddBillingInterval(jan31, BillingIntervalMonth, 2))
+	assert.Equal(t, time.Date(2029, time.February, 28, 0, 0, 0, 0, time.UTC),
+		AddBillingInterval(time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC), BillingIntervalYear, 1))
+	assert.Equal(t, jan31.AddDate(0, 0, 14), AddBillingInterval(jan31, BillingIntervalWeek, 2))
+}
+
+func TestSubscription_AdvanceKeepsAnchorDay(t *testing.T) {
+	start := time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC)
+	plan := monthlyPlan()
+	sub := NewSubscription("sub-1", SubscriptionRequest{CustomerID: "cust-001"}, plan, start, start)
+
+	assert.Equal(t, "sub_sub-1_p1_a0", sub.BillingKey())
+	sub.Advance(plan, "pay-1")
+	assert.Equal(t, 2, sub.Period)
+	assert.Equal(t, time.Date(2026, time.February, 28, 0, 0, 0, 0, time.UTC), sub.PeriodStart)
+	assert.Equal(t, time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC), sub.PeriodEnd)
+	assert.Equal(t, sub.PeriodStart, *sub.NextBillingAt)
+	assert.Equal(t, sub.PeriodStart, *sub.PaidThrough)
+	assert.Equal(t, "pay-1", sub.LastPaymentID)
+}
+
+func TestSubscription_FailFollowsDunningSchedule(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	dunning := []time.Duration{24 * time.Hour, 72 * time.Hour}
+	sub := NewSubscription("sub-1", SubscriptionRequest{}, monthlyPlan(), now, now)
+
+	sub.Fail("card declined", dunning, now)
+	assert.Equal(t, SubscriptionPastDue, sub.Status)
+	assert.Equal(t, now.Add(24*time.Hour), *sub.NextBillingAt)
+	assert.Equal(t, "sub_sub-1_p1_a1", sub.BillingKey())
+
+	sub.Fail("card declined", dunning, now)
+	assert.Equal(t, now.Add(72*time.Hour), *sub.NextBillingAt)
+
+	sub.Fail("card declined", dunning, now)
+	assert.Equal(t, SubscriptionCanceled, sub.Status)
+	assert.Equal(t, SubscriptionCancelDunningExhausted, sub.CancelReason)
+	assert.Nil(t, sub.NextBillingAt)
+}
+
+func TestSubscription_Apply(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	plan := monthlyPlan()
+	sub := NewSubscription("sub-1", SubscriptionRequest{}, plan, now, now)
+	sub.Advance(plan, "pay-1")
+
+	require.NoError(t, sub.Apply(SubscriptionActionPause, plan, now))
+	assert.Equal(t, SubscriptionPaused, sub.Status)
+	assert.Nil(t, sub.NextBillingAt)
+	require.NoError(t, sub.Apply(SubscriptionActionPause, plan, now))
+
+	require.NoError(t, sub.Apply(SubscriptionActionResume, plan, now))
+	assert.Equal(t, SubscriptionActive, sub.Status)
+	assert.Equal(t, *sub.PaidThrough, *sub.NextBillingAt, "resuming inside a paid period bills when it ends")
+
+	require.NoError(t, sub.Apply(SubscriptionActionCancel, plan, now))
+	assert.Equal(t, SubscriptionCanceled, sub.Status)
+	assert.Equal(t, SubscriptionCancelRequested, sub.CancelReason)
+	require.NoError(t, sub.Apply(SubscriptionActionCancel, plan, now))
+
+	assert.ErrorIs(t, sub.Apply(SubscriptionActionResume, plan, now), ErrSubscriptionTransition)
+	assert.ErrorIs(t, sub.Apply(SubscriptionActionPause, plan, now), ErrSubscriptionTransition)
+}
+
+func TestParseDunningSchedule(t *testing.T) {
+	schedule, err := ParseDunningSchedule("24h, 72h,,168h")
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{24 * time.Hour, 72 * time.Hour, 168 * time.Hour}, schedule)
+
+	_, err = ParseDunningSchedule("24h,soon")
+	assert.Error(t, err)
+	_, err = ParseDunningSchedule("-1h")
+	assert.Error(t, err)
+}
@@ -1,15 +1,33 @@
 package migrations
 
 import (
-	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	"time"
+
 	"gorm.io/gorm"
 )
 
+type payment001 struct {
+	ID          string    `gorm:"primaryKey;type:varchar(36)"`
+	Amount      float64   `gorm:"not null"`
+	Currency    string    `gorm:"type:varchar(3);not null"`
+	CustomerID  string    `gorm:"type:varchar(100);not null"`
+	RideID      string    `gorm:"type:varchar(100);not null"`
+	Status      string    `gorm:"type:varchar(20);not null"`
+	CardLast4   string    `gorm:"type:varchar(4)"`
+	Description string    `gorm:"type:text"`
+	FailReason  string    `gorm:"type:text"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+func (payment001) TableName() string {
+	return "payments"
+}
+
 func init() {
 	Register(Migration{
 		ID: "001_create_payments",
 		Migrate: func(tx *gorm.DB) error {
-			return tx.AutoMigrate(&domain.Payment{})
+			return tx.AutoMigrate(&payment001{})
 		},
 	})
 }
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type payment003 struct {
+	payment001
+	StatusChecks      int        `gorm:"not null;default:0"`
+	NextStatusCheckAt *time.Time `gorm:"index"`
+}
+
+func (payment003) TableName() string {
+	return "payments"
+}
+
+type idempotencyRecord003 struct {
+	Key                string    `gorm:"primaryKey;type:varchar(64)"`
+	RequestFingerprint string    `gorm:"type:varchar(64);not null"`
+	PaymentID          string    `gorm:"type:varchar(36);index"`
+	ResponseBody       []byte    `gorm:"type:jsonb"`
+	Status             string    `gorm:"type:varchar(20);not null"`
+	CreatedAt          time.Time `gorm:"autoCreateTime"`
+	ExpiresAt          time.Time `gorm:"index;not null"`
+}
+
+func (idempotencyRecord003) TableName() string {
+	return "idempotency_records"
+}
+
+func init() {
+	Register(Migration{
+		ID: "003_add_payment_status_checks",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&payment003{}, &idempotencyRecord003{})
+		},
+	})
+}
@@ -0,0 +1,15 @@
+package migrations
+
+import (
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	"gorm.io/gorm"
+)
+
+func init() {
+	Register(Migration{
+		ID: "004_create_webhooks",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&domain.OutboxEvent{}, &domain.WebhookEndpoint{}, &domain.WebhookDelivery{})
+		},
+	})
+}
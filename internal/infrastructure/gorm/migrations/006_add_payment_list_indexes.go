@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+func init() {
+	Register(Migration{
+		ID: "006_add_payment_list_indexes",
+		Migrate: func(tx *gorm.DB) error {
+			statements := []string{
+				"CREATE INDEX IF NOT EXISTS idx_payments_created_at_id ON payments (created_at DESC, id DESC)",
+				"CREATE INDEX IF NOT EXISTS idx_payments_customer_created_at ON payments (customer_id, created_at DESC, id DESC)",
+				"CREATE INDEX IF NOT EXISTS idx_payments_ride_created_at ON payments (ride_id, created_at DESC, id DESC)",
+				"CREATE INDEX IF NOT EXISTS idx_payments_status_created_at ON payments (status, created_at DESC, id DESC)",
+			}
+			for _, stmt := range statements {
+				if err := tx.Exec(stmt).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
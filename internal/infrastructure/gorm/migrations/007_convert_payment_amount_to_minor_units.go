@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+func init() {
+	Register(Migration{
+		ID: "007_convert_payment_amount_to_minor_units",
+		Migrate: func(tx *gorm.DB) error {
+			var dataType string
+			err := tx.Raw(`SELECT data_type FROM information_schema.columns
+				WHERE table_schema = current_schema() AND table_name = 'payments' AND column_name = 'amount'`).
+				Scan(&dataType).Error
+			if err != nil {
+				return err
+			}
+
+			switch dataType {
+			case "double precision", "real", "numeric":
+			default:
+				return nil
+			}
+			return tx.Exec(`ALTER TABLE payments ALTER COLUMN amount TYPE bigint
+				USING ROUND(amount::numeric * CASE currency WHEN 'THB' THEN 100 WHEN 'PHP' THEN 100 ELSE 1 END)::bigint`).Error
+		},
+	})
+}
@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func init() {
+	Register(Migration{
+		ID: "008_create_currencies",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&domain.CurrencyDefinition{}); err != nil {
+				return err
+			}
+			defaults := domain.DefaultCurrencyDefinitions()
+			return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&defaults).Error
+		},
+	})
+}
@@ -0,0 +1,15 @@
+package migrations
+
+import (
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	"gorm.io/gorm"
+)
+
+func init() {
+	Register(Migration{
+		ID: "009_add_payment_card_brand",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&domain.Payment{})
+		},
+	})
+}
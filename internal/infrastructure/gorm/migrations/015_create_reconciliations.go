@@ -0,0 +1,15 @@
+package migrations
+
+import (
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	"gorm.io/gorm"
+)
+
+func init() {
+	Register(Migration{
+		ID: "015_create_reconciliations",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&domain.Reconciliation{}, &domain.ReconciliationItem{})
+		},
+	})
+}
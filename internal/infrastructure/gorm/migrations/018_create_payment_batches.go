@@ -0,0 +1,15 @@
+package migrations
+
+import (
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	"gorm.io/gorm"
+)
+
+func init() {
+	Register(Migration{
+		ID: "018_create_payment_batches",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&domain.PaymentBatch{})
+		},
+	})
+}
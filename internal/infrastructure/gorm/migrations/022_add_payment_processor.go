@@ -0,0 +1,15 @@
+package migrations
+
+import (
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	"gorm.io/gorm"
+)
+
+func init() {
+	Register(Migration{
+		ID: "022_add_payment_processor",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&domain.Payment{})
+		},
+	})
+}
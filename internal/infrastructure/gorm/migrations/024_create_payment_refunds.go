@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type refund024 struct {
+	ID                 string `gorm:"primaryKey;type:varchar(36)"`
+	IdempotencyKey     string `gorm:"type:varchar(64);uniqueIndex;not null"`
+	RequestFingerprint string `gorm:"type:varchar(64);not null"`
+	PaymentID          string `gorm:"type:varchar(36);not null;index"`
+	Amount             int64  `gorm:"type:bigint;not null"`
+	Currency           string `gorm:"type:varchar(3);not null"`
+	Reason             string `gorm:"type:text"`
+	ProcessorReference string `gorm:"type:varchar(100)"`
+	CreatedAt          time.Time
+}
+
+func (refund024) TableName() string {
+	return "payment_refunds"
+}
+
+func init() {
+	Register(Migration{
+		ID: "024_create_payment_refunds",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&refund024{})
+		},
+	})
+}
@@ -4,6 +4,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 func TestMigrationRecordTableName(t *testing.T) {
@@ -42,3 +46,17 @@ func TestRegistryPreservesOrdering(t *testing.T) {
 		assert.Equal(t, id, registry[i].ID)
 	}
 }
+
+func TestHistoricalPaymentMigrationsKeepDecimalAmount(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	require.NoError(t, db.AutoMigrate(&payment001{}))
+	require.NoError(t, db.Exec("INSERT INTO payments (id, amount, currency, customer_id, ride_id, status) VALUES ('pay_1', 12.34, 'THB', 'c', 'r', 'SUCCEEDED')").Error)
+	require.NoError(t, db.AutoMigrate(&payment003{}, &idempotencyRecord003{}))
+
+	var amount float64
+	require.NoError(t, db.Raw("SELECT amount FROM payments WHERE id = 'pay_1'").Scan(&amount).Error)
+	assert.Equal(t, 12.34, amount)
+	assert.True(t, db.Migrator().HasColumn(&payment003{}, "next_status_check_at"))
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"gorm.io/gorm"
+)
+
+type CurrencyRepo struct {
+	db *gorm.DB
+}
+
+func NewCurrencyRepo(db *gorm.DB) domain.CurrencyRepository {
+	return &CurrencyRepo{db: db}
+}
+
+func (r *CurrencyRepo) conn(ctx context.Context) *gorm.DB {
+	return gormdb.ExtractTx(ctx, r.db).WithContext(ctx)
+}
+
+func (r *CurrencyRepo) List(ctx context.Context) ([]*domain.CurrencyDefinition, error) {
+	var definitions []*domain.CurrencyDefinition
+	err := r.conn(ctx).Order("code ASC").Find(&definitions).Error
+	return definitions, err
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrencyList(t *testing.T) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+	repo := NewCurrencyRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, db.Create(&domain.CurrencyDefinition{Code: "SGD", Exponent: 2, MinAmount: 100, Enabled: true}).Error)
+	require.NoError(t, db.Create(&domain.CurrencyDefinition{Code: "MYR", Exponent: 2, Enabled: false}).Error)
+
+	definitions, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, definitions, 2)
+	assert.Equal(t, domain.Currency("MYR"), definitions[0].Code)
+	assert.False(t, definitions[0].Enabled)
+	assert.Equal(t, domain.Currency("SGD"), definitions[1].Code)
+	assert.Equal(t, int64(100), definitions[1].MinAmount)
+	assert.True(t, definitions[1].Enabled)
+}
@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"gorm.io/gorm"
+)
+
+type CustomerRepo struct {
+	db *gorm.DB
+}
+
+func NewCustomerRepo(db *gorm.DB) domain.CustomerRepository {
+	return &CustomerRepo{db: db}
+}
+
+func (r *CustomerRepo) conn(ctx context.Context) *gorm.DB {
+	return gormdb.ExtractTx(ctx, r.db).WithContext(ctx)
+}
+
+func (r *CustomerRepo) Create(ctx context.Context, customer *domain.Customer) error {
+	return r.conn(ctx).Create(customer).Error
+}
+
+func (r *CustomerRepo) FindByID(ctx context.Context, id string) (*domain.Customer, error) {
+	var customer domain.Customer
+	err := r.conn(ctx).Where("id = ?", id).First(&customer).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+func (r *CustomerRepo) List(ctx context.Context, limit int) ([]*domain.Customer, error) {
+	var customers []*domain.Customer
+	err := r.conn(ctx).Order("created_at DESC, id DESC").Limit(limit).Find(&customers).Error
+	if err != nil {
+		return nil, err
+	}
+	return customers, nil
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomerRepo(t *testing.T) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+	repo := NewCustomerRepo(db)
+	ctx := context.Background()
+	now := time.Now()
+
+	require.NoError(t, repo.Create(ctx, &domain.Customer{ID: "cust-1", Name: "Ayu", Country: "ID", CreatedAt: now.Add(-time.Minute)}))
+	require.NoError(t, repo.Create(ctx, &domain.Customer{ID: "cust-2", Name: "Somchai", Country: "TH", CreatedAt: now}))
+	assert.Error(t, repo.Create(ctx, &domain.Customer{ID: "cust-1", Name: "Dup", CreatedAt: now}))
+
+	found, err := repo.FindByID(ctx, "cust-1")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "Ayu", found.Name)
+
+	missing, err := repo.FindByID(ctx, "nobody")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	customers, err := repo.List(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, customers, 1)
+	assert.Equal(t, "cust-2", customers[0].ID)
+}
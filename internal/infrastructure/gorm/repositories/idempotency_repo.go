@@ -52,6 +52,18 @@ func (r *IdempotencyRepo) FindByKeyForUpdate(ctx context.Context, key string) (*
 	return &record, nil
 }
 
+func (r *IdempotencyRepo) ListByPaymentID(ctx context.Context, paymentID string) ([]*domain.IdempotencyRecord, error) {
+	var records []*domain.IdempotencyRecord
+	err := r.conn(ctx).
+		Where("payment_id = ?", paymentID).
+		Order("created_at ASC").
+		Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
 func (r *IdempotencyRepo) Create(ctx context.Context, record *domain.IdempotencyRecord) error {
 	return r.conn(ctx).Create(record).Error
 }
@@ -234,3 +234,28 @@ func TestUpdateInTransaction(t *testing.T) {
 	assert.Equal(t, domain.IdempotencyStatusCompleted, found.Status)
 	assert.Equal(t, "pay-tx-updated", found.PaymentID)
 }
+
+func TestListByPaymentID(t *testing.T) {
+	repo, _ := setupIdempotencyTest(t)
+	ctx := context.Background()
+
+	for _, key := range []string{"payment-id-key", "payment-id-duplicate"} {
+		require.NoError(t, repo.Create(ctx, &domain.IdempotencyRecord{
+			Key:                key,
+			RequestFingerprint: "fp-" + key,
+			PaymentID:          "pay-lookup",
+			Status:             domain.IdempotencyStatusCompleted,
+			CreatedAt:          time.Now(),
+			ExpiresAt:          time.Now().Add(24 * time.Hour),
+		}))
+	}
+
+	found, err := repo.ListByPaymentID(ctx, "pay-lookup")
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.ElementsMatch(t, []string{"payment-id-key", "payment-id-duplicate"}, []string{found[0].Key, found[1].Key})
+
+	missing, err := repo.ListByPaymentID(ctx, "pay-missing")
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+}
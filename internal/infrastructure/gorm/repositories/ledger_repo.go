@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LedgerRepo struct {
+	db *gorm.DB
+}
+
+func NewLedgerRepo(db *gorm.DB) domain.LedgerRepository {
+	return &LedgerRepo{db: db}
+}
+
+func (r *LedgerRepo) conn(ctx context.Context) *gorm.DB {
+	return gormdb.ExtractTx(ctx, r.db).WithContext(ctx)
+}
+
+func (r *LedgerRepo) EnsureAccounts(ctx context.Context, accounts ...*domain.LedgerAccount) error {
+	if len(accounts) == 0 {
+		return nil
+	}
+	return r.conn(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "id"}}, DoNothing: true}).
+		Create(&accounts).Error
+}
+
+func (r *LedgerRepo) CreateEntry(ctx context.Context, entry *domain.JournalEntry) error {
+	return r.conn(ctx).Create(entry).Error
+}
+
+func (r *LedgerRepo) ListEntriesByPayment(ctx context.Context, paymentID string) ([]*domain.JournalEntry, error) {
+	var entries []*domain.JournalEntry
+	err := r.conn(ctx).
+		Preload("Postings", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Where("payment_id = ?", paymentID).
+		Order("created_at ASC, id ASC").
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *LedgerRepo) Balances(ctx context.Context, filter domain.LedgerBalanceFilter) ([]*domain.AccountBalance, error) {
+	query := r.conn(ctx).
+		Table("ledger_accounts AS a").
+		Select(`a.id AS account_id, a.type AS type, a.currency AS currency,
+			COALESCE(SUM(CASE WHEN p.amount > 0 THEN p.amount ELSE 0 END), 0) AS debits,
+			COALESCE(SUM(CASE WHEN p.amount < 0 THEN -p.amount ELSE 0 END), 0) AS credits`).
+		Joins("LEFT JOIN ledger_postings AS p ON p.account_id = a.id AND p.created_at <= ?", filter.AsOf)
+	if filter.AccountID != "" {
+		query = query.Where("a.id = ?", filter.AccountID)
+	}
+	if filter.Currency != "" {
+		query = query.Where("a.currency = ?", filter.Currency)
+	}
+
+	var balances []*domain.AccountBalance
+	err := query.Group("a.id, a.type, a.currency").Order("a.id ASC").Scan(&balances).Error
+	if err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedgerRepo_EntriesAndBalances(t *testing.T) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+	repo := NewLedgerRepo(db)
+	ctx := context.Background()
+
+	receivable := domain.NewLedgerAccount(domain.AccountProcessorReceivable, domain.LedgerAccountAsset, domain.CurrencyIDR)
+	merchant := domain.NewLedgerAccount(domain.AccountMerchantPayable, domain.LedgerAccountLiability, domain.CurrencyIDR)
+	require.NoError(t, repo.EnsureAccounts(ctx, receivable, merchant))
+	require.NoError(t, repo.EnsureAccounts(ctx, receivable), "existing accounts are ignored")
+
+	earlier := time.Now().Add(-time.Hour)
+	first := &domain.JournalEntry{ID: "entry-1", PaymentID: "pay-1", Type: domain.JournalEntryCharge, CreatedAt: earlier}
+	first.Debit(receivable, 500)
+	first.Credit(merchant, 500)
+	require.NoError(t, repo.CreateEntry(ctx, first))
+
+	second := &domain.JournalEntry{ID: "entry-2", PaymentID: "pay-1", Type: domain.JournalEntryReversal, CreatedAt: time.Now()}
+	second.Debit(merchant, 200)
+	second.Credit(receivable, 200)
+	require.NoError(t, repo.CreateEntry(ctx, second))
+
+	entries, err := repo.ListEntriesByPayment(ctx, "pay-1")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "entry-1", entries[0].ID)
+	require.Len(t, entries[0].Postings, 2)
+	assert.Equal(t, int64(-500), entries[0].Postings[1].Amount)
+
+	balances, err := repo.Balances(ctx, domain.LedgerBalanceFilter{AsOf: time.Now().Add(time.Minute)})
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+	assert.Equal(t, "merchant_payable:IDR", balances[0].AccountID)
+	assert.Equal(t, int64(200), balances[0].Debits)
+	assert.Equal(t, int64(500), balances[0].Credits)
+
+	past, err := repo.Balances(ctx, domain.LedgerBalanceFilter{AccountID: receivable.ID, AsOf: earlier.Add(time.Minute)})
+	require.NoError(t, err)
+	require.Len(t, past, 1)
+	assert.Equal(t, int64(500), past[0].Debits)
+	assert.Zero(t, past[0].Credits)
+
+	none, err := repo.Balances(ctx, domain.LedgerBalanceFilter{Currency: domain.CurrencyTHB, AsOf: time.Now()})
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
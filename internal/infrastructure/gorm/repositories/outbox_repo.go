@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"gorm.io/gorm"
+)
+
+type OutboxRepo struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepo(db *gorm.DB) domain.OutboxRepository {
+	return &OutboxRepo{db: db}
+}
+
+func (r *OutboxRepo) conn(ctx context.Context) *gorm.DB {
+	return gormdb.ExtractTx(ctx, r.db).WithContext(ctx)
+}
+
+func (r *OutboxRepo) Create(ctx context.Context, event *domain.OutboxEvent) error {
+	return r.conn(ctx).Create(event).Error
+}
+
+func (r *OutboxRepo) FindByID(ctx context.Context, id string) (*domain.OutboxEvent, error) {
+	var event domain.OutboxEvent
+	err := r.conn(ctx).Where("id = ?", id).First(&event).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *OutboxRepo) FindPending(ctx context.Context, limit int) ([]*domain.OutboxEvent, error) {
+	var events []*domain.OutboxEvent
+	err := r.conn(ctx).
+		Where("status = ?", domain.OutboxStatusPending).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *OutboxRepo) MarkDispatched(ctx context.Context, id string) (bool, error) {
+	result := r.conn(ctx).
+		Model(&domain.OutboxEvent{}).
+		Where("id = ? AND status = ?", id, domain.OutboxStatusPending).
+		Update("status", domain.OutboxStatusDispatched)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *OutboxRepo) Update(ctx context.Context, event *domain.OutboxEvent) error {
+	return r.conn(ctx).Save(event).Error
+}
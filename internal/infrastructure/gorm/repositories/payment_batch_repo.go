@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PaymentBatchRepo struct {
+	db *gorm.DB
+}
+
+func NewPaymentBatchRepo(db *gorm.DB) domain.PaymentBatchRepository {
+	return &PaymentBatchRepo{db: db}
+}
+
+func (r *PaymentBatchRepo) conn(ctx context.Context) *gorm.DB {
+	return gormdb.ExtractTx(ctx, r.db).WithContext(ctx)
+}
+
+func (r *PaymentBatchRepo) CreateIfAbsent(ctx context.Context, batch *domain.PaymentBatch) (bool, error) {
+	result := r.conn(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "idempotency_key"}},
+			Where:     clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "payment_batches.expires_at < ?", Vars: []interface{}{time.Now()}}}},
+			DoUpdates: clause.AssignmentColumns([]string{"request_fingerprint", "status", "response_body", "created_at", "updated_at", "expires_at"}),
+		}).
+		Create(batch)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *PaymentBatchRepo) FindByKey(ctx context.Context, key string) (*domain.PaymentBatch, error) {
+	var batch domain.PaymentBatch
+	err := r.conn(ctx).
+		Where("idempotency_key = ? AND expires_at > ?", key, time.Now()).
+		First(&batch).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+func (r *PaymentBatchRepo) ClaimStale(ctx context.Context, key string, staleBefore time.Time) (bool, error) {
+	result := r.conn(ctx).
+		Model(&domain.PaymentBatch{}).
+		Where("idempotency_key = ? AND status = ? AND updated_at < ?", key, domain.IdempotencyStatusProcessing, staleBefore).
+		Update("updated_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *PaymentBatchRepo) Update(ctx context.Context, batch *domain.PaymentBatch) error {
+	return r.conn(ctx).Save(batch).Error
+}
+
+func (r *PaymentBatchRepo) DeleteExpired(ctx context.Context) (int64, error) {
+	result := r.conn(ctx).
+		Where("expires_at < ?", time.Now()).
+		Delete(&domain.PaymentBatch{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBatch(key string, expiresAt time.Time) *domain.PaymentBatch {
+	now := time.Now()
+	return &domain.PaymentBatch{
+		IdempotencyKey:     key,
+		RequestFingerprint: "fp-" + key,
+		Status:             domain.IdempotencyStatusProcessing,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+		ExpiresAt:          expiresAt,
+	}
+}
+
+func TestPaymentBatchRepo_CreateIfAbsent(t *testing.T) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+	repo := NewPaymentBatchRepo(db)
+	ctx := context.Background()
+
+	created, err := repo.CreateIfAbsent(ctx, newTestBatch("batch-1", time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	created, err = repo.CreateIfAbsent(ctx, newTestBatch("batch-1", time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+	assert.False(t, created)
+
+	found, err := repo.FindByKey(ctx, "batch-1")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "fp-batch-1", found.RequestFingerprint)
+
+	missing, err := repo.FindByKey(ctx, "batch-missing")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestPaymentBatchRepo_ExpiredBatchIsReplaced(t *testing.T) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+	repo := NewPaymentBatchRepo(db)
+	ctx := context.Background()
+
+	_, err = repo.CreateIfAbsent(ctx, newTestBatch("batch-old", time.Now().Add(-time.Minute)))
+	require.NoError(t, err)
+
+	found, err := repo.FindByKey(ctx, "batch-old")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	replacement := newTestBatch("batch-old", time.Now().Add(time.Hour))
+	replacement.RequestFingerprint = "fp-new"
+	created, err := repo.CreateIfAbsent(ctx, replacement)
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	found, err = repo.FindByKey(ctx, "batch-old")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "fp-new", found.RequestFingerprint)
+}
+
+func TestPaymentBatchRepo_ClaimStaleAndDeleteExpired(t *testing.T) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+	repo := NewPaymentBatchRepo(db)
+	ctx := context.Background()
+
+	batch := newTestBatch("batch-stale", time.Now().Add(time.Hour))
+	batch.UpdatedAt = time.Now().Add(-10 * time.Minute)
+	_, err = repo.CreateIfAbsent(ctx, batch)
+	require.NoError(t, err)
+
+	claimed, err := repo.ClaimStale(ctx, "batch-stale", time.Now().Add(-5*time.Minute))
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	claimed, err = repo.ClaimStale(ctx, "batch-stale", time.Now().Add(-5*time.Minute))
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	_, err = repo.CreateIfAbsent(ctx, newTestBatch("batch-expired", time.Now().Add(-time.Minute)))
+	require.NoError(t, err)
+
+	deleted, err := repo.DeleteExpired(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"gorm.io/gorm"
+)
+
+type PaymentJobRepo struct {
+	db *gorm.DB
+}
+
+func NewPaymentJobRepo(db *gorm.DB) domain.PaymentJobRepository {
+	return &PaymentJobRepo{db: db}
+}
+
+func (r *PaymentJobRepo) conn(ctx context.Context) *gorm.DB {
+	return gormdb.ExtractTx(ctx, r.db).WithContext(ctx)
+}
+
+func (r *PaymentJobRepo) Create(ctx context.Context, job *domain.PaymentJob) error {
+	return r.conn(ctx).Create(job).Error
+}
+
+func (r *PaymentJobRepo) FindByID(ctx context.Context, id string) (*domain.PaymentJob, error) {
+	var job domain.PaymentJob
+	err := r.conn(ctx).Where("id = ?", id).First(&job).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *PaymentJobRepo) ClaimNext(ctx context.Context, staleBefore time.Time) (*domain.PaymentJob, error) {
+	var job domain.PaymentJob
+	err := r.conn(ctx).
+		Where("status = ? OR (status = ? AND updated_at < ?)", domain.PaymentJobQueued, domain.PaymentJobRunning, staleBefore).
+		Order("created_at ASC").
+		First(&job).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := r.conn(ctx).
+		Model(&domain.PaymentJob{}).
+		Where("id = ? AND status = ? AND updated_at = ?", job.ID, job.Status, job.UpdatedAt).
+		Updates(map[string]interface{}{"status": domain.PaymentJobRunning, "updated_at": now})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	job.Status = domain.PaymentJobRunning
+	job.UpdatedAt = now
+	return &job, nil
+}
+
+func (r *PaymentJobRepo) Update(ctx context.Context, job *domain.PaymentJob) error {
+	return r.conn(ctx).Save(job).Error
+}
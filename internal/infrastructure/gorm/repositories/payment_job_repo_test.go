@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentJobRepo_ClaimNext(t *testing.T) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+	repo := NewPaymentJobRepo(db)
+	ctx := context.Background()
+	now := time.Now()
+
+	queued := &domain.PaymentJob{ID: "job-queued", Status: domain.PaymentJobQueued, Input: "x", CreatedAt: now, UpdatedAt: now}
+	require.NoError(t, repo.Create(ctx, queued))
+
+	claimed, err := repo.ClaimNext(ctx, now.Add(-5*time.Minute))
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+	assert.Equal(t, "job-queued", claimed.ID)
+	assert.Equal(t, domain.PaymentJobRunning, claimed.Status)
+
+	next, err := repo.ClaimNext(ctx, now.Add(-5*time.Minute))
+	require.NoError(t, err)
+	assert.Nil(t, next)
+
+	stale, err := repo.ClaimNext(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.NotNil(t, stale)
+	assert.Equal(t, "job-queued", stale.ID)
+
+	found, err := repo.FindByID(ctx, "job-queued")
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentJobRunning, found.Status)
+
+	missing, err := repo.FindByID(ctx, "job-missing")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"gorm.io/gorm"
+)
+
+type PaymentMethodRepo struct {
+	db *gorm.DB
+}
+
+func NewPaymentMethodRepo(db *gorm.DB) domain.PaymentMethodRepository {
+	return &PaymentMethodRepo{db: db}
+}
+
+func (r *PaymentMethodRepo) conn(ctx context.Context) *gorm.DB {
+	return gormdb.ExtractTx(ctx, r.db).WithContext(ctx)
+}
+
+func (r *PaymentMethodRepo) Create(ctx context.Context, method *domain.PaymentMethod) error {
+	return r.conn(ctx).Create(method).Error
+}
+
+func (r *PaymentMethodRepo) FindByID(ctx context.Context, id string) (*domain.PaymentMethod, error) {
+	var method domain.PaymentMethod
+	err := r.conn(ctx).Where("id = ?", id).First(&method).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &method, nil
+}
+
+func (r *PaymentMethodRepo) FindByFingerprint(ctx context.Context, customerID, fingerprint string) (*domain.PaymentMethod, error) {
+	var method domain.PaymentMethod
+	err := r.conn(ctx).
+		Where("customer_id = ? AND card_fingerprint = ?", customerID, fingerprint).
+		First(&method).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &method, nil
+}
+
+func (r *PaymentMethodRepo) ListByCustomer(ctx context.Context, customerID string) ([]*domain.PaymentMethod, error) {
+	var methods []*domain.PaymentMethod
+	err := r.conn(ctx).Where("customer_id = ?", customerID).Order("created_at ASC").Find(&methods).Error
+	if err != nil {
+		return nil, err
+	}
+	return methods, nil
+}
+
+func (r *PaymentMethodRepo) Delete(ctx context.Context, id string) error {
+	return r.conn(ctx).Where("id = ?", id).Delete(&domain.PaymentMethod{}).Error
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func samplePaymentMethod(id, customerID, fingerprint string) *domain.PaymentMethod {
+	return &domain.PaymentMethod{
+		ID:              id,
+		CustomerID:      customerID,
+		CardBrand:       domain.CardBrandVisa,
+		CardLast4:       "1111",
+		ExpMonth:        12,
+		ExpYear:         2030,
+		CardFingerprint: fingerprint,
+		EncryptedCard:   []byte("ciphertext"),
+		CreatedAt:       time.Now(),
+	}
+}
+
+func TestPaymentMethodRepo(t *testing.T) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+	repo := NewPaymentMethodRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Create(ctx, samplePaymentMethod("pm_1", "cust-1", "fp-a")))
+	require.NoError(t, repo.Create(ctx, samplePaymentMethod("pm_2", "cust-1", "fp-b")))
+	require.NoError(t, repo.Create(ctx, samplePaymentMethod("pm_3", "cust-2", "fp-a")))
+	assert.Error(t, repo.Create(ctx, samplePaymentMethod("pm_4", "cust-1", "fp-a")))
+
+	found, err := repo.FindByID(ctx, "pm_1")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, []byte("ciphertext"), found.EncryptedCard)
+
+	byFingerprint, err := repo.FindByFingerprint(ctx, "cust-2", "fp-a")
+	require.NoError(t, err)
+	require.NotNil(t, byFingerprint)
+	assert.Equal(t, "pm_3", byFingerprint.ID)
+
+	methods, err := repo.ListByCustomer(ctx, "cust-1")
+	require.NoError(t, err)
+	assert.Len(t, methods, 2)
+
+	require.NoError(t, repo.Delete(ctx, "pm_1"))
+	missing, err := repo.FindByID(ctx, "pm_1")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
@@ -3,10 +3,12 @@ package repositories
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
 	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PaymentRepo struct {
@@ -27,7 +29,11 @@ func (r *PaymentRepo) Create(ctx context.Context, payment *domain.Payment) error
 
 func (r *PaymentRepo) FindByID(ctx context.Context, id string) (*domain.Payment, error) {
 	var payment domain.Payment
-	err := r.conn(ctx).Where("id = ?", id).First(&payment).Error
+	err := r.conn(ctx).
+		Preload("Splits", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Preload("Tips", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC, id ASC") }).
+		Where("id = ?", id).
+		First(&payment).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
@@ -36,3 +42,195 @@ func (r *PaymentRepo) FindByID(ctx context.Context, id string) (*domain.Payment,
 	}
 	return &payment, nil
 }
+
+func (r *PaymentRepo) FindByIDForUpdate(ctx context.Context, id string) (*domain.Payment, error) {
+	var payment domain.Payment
+	err := r.conn(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Splits", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Where("id = ?", id).
+		First(&payment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+func (r *PaymentRepo) FindPendingDue(ctx context.Context, now time.Time, limit int) ([]*domain.Payment, error) {
+	return r.findDue(ctx, domain.PaymentStatusPending, now, limit)
+}
+
+func (r *PaymentRepo) FindUnknownDue(ctx context.Context, now time.Time, limit int) ([]*domain.Payment, error) {
+	return r.findDue(ctx, domain.PaymentStatusUnknown, now, limit)
+}
+
+func (r *PaymentRepo) findDue(ctx context.Context, status domain.PaymentStatus, now time.Time, limit int) ([]*domain.Payment, error) {
+	var payments []*domain.Payment
+	err := r.conn(ctx).
+		Where("status = ?", status).
+		Where("next_status_check_at IS NULL OR next_status_check_at <= ?", now).
+		Where("escalated_at IS NULL").
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&payments).Error
+	if err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+func (r *PaymentRepo) ScheduleStatusCheck(ctx context.Context, id string, status domain.PaymentStatus, checks int, next time.Time) (bool, error) {
+	result := r.conn(ctx).
+		Model(&domain.Payment{}).
+		Where("id = ? AND status = ?", id, status).
+		Updates(map[string]interface{}{
+			"status_checks":        checks,
+			"next_status_check_at": next,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *PaymentRepo) Escalate(ctx context.Context, id string, status domain.PaymentStatus, at time.Time) (bool, error) {
+	result := r.conn(ctx).
+		Model(&domain.Payment{}).
+		Where("id = ? AND status = ? AND escalated_at IS NULL", id, status).
+		Update("escalated_at", at)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *PaymentRepo) FindByProcessorReference(ctx context.Context, reference string) (*domain.Payment, error) {
+	var payment domain.Payment
+	err := r.conn(ctx).
+		Preload("Splits", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Where("processor_reference = ?", reference).
+		First(&payment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+func (r *PaymentRepo) FindByProcessorKey(ctx context.Context, key string) (*domain.Payment, error) {
+	var payment domain.Payment
+	err := r.conn(ctx).
+		Preload("Splits", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Where("processor_key = ?", key).
+		First(&payment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+func (r *PaymentRepo) FindRecentCharge(ctx context.Context, customerID, rideID string, amount domain.Money, since time.Time) (*domain.Payment, error) {
+	var payment domain.Payment
+	err := r.conn(ctx).
+		Preload("Splits", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Where("customer_id = ? AND ride_id = ?", customerID, rideID).
+		Where("amount = ? AND currency = ?", amount.Amount, amount.Currency).
+		Where("status IN ?", []domain.PaymentStatus{domain.PaymentStatusSucceeded, domain.PaymentStatusPending, domain.PaymentStatusUnknown}).
+		Where("created_at >= ?", since).
+		Order("created_at DESC").
+		First(&payment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+func (r *PaymentRepo) LockRide(ctx context.Context, customerID, rideID string) error {
+	conn := r.conn(ctx)
+	if conn.Dialector.Name() != "postgres" {
+		return nil
+	}
+	return conn.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", customerID+":"+rideID).Error
+}
+
+func (r *PaymentRepo) List(ctx context.Context, filter domain.PaymentFilter) ([]*domain.Payment, error) {
+	query := r.conn(ctx)
+	if filter.CustomerID != "" {
+		query = query.Where("customer_id = ?", filter.CustomerID)
+	}
+	if filter.RideID != "" {
+		query = query.Where("ride_id = ?", filter.RideID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Currency != "" {
+		query = query.Where("currency = ?", filter.Currency)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+	if filter.After != nil {
+		query = query.Where(
+			"created_at < ? OR (created_at = ? AND id < ?)",
+			filter.After.CreatedAt, filter.After.CreatedAt, filter.After.ID,
+		)
+	}
+
+	var payments []*domain.Payment
+	err := query.
+		Order("created_at DESC").
+		Order("id DESC").
+		Limit(filter.Limit).
+		Find(&payments).Error
+	if err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+func (r *PaymentRepo) TotalsByCurrency(ctx context.Context, customerID string, status domain.PaymentStatus) ([]*domain.CurrencyTotal, error) {
+	var totals []*domain.CurrencyTotal
+	err := r.conn(ctx).
+		Model(&domain.Payment{}).
+		Select("currency, SUM(amount) AS amount, COUNT(*) AS count").
+		Where("customer_id = ? AND status = ?", customerID, status).
+		Group("currency").
+		Order("currency ASC").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+func (r *PaymentRepo) TipTotal(ctx context.Context, parentPaymentID string) (int64, error) {
+	var total int64
+	err := r.conn(ctx).
+		Model(&domain.Payment{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("parent_payment_id = ? AND status NOT IN ?", parentPaymentID, []domain.PaymentStatus{domain.PaymentStatusFailed, domain.PaymentStatusBlocked}).
+		Scan(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *PaymentRepo) Update(ctx context.Context, payment *domain.Payment) error {
+	return r.conn(ctx).Omit(clause.Associations).Save(payment).Error
+}
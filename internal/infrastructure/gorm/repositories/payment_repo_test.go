@@ -25,7 +25,7 @@ func TestPaymentCreate_And_FindByID(t *testing.T) {
 
 	payment := &domain.Payment{
 		ID:          "pay-001",
-		Amount:      100.50,
+		Amount:      100,
 		Currency:    domain.CurrencyIDR,
 		CustomerID:  "cust-001",
 		RideID:      "ride-001",
@@ -70,7 +70,7 @@ func TestPaymentCreateInTransaction(t *testing.T) {
 
 	payment := &domain.Payment{
 		ID:         "pay-tx-001",
-		Amount:     250.00,
+		Amount:     250,
 		Currency:   domain.CurrencyTHB,
 		CustomerID: "cust-tx-001",
 		RideID:     "ride-tx-001",
@@ -99,7 +99,7 @@ func TestPaymentCreate_DuplicateID(t *testing.T) {
 
 	payment := &domain.Payment{
 		ID:         "pay-dup-001",
-		Amount:     50.00,
+		Amount:     50,
 		Currency:   domain.CurrencyVND,
 		CustomerID: "cust-dup",
 		RideID:     "ride-dup",
@@ -113,7 +113,7 @@ func TestPaymentCreate_DuplicateID(t *testing.T) {
 
 	duplicate := &domain.Payment{
 		ID:         "pay-dup-001",
-		Amount:     75.00,
+		Amount:     75,
 		Currency:   domain.CurrencyPHP,
 		CustomerID: "cust-dup-2",
 		RideID:     "ride-dup-2",
@@ -125,3 +125,286 @@ func TestPaymentCreate_DuplicateID(t *testing.T) {
 	err = repo.Create(ctx, duplicate)
 	assert.Error(t, err)
 }
+
+func TestPaymentFindPendingDue(t *testing.T) {
+	repo, _ := setupPaymentTest(t)
+	ctx := context.Background()
+	now := time.Now()
+	later := now.Add(time.Hour)
+
+	payments := []*domain.Payment{
+		{ID: "pay-pending-new", Amount: 10, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r", Status: domain.PaymentStatusPending, CreatedAt: now},
+		{ID: "pay-pending-later", Amount: 10, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r", Status: domain.PaymentStatusPending, CreatedAt: now, NextStatusCheckAt: &later},
+		{ID: "pay-succeeded", Amount: 10, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r", Status: domain.PaymentStatusSucceeded, CreatedAt: now},
+	}
+	for _, p := range payments {
+		require.NoError(t, repo.Create(ctx, p))
+	}
+
+	due, err := repo.FindPendingDue(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, "pay-pending-new", due[0].ID)
+}
+
+func TestPaymentScheduleStatusCheck(t *testing.T) {
+	repo, _ := setupPaymentTest(t)
+	ctx := context.Background()
+	now := time.Now()
+	next := now.Add(time.Minute)
+
+	pending := &domain.Payment{ID: "pay-check-pending", Amount: 10, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r", Status: domain.PaymentStatusPending, CreatedAt: now}
+	settled := &domain.Payment{ID: "pay-check-settled", Amount: 10, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r", Status: domain.PaymentStatusSucceeded, CreatedAt: now}
+	require.NoError(t, repo.Create(ctx, pending))
+	require.NoError(t, repo.Create(ctx, settled))
+
+	scheduled, err := repo.ScheduleStatusCheck(ctx, pending.ID, domain.PaymentStatusPending, 2, next)
+	require.NoError(t, err)
+	assert.True(t, scheduled)
+
+	found, err := repo.FindByID(ctx, pending.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, found.StatusChecks)
+	require.NotNil(t, found.NextStatusCheckAt)
+	assert.WithinDuration(t, next, *found.NextStatusCheckAt, time.Second)
+
+	scheduled, err = repo.ScheduleStatusCheck(ctx, settled.ID, domain.PaymentStatusPending, 1, next)
+	require.NoError(t, err)
+	assert.False(t, scheduled)
+
+	found, err = repo.FindByID(ctx, settled.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusSucceeded, found.Status)
+	assert.Zero(t, found.StatusChecks)
+	assert.Nil(t, found.NextStatusCheckAt)
+}
+
+func TestPaymentFindUnknownDue_And_FindByProcessorReference(t *testing.T) {
+	repo, _ := setupPaymentTest(t)
+	ctx := context.Background()
+	now := time.Now()
+	later := now.Add(time.Hour)
+
+	payments := []*domain.Payment{
+		{ID: "pay-unknown-new", Amount: 10, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r", Status: domain.PaymentStatusUnknown, CreatedAt: now},
+		{ID: "pay-unknown-later", Amount: 10, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r", Status: domain.PaymentStatusUnknown, CreatedAt: now, NextStatusCheckAt: &later},
+		{ID: "pay-unknown-escalated", Amount: 10, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r", Status: domain.PaymentStatusUnknown, CreatedAt: now},
+		{ID: "pay-pending", Amount: 10, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r", Status: domain.PaymentStatusPending, CreatedAt: now},
+		{ID: "pay-resolved", Amount: 10, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r", Status: domain.PaymentStatusSucceeded, CreatedAt: now, ProcessorReference: "proc-ref-1"},
+	}
+	for _, p := range payments {
+		require.NoError(t, repo.Create(ctx, p))
+	}
+
+	escalated, err := repo.Escalate(ctx, "pay-unknown-escalated", domain.PaymentStatusUnknown, now)
+	require.NoError(t, err)
+	assert.True(t, escalated)
+	escalated, err = repo.Escalate(ctx, "pay-unknown-escalated", domain.PaymentStatusUnknown, later)
+	require.NoError(t, err)
+	assert.False(t, escalated, "a payment is escalated once")
+	escalated, err = repo.Escalate(ctx, "pay-resolved", domain.PaymentStatusUnknown, now)
+	require.NoError(t, err)
+	assert.False(t, escalated)
+
+	due, err := repo.FindUnknownDue(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, "pay-unknown-new", due[0].ID)
+
+	found, err := repo.FindByProcessorReference(ctx, "proc-ref-1")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "pay-resolved", found.ID)
+
+	missing, err := repo.FindByProcessorReference(ctx, "proc-ref-2")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestPaymentUpdate(t *testing.T) {
+	repo, _ := setupPaymentTest(t)
+	ctx := context.Background()
+
+	payment := &domain.Payment{
+		ID:         "pay-update-001",
+		Amount:     10,
+		Currency:   domain.CurrencyIDR,
+		CustomerID: "cust-update",
+		RideID:     "ride-update",
+		Status:     domain.PaymentStatusPending,
+		CreatedAt:  time.Now(),
+	}
+	require.NoError(t, repo.Create(ctx, payment))
+
+	payment.Status = domain.PaymentStatusSucceeded
+	require.NoError(t, repo.Update(ctx, payment))
+
+	found, err := repo.FindByIDForUpdate(ctx, "pay-update-001")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, domain.PaymentStatusSucceeded, found.Status)
+}
+
+func TestPaymentList_FiltersAndCursor(t *testing.T) {
+	repo, _ := setupPaymentTest(t)
+	ctx := context.Background()
+	base := time.Now().Add(-time.Hour)
+
+	for i, id := range []string{"pay-a", "pay-b", "pay-c"} {
+		require.NoError(t, repo.Create(ctx, &domain.Payment{
+			ID:         id,
+			Amount:     100,
+			Currency:   domain.CurrencyIDR,
+			CustomerID: "cust-001",
+			RideID:     "ride-" + id,
+			Status:     domain.PaymentStatusSucceeded,
+			CreatedAt:  base.Add(time.Duration(i) * time.Minute),
+		}))
+	}
+	require.NoError(t, repo.Create(ctx, &domain.Payment{
+		ID:         "pay-other",
+		Amount:     100,
+		Currency:   domain.CurrencyIDR,
+		CustomerID: "cust-002",
+		RideID:     "ride-other",
+		Status:     domain.PaymentStatusFailed,
+		CreatedAt:  base,
+	}))
+
+	first, err := repo.List(ctx, domain.PaymentFilter{CustomerID: "cust-001", Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+	assert.Equal(t, "pay-c", first[0].ID)
+	assert.Equal(t, "pay-b", first[1].ID)
+
+	rest, err := repo.List(ctx, domain.PaymentFilter{
+		CustomerID: "cust-001",
+		After:      &domain.PaymentCursor{CreatedAt: first[1].CreatedAt, ID: first[1].ID},
+		Limit:      2,
+	})
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	assert.Equal(t, "pay-a", rest[0].ID)
+
+	failed, err := repo.List(ctx, domain.PaymentFilter{Status: domain.PaymentStatusFailed, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+	assert.Equal(t, "pay-other", failed[0].ID)
+}
+
+func TestPaymentFindRecentCharge(t *testing.T) {
+	repo, _ := setupPaymentTest(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	payments := []*domain.Payment{
+		{ID: "pay-old", Amount: 100, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r", Status: domain.PaymentStatusSucceeded, CreatedAt: now.Add(-time.Hour)},
+		{ID: "pay-failed", Amount: 100, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r", Status: domain.PaymentStatusFailed, CreatedAt: now},
+		{ID: "pay-recent", Amount: 100, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r", Status: domain.PaymentStatusSucceeded, CreatedAt: now},
+		{ID: "pay-blocked", Amount: 300, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r", Status: domain.PaymentStatusBlocked, CreatedAt: now},
+		{ID: "pay-unknown", Amount: 300, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r", Status: domain.PaymentStatusUnknown, CreatedAt: now.Add(-time.Minute)},
+	}
+	for _, p := range payments {
+		require.NoError(t, repo.Create(ctx, p))
+	}
+
+	found, err := repo.FindRecentCharge(ctx, "c", "r", domain.NewMoney(100, domain.CurrencyIDR), now.Add(-10*time.Minute))
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "pay-recent", found.ID)
+
+	none, err := repo.FindRecentCharge(ctx, "c", "r", domain.NewMoney(200, domain.CurrencyIDR), now.Add(-10*time.Minute))
+	require.NoError(t, err)
+	assert.Nil(t, none)
+
+	inFlight, err := repo.FindRecentCharge(ctx, "c", "r", domain.NewMoney(300, domain.CurrencyIDR), now.Add(-10*time.Minute))
+	require.NoError(t, err)
+	require.NotNil(t, inFlight)
+	assert.Equal(t, "pay-unknown", inFlight.ID)
+	assert.NoError(t, repo.LockRide(ctx, "c", "r"))
+}
+
+func TestPaymentTotalsByCurrency(t *testing.T) {
+	repo, _ := setupPaymentTest(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	payments := []*domain.Payment{
+		{ID: "pay-1", Amount: 100, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r1", Status: domain.PaymentStatusSucceeded, CreatedAt: now},
+		{ID: "pay-2", Amount: 250, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r2", Status: domain.PaymentStatusSucceeded, CreatedAt: now},
+		{ID: "pay-3", Amount: 1050, Currency: domain.CurrencyTHB, CustomerID: "c", RideID: "r3", Status: domain.PaymentStatusSucceeded, CreatedAt: now},
+		{ID: "pay-4", Amount: 999, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r4", Status: domain.PaymentStatusFailed, CreatedAt: now},
+		{ID: "pay-5", Amount: 999, Currency: domain.CurrencyIDR, CustomerID: "other", RideID: "r5", Status: domain.PaymentStatusSucceeded, CreatedAt: now},
+	}
+	for _, p := range payments {
+		require.NoError(t, repo.Create(ctx, p))
+	}
+
+	totals, err := repo.TotalsByCurrency(ctx, "c", domain.PaymentStatusSucceeded)
+	require.NoError(t, err)
+	require.Len(t, totals, 2)
+	assert.Equal(t, domain.CurrencyIDR, totals[0].Currency)
+	assert.Equal(t, int64(350), totals[0].Amount)
+	assert.Equal(t, int64(2), totals[0].Count)
+	assert.Equal(t, domain.CurrencyTHB, totals[1].Currency)
+	assert.Equal(t, int64(1050), totals[1].Amount)
+}
+
+func TestPaymentCreate_WithSplits(t *testing.T) {
+	repo, _ := setupPaymentTest(t)
+	ctx := context.Background()
+
+	payment := &domain.Payment{
+		ID: "pay-split", Amount: 1000, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r",
+		Status: domain.PaymentStatusSucceeded, CreatedAt: time.Now(),
+		Splits: []*domain.PaymentSplit{
+			{Position: 0, Recipient: "driver", Amount: 800, Currency: domain.CurrencyIDR, Percentage: "80"},
+			{Position: 1, Recipient: "platform", Amount: 200, Currency: domain.CurrencyIDR},
+		},
+	}
+	require.NoError(t, repo.Create(ctx, payment))
+
+	found, err := repo.FindByID(ctx, "pay-split")
+	require.NoError(t, err)
+	require.Len(t, found.Splits, 2)
+	assert.Equal(t, "driver", found.Splits[0].Recipient)
+	assert.Equal(t, int64(800), found.Splits[0].Amount)
+	assert.Equal(t, "platform", found.Splits[1].Recipient)
+
+	found.Status = domain.PaymentStatusFailed
+	require.NoError(t, repo.Update(ctx, found))
+
+	updated, err := repo.FindByID(ctx, "pay-split")
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusFailed, updated.Status)
+	assert.Len(t, updated.Splits, 2)
+}
+
+func TestPaymentTipTotal_AndTipsPreloaded(t *testing.T) {
+	repo, _ := setupPaymentTest(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	payments := []*domain.Payment{
+		{ID: "parent", Amount: 1000, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r", Status: domain.PaymentStatusSucceeded, CreatedAt: now},
+		{ID: "tip-1", ParentPaymentID: "parent", Amount: 100, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r", Status: domain.PaymentStatusSucceeded, CreatedAt: now.Add(time.Second)},
+		{ID: "tip-2", ParentPaymentID: "parent", Amount: 50, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r", Status: domain.PaymentStatusPending, CreatedAt: now.Add(2 * time.Second)},
+		{ID: "tip-3", ParentPaymentID: "parent", Amount: 500, Currency: domain.CurrencyIDR, CustomerID: "c", RideID: "r", Status: domain.PaymentStatusFailed, CreatedAt: now.Add(3 * time.Second)},
+	}
+	for _, p := range payments {
+		require.NoError(t, repo.Create(ctx, p))
+	}
+
+	total, err := repo.TipTotal(ctx, "parent")
+	require.NoError(t, err)
+	assert.Equal(t, int64(150), total)
+
+	none, err := repo.TipTotal(ctx, "tip-1")
+	require.NoError(t, err)
+	assert.Zero(t, none)
+
+	parent, err := repo.FindByID(ctx, "parent")
+	require.NoError(t, err)
+	require.Len(t, parent.Tips, 3)
+	assert.Equal(t, "tip-1", parent.Tips[0].ID)
+}
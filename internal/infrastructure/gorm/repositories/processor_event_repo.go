@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProcessorEventRepo struct {
+	db *gorm.DB
+}
+
+func NewProcessorEventRepo(db *gorm.DB) domain.ProcessorEventRepository {
+	return &ProcessorEventRepo{db: db}
+}
+
+func (r *ProcessorEventRepo) conn(ctx context.Context) *gorm.DB {
+	return gormdb.ExtractTx(ctx, r.db).WithContext(ctx)
+}
+
+func (r *ProcessorEventRepo) FindByEventID(ctx context.Context, eventID string) (*domain.ProcessorEvent, error) {
+	var event domain.ProcessorEvent
+	err := r.conn(ctx).Where("event_id = ?", eventID).First(&event).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *ProcessorEventRepo) CreateIfAbsent(ctx context.Context, event *domain.ProcessorEvent) (bool, error) {
+	result := r.conn(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "event_id"}}, DoNothing: true}).
+		Create(event)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *ProcessorEventRepo) Update(ctx context.Context, event *domain.ProcessorEvent) error {
+	return r.conn(ctx).Save(event).Error
+}
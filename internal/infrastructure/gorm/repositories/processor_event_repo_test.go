@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupProcessorEventTest(t *testing.T) *ProcessorEventRepo {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+	return &ProcessorEventRepo{db: db}
+}
+
+func TestProcessorEventCreateIfAbsent_DeduplicatesByEventID(t *testing.T) {
+	repo := setupProcessorEventTest(t)
+	ctx := context.Background()
+
+	first := &domain.ProcessorEvent{
+		EventID:   "evt-001",
+		PaymentID: "pay-001",
+		Status:    domain.PaymentStatusSucceeded,
+		Outcome:   domain.ProcessorEventOutcomeApplied,
+	}
+	created, err := repo.CreateIfAbsent(ctx, first)
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	duplicate := &domain.ProcessorEvent{
+		EventID:   "evt-001",
+		PaymentID: "pay-001",
+		Status:    domain.PaymentStatusFailed,
+		Outcome:   domain.ProcessorEventOutcomeIgnored,
+	}
+	created, err = repo.CreateIfAbsent(ctx, duplicate)
+	require.NoError(t, err)
+	assert.False(t, created)
+
+	found, err := repo.FindByEventID(ctx, "evt-001")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, domain.PaymentStatusSucceeded, found.Status)
+	assert.Equal(t, domain.ProcessorEventOutcomeApplied, found.Outcome)
+}
+
+func TestProcessorEventFindByEventID_NotFound(t *testing.T) {
+	repo := setupProcessorEventTest(t)
+
+	found, err := repo.FindByEventID(context.Background(), "missing")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
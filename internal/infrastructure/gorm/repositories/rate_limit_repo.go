@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RateLimitRepo struct {
+	db *gorm.DB
+}
+
+func NewRateLimitRepo(db *gorm.DB) domain.RateLimitStore {
+	return &RateLimitRepo{db: db}
+}
+
+func (r *RateLimitRepo) conn(ctx context.Context) *gorm.DB {
+	return gormdb.ExtractTx(ctx, r.db).WithContext(ctx)
+}
+
+func (r *RateLimitRepo) Take(ctx context.Context, key string, limit domain.RateLimit, now time.Time) (domain.RateLimitDecision, error) {
+	var decision domain.RateLimitDecision
+	err := r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		seed := &domain.TokenBucket{Key: key, Tokens: float64(limit.Limit), RefilledAt: now}
+		err := tx.
+			Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "key"}}, DoNothing: true}).
+			Create(seed).Error
+		if err != nil {
+			return err
+		}
+
+		var bucket domain.TokenBucket
+		err = tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("key = ?", key).
+			First(&bucket).Error
+		if err != nil {
+			return err
+		}
+
+		decision = bucket.Take(limit, now)
+		return tx.Model(&bucket).
+			Where("key = ?", key).
+			Updates(map[string]interface{}{"tokens": bucket.Tokens, "refilled_at": bucket.RefilledAt}).Error
+	})
+	return decision, err
+}
+
+func (r *RateLimitRepo) Release(ctx context.Context, key string, limit domain.RateLimit) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		var bucket domain.TokenBucket
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("key = ?", key).
+			First(&bucket).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		bucket.Release(limit)
+		return tx.Model(&bucket).Where("key = ?", key).Update("tokens", bucket.Tokens).Error
+	})
+}
+
+func (r *RateLimitRepo) DeleteIdle(ctx context.Context, before time.Time) (int64, error) {
+	result := r.conn(ctx).Where("refilled_at < ?", before).Delete(&domain.TokenBucket{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitRepo_TakeAndDeleteIdle(t *testing.T) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+	repo := NewRateLimitRepo(db)
+	ctx := context.Background()
+	limit := domain.RateLimit{Limit: 2, Period: time.Minute}
+	now := time.Now()
+
+	first, err := repo.Take(ctx, "ip:10.0.0.1", limit, now)
+	require.NoError(t, err)
+	assert.True(t, first.Allowed)
+	assert.Equal(t, 1, first.Remaining)
+
+	second, err := repo.Take(ctx, "ip:10.0.0.1", limit, now)
+	require.NoError(t, err)
+	assert.True(t, second.Allowed)
+
+	third, err := repo.Take(ctx, "ip:10.0.0.1", limit, now)
+	require.NoError(t, err)
+	assert.False(t, third.Allowed)
+	assert.Equal(t, 30*time.Second, third.RetryAfter)
+
+	other, err := repo.Take(ctx, "ip:10.0.0.2", limit, now.Add(-2*time.Minute))
+	require.NoError(t, err)
+	assert.True(t, other.Allowed)
+
+	deleted, err := repo.DeleteIdle(ctx, now.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	afterCleanup, err := repo.Take(ctx, "ip:10.0.0.2", limit, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, afterCleanup.Remaining)
+}
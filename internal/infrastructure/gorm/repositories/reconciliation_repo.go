@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const reconciliationItemBatchSize = 500
+
+type ReconciliationRepo struct {
+	db *gorm.DB
+}
+
+func NewReconciliationRepo(db *gorm.DB) domain.ReconciliationRepository {
+	return &ReconciliationRepo{db: db}
+}
+
+func (r *ReconciliationRepo) conn(ctx context.Context) *gorm.DB {
+	return gormdb.ExtractTx(ctx, r.db).WithContext(ctx)
+}
+
+func (r *ReconciliationRepo) Create(ctx context.Context, reconciliation *domain.Reconciliation) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(reconciliation).Error; err != nil {
+			return err
+		}
+		if len(reconciliation.Items) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(reconciliation.Items, reconciliationItemBatchSize).Error
+	})
+}
+
+func (r *ReconciliationRepo) FindByID(ctx context.Context, id string) (*domain.Reconciliation, error) {
+	var reconciliation domain.Reconciliation
+	err := r.conn(ctx).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Where("id = ?", id).
+		First(&reconciliation).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &reconciliation, nil
+}
@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconciliationRepo_CreateAndFind(t *testing.T) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+	repo := NewReconciliationRepo(db)
+	ctx := context.Background()
+
+	reconciliation := &domain.Reconciliation{ID: "rec-1", SettlementDate: "2026-02-24", FileSHA256: "abc", CreatedAt: time.Now()}
+	for i := 0; i < reconciliationItemBatchSize+5; i++ {
+		reconciliation.Add(&domain.ReconciliationItem{
+			Outcome:            domain.ReconciliationMatched,
+			ProcessorReference: fmt.Sprintf("pay-%d", i),
+			PaymentID:          fmt.Sprintf("pay-%d", i),
+		})
+	}
+	reconciliation.Add(&domain.ReconciliationItem{Outcome: domain.ReconciliationMissingInTheirs, PaymentID: "pay-x"})
+	require.NoError(t, repo.Create(ctx, reconciliation))
+
+	found, err := repo.FindByID(ctx, "rec-1")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, reconciliationItemBatchSize+5, found.Matched)
+	assert.Equal(t, 1, found.MissingInTheirs)
+	require.Len(t, found.Items, reconciliationItemBatchSize+6)
+	assert.Equal(t, "pay-0", found.Items[0].PaymentID)
+	assert.Equal(t, domain.ReconciliationMissingInTheirs, found.Items[len(found.Items)-1].Outcome)
+
+	missing, err := repo.FindByID(ctx, "rec-2")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"gorm.io/gorm"
+)
+
+type RefundRepo struct {
+	db *gorm.DB
+}
+
+func NewRefundRepo(db *gorm.DB) domain.RefundRepository {
+	return &RefundRepo{db: db}
+}
+
+func (r *RefundRepo) conn(ctx context.Context) *gorm.DB {
+	return gormdb.ExtractTx(ctx, r.db).WithContext(ctx)
+}
+
+func (r *RefundRepo) Create(ctx context.Context, refund *domain.Refund) error {
+	return r.conn(ctx).Create(refund).Error
+}
+
+func (r *RefundRepo) FindByKey(ctx context.Context, key string) (*domain.Refund, error) {
+	var refund domain.Refund
+	err := r.conn(ctx).Where("idempotency_key = ?", key).First(&refund).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
+
+func (r *RefundRepo) TotalByPayment(ctx context.Context, paymentID string) (int64, error) {
+	var total int64
+	err := r.conn(ctx).
+		Model(&domain.Refund{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("payment_id = ?", paymentID).
+		Scan(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
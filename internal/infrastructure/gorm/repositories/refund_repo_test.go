@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefundRepo_FindByKeyAndTotal(t *testing.T) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+	repo := NewRefundRepo(db)
+	ctx := context.Background()
+
+	refunds := []*domain.Refund{
+		{ID: "rf-1", IdempotencyKey: "refund-key-1", RequestFingerprint: "fp", PaymentID: "pay-1", Amount: 300, Currency: domain.CurrencyIDR, CreatedAt: time.Now()},
+		{ID: "rf-2", IdempotencyKey: "refund-key-2", RequestFingerprint: "fp", PaymentID: "pay-1", Amount: 200, Currency: domain.CurrencyIDR, CreatedAt: time.Now()},
+		{ID: "rf-3", IdempotencyKey: "refund-key-3", RequestFingerprint: "fp", PaymentID: "pay-2", Amount: 50, Currency: domain.CurrencyIDR, CreatedAt: time.Now()},
+	}
+	for _, refund := range refunds {
+		require.NoError(t, repo.Create(ctx, refund))
+	}
+
+	found, err := repo.FindByKey(ctx, "refund-key-2")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "rf-2", found.ID)
+
+	missing, err := repo.FindByKey(ctx, "refund-key-9")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	total, err := repo.TotalByPayment(ctx, "pay-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), total)
+
+	none, err := repo.TotalByPayment(ctx, "pay-9")
+	require.NoError(t, err)
+	assert.Zero(t, none)
+
+	duplicate := *refunds[0]
+	duplicate.ID = "rf-4"
+	assert.Error(t, repo.Create(ctx, &duplicate))
+}
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"gorm.io/gorm"
+)
+
+type RiskAssessmentRepo struct {
+	db *gorm.DB
+}
+
+func NewRiskAssessmentRepo(db *gorm.DB) domain.RiskAssessmentRepository {
+	return &RiskAssessmentRepo{db: db}
+}
+
+func (r *RiskAssessmentRepo) conn(ctx context.Context) *gorm.DB {
+	return gormdb.ExtractTx(ctx, r.db).WithContext(ctx)
+}
+
+func (r *RiskAssessmentRepo) Create(ctx context.Context, assessment *domain.RiskAssessment) error {
+	return r.conn(ctx).Create(assessment).Error
+}
+
+func (r *RiskAssessmentRepo) FindByPaymentID(ctx context.Context, paymentID string) (*domain.RiskAssessment, error) {
+	var assessment domain.RiskAssessment
+	err := r.conn(ctx).Where("payment_id = ?", paymentID).First(&assessment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &assessment, nil
+}
+
+func (r *RiskAssessmentRepo) CountSince(ctx context.Context, filter domain.RiskVelocityFilter) (int64, error) {
+	query := r.conn(ctx).Model(&domain.RiskAssessment{}).Where("created_at >= ?", filter.Since)
+	if filter.CustomerID != "" {
+		query = query.Where("customer_id = ?", filter.CustomerID)
+	}
+	if filter.CardFingerprint != "" {
+		query = query.Where("card_fingerprint = ?", filter.CardFingerprint)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRiskAssessmentRepo_CreateFindAndCount(t *testing.T) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+	repo := NewRiskAssessmentRepo(db)
+	ctx := context.Background()
+	now := time.Now()
+
+	assessments := []*domain.RiskAssessment{
+		{ID: "ra-1", PaymentID: "pay-1", CustomerID: "cust-1", CardFingerprint: "card-a", Amount: 100, Currency: domain.CurrencyIDR, Decision: domain.RiskDecisionAllow, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "ra-2", PaymentID: "pay-2", CustomerID: "cust-1", CardFingerprint: "card-a", Amount: 100, Currency: domain.CurrencyIDR, Decision: domain.RiskDecisionAllow, CreatedAt: now.Add(-10 * time.Minute)},
+		{ID: "ra-3", PaymentID: "pay-3", CustomerID: "cust-2", CardFingerprint: "card-a", Amount: 100, Currency: domain.CurrencyIDR, Decision: domain.RiskDecisionDeny, CreatedAt: now},
+	}
+	assessments[2].Add(domain.RiskTrigger{Rule: domain.RiskRuleCardVelocity, Decision: domain.RiskDecisionDeny, Reason: "limit reached"})
+	for _, assessment := range assessments {
+		require.NoError(t, repo.Create(ctx, assessment))
+	}
+
+	found, err := repo.FindByPaymentID(ctx, "pay-3")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, domain.RiskDecisionDeny, found.Decision)
+	assert.Equal(t, assessments[2].Triggers, found.Triggers)
+
+	missing, err := repo.FindByPaymentID(ctx, "pay-9")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	since := now.Add(-time.Hour)
+	customerCount, err := repo.CountSince(ctx, domain.RiskVelocityFilter{CustomerID: "cust-1", Since: since})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), customerCount)
+
+	cardCount, err := repo.CountSince(ctx, domain.RiskVelocityFilter{CardFingerprint: "card-a", Since: since})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), cardCount)
+}
@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"gorm.io/gorm"
+)
+
+type ScheduledPaymentRepo struct {
+	db *gorm.DB
+}
+
+func NewScheduledPaymentRepo(db *gorm.DB) domain.ScheduledPaymentRepository {
+	return &ScheduledPaymentRepo{db: db}
+}
+
+func (r *ScheduledPaymentRepo) conn(ctx context.Context) *gorm.DB {
+	return gormdb.ExtractTx(ctx, r.db).WithContext(ctx)
+}
+
+func (r *ScheduledPaymentRepo) Create(ctx context.Context, scheduled *domain.ScheduledPayment) error {
+	return r.conn(ctx).Create(scheduled).Error
+}
+
+func (r *ScheduledPaymentRepo) FindByID(ctx context.Context, id string) (*domain.ScheduledPayment, error) {
+	return r.findOne(ctx, "id = ?", id)
+}
+
+func (r *ScheduledPaymentRepo) FindByKey(ctx context.Context, key string) (*domain.ScheduledPayment, error) {
+	return r.findOne(ctx, "idempotency_key = ?", key)
+}
+
+func (r *ScheduledPaymentRepo) findOne(ctx context.Context, query string, arg string) (*domain.ScheduledPayment, error) {
+	var scheduled domain.ScheduledPayment
+	err := r.conn(ctx).Where(query, arg).First(&scheduled).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &scheduled, nil
+}
+
+func (r *ScheduledPaymentRepo) List(ctx context.Context, filter domain.ScheduledPaymentFilter) ([]*domain.ScheduledPayment, error) {
+	query := r.conn(ctx)
+	if filter.CustomerID != "" {
+		query = query.Where("customer_id = ?", filter.CustomerID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var scheduled []*domain.ScheduledPayment
+	err := query.
+		Order("scheduled_at ASC, id ASC").
+		Limit(filter.Limit).
+		Find(&scheduled).Error
+	if err != nil {
+		return nil, err
+	}
+	return scheduled, nil
+}
+
+func (r *ScheduledPaymentRepo) ClaimDue(ctx context.Context, now, staleBefore time.Time, limit int) ([]*domain.ScheduledPayment, error) {
+	var candidates []*domain.ScheduledPayment
+	err := r.conn(ctx).
+		Where("(status = ? AND scheduled_at <= ?) OR (status = ? AND updated_at < ?)",
+			domain.ScheduledPaymentScheduled, now, domain.ScheduledPaymentExecuting, staleBefore).
+		Order("scheduled_at ASC").
+		Limit(limit).
+		Find(&candidates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := make([]*domain.ScheduledPayment, 0, len(candidates))
+	for _, scheduled := range candidates {
+		result := r.conn(ctx).
+			Model(&domain.ScheduledPayment{}).
+			Where("id = ? AND status = ? AND updated_at = ?", scheduled.ID, scheduled.Status, scheduled.UpdatedAt).
+			Updates(map[string]interface{}{"status": domain.ScheduledPaymentExecuting, "updated_at": now})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+		scheduled.Status = domain.ScheduledPaymentExecuting
+		scheduled.UpdatedAt = now
+		claimed = append(claimed, scheduled)
+	}
+	return claimed, nil
+}
+
+func (r *ScheduledPaymentRepo) Cancel(ctx context.Context, id string, now time.Time) (bool, error) {
+	result := r.conn(ctx).
+		Model(&domain.ScheduledPayment{}).
+		Where("id = ? AND status = ?", id, domain.ScheduledPaymentScheduled).
+		Updates(map[string]interface{}{
+			"status":      domain.ScheduledPaymentCanceled,
+			"canceled_at": now,
+			"updated_at":  now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *ScheduledPaymentRepo) Update(ctx context.Context, scheduled *domain.ScheduledPayment) error {
+	return r.conn(ctx).Save(scheduled).Error
+}
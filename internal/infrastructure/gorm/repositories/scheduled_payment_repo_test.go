@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestScheduledPayment(id string, scheduledAt time.Time) *domain.ScheduledPayment {
+	now := time.Now()
+	return &domain.ScheduledPayment{
+		ID:                 id,
+		IdempotencyKey:     "key-" + id,
+		RequestFingerprint: "fp",
+		CustomerID:         "cust-001",
+		Status:             domain.ScheduledPaymentScheduled,
+		ScheduledAt:        scheduledAt,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+}
+
+func TestScheduledPaymentRepo_ClaimDue(t *testing.T) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+	repo := NewScheduledPaymentRepo(db)
+	ctx := context.Background()
+	now := time.Now()
+
+	require.NoError(t, repo.Create(ctx, newTestScheduledPayment("sp-due", now.Add(-time.Minute))))
+	require.NoError(t, repo.Create(ctx, newTestScheduledPayment("sp-later", now.Add(time.Hour))))
+
+	claimed, err := repo.ClaimDue(ctx, now, now.Add(-5*time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, "sp-due", claimed[0].ID)
+	assert.Equal(t, domain.ScheduledPaymentExecuting, claimed[0].Status)
+
+	again, err := repo.ClaimDue(ctx, now, now.Add(-5*time.Minute), 10)
+	require.NoError(t, err)
+	assert.Empty(t, again)
+
+	stale, err := repo.ClaimDue(ctx, now, now.Add(time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	assert.Equal(t, "sp-due", stale[0].ID)
+}
+
+func TestScheduledPaymentRepo_Cancel(t *testing.T) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+	repo := NewScheduledPaymentRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Create(ctx, newTestScheduledPayment("sp-cancel", time.Now().Add(time.Hour))))
+
+	canceled, err := repo.Cancel(ctx, "sp-cancel", time.Now())
+	require.NoError(t, err)
+	assert.True(t, canceled)
+
+	canceled, err = repo.Cancel(ctx, "sp-cancel", time.Now())
+	require.NoError(t, err)
+	assert.False(t, canceled)
+
+	found, err := repo.FindByKey(ctx, "key-sp-cancel")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, domain.ScheduledPaymentCanceled, found.Status)
+	assert.NotNil(t, found.CanceledAt)
+}
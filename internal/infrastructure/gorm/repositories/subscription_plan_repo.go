@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"gorm.io/gorm"
+)
+
+type SubscriptionPlanRepo struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionPlanRepo(db *gorm.DB) domain.SubscriptionPlanRepository {
+	return &SubscriptionPlanRepo{db: db}
+}
+
+func (r *SubscriptionPlanRepo) conn(ctx context.Context) *gorm.DB {
+	return gormdb.ExtractTx(ctx, r.db).WithContext(ctx)
+}
+
+func (r *SubscriptionPlanRepo) Create(ctx context.Context, plan *domain.SubscriptionPlan) error {
+	return r.conn(ctx).Create(plan).Error
+}
+
+func (r *SubscriptionPlanRepo) FindByID(ctx context.Context, id string) (*domain.SubscriptionPlan, error) {
+	var plan domain.SubscriptionPlan
+	err := r.conn(ctx).Where("id = ?", id).First(&plan).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+func (r *SubscriptionPlanRepo) List(ctx context.Context, limit int) ([]*domain.SubscriptionPlan, error) {
+	var plans []*domain.SubscriptionPlan
+	err := r.conn(ctx).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&plans).Error
+	if err != nil {
+		return nil, err
+	}
+	return plans, nil
+}
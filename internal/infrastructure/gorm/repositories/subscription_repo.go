@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var liveSubscriptionStatuses = []domain.SubscriptionStatus{
+	domain.SubscriptionActive, domain.SubscriptionPastDue, domain.SubscriptionPaused,
+}
+
+type SubscriptionRepo struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionRepo(db *gorm.DB) domain.SubscriptionRepository {
+	return &SubscriptionRepo{db: db}
+}
+
+func (r *SubscriptionRepo) conn(ctx context.Context) *gorm.DB {
+	return gormdb.ExtractTx(ctx, r.db).WithContext(ctx)
+}
+
+func (r *SubscriptionRepo) Create(ctx context.Context, sub *domain.Subscription) error {
+	return r.conn(ctx).Create(sub).Error
+}
+
+func (r *SubscriptionRepo) FindByID(ctx context.Context, id string) (*domain.Subscription, error) {
+	return r.first(r.conn(ctx).Where("id = ?", id))
+}
+
+func (r *SubscriptionRepo) FindByIDForUpdate(ctx context.Context, id string) (*domain.Subscription, error) {
+	return r.first(r.conn(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id))
+}
+
+func (r *SubscriptionRepo) FindLive(ctx context.Context, customerID, planID string) (*domain.Subscription, error) {
+	return r.first(r.conn(ctx).
+		Where("customer_id = ? AND plan_id = ? AND status IN ?", customerID, planID, liveSubscriptionStatuses))
+}
+
+func (r *SubscriptionRepo) first(query *gorm.DB) (*domain.Subscription, error) {
+	var sub domain.Subscription
+	err := query.First(&sub).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+func (r *SubscriptionRepo) List(ctx context.Context, filter domain.SubscriptionFilter) ([]*domain.Subscription, error) {
+	query := r.conn(ctx)
+	if filter.CustomerID != "" {
+		query = query.Where("customer_id = ?", filter.CustomerID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var subs []*domain.Subscription
+	err := query.
+		Order("created_at DESC, id DESC").
+		Limit(filter.Limit).
+		Find(&subs).Error
+	if err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+func (r *SubscriptionRepo) ClaimDue(ctx context.Context, now, lockedUntil time.Time, limit int) ([]*domain.Subscription, error) {
+	due := func(db *gorm.DB) *gorm.DB {
+		return db.Where("status IN ? AND next_billing_at <= ? AND (locked_until IS NULL OR locked_until < ?)",
+			[]domain.SubscriptionStatus{domain.SubscriptionActive, domain.SubscriptionPastDue}, now, now)
+	}
+
+	var candidates []*domain.Subscription
+	err := r.conn(ctx).
+		Scopes(due).
+		Order("next_billing_at ASC").
+		Limit(limit).
+		Find(&candidates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := make([]*domain.Subscription, 0, len(candidates))
+	for _, sub := range candidates {
+		result := r.conn(ctx).
+			Model(&domain.Subscription{}).
+			Scopes(due).
+			Where("id = ?", sub.ID).
+			Update("locked_until", lockedUntil)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+		sub.LockedUntil = &lockedUntil
+		claimed = append(claimed, sub)
+	}
+	return claimed, nil
+}
+
+func (r *SubscriptionRepo) Update(ctx context.Context, sub *domain.Subscription) error {
+	return r.conn(ctx).Save(sub).Error
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSubscription(id string, start time.Time) *domain.Subscription {
+	plan := &domain.SubscriptionPlan{ID: "plan-1", Interval: domain.BillingIntervalMonth, IntervalCount: 1}
+	req := domain.SubscriptionRequest{CustomerID: "cust-001", PaymentMethodID: "pm_1"}
+	return domain.NewSubscription(id, req, plan, start, time.Now())
+}
+
+func TestSubscriptionRepo_ClaimDue(t *testing.T) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+	repo := NewSubscriptionRepo(db)
+	ctx := context.Background()
+	now := time.Now()
+
+	require.NoError(t, repo.Create(ctx, newTestSubscription("sub-due", now.Add(-time.Minute))))
+	require.NoError(t, repo.Create(ctx, newTestSubscription("sub-later", now.Add(time.Hour))))
+
+	claimed, err := repo.ClaimDue(ctx, now, now.Add(5*time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, "sub-due", claimed[0].ID)
+	require.NotNil(t, claimed[0].LockedUntil)
+
+	again, err := repo.ClaimDue(ctx, now, now.Add(5*time.Minute), 10)
+	require.NoError(t, err)
+	assert.Empty(t, again)
+
+	expired, err := repo.ClaimDue(ctx, now.Add(6*time.Minute), now.Add(11*time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, "sub-due", expired[0].ID)
+}
+
+func TestSubscriptionRepo_FindLive(t *testing.T) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+	repo := NewSubscriptionRepo(db)
+	ctx := context.Background()
+
+	sub := newTestSubscription("sub-1", time.Now())
+	require.NoError(t, repo.Create(ctx, sub))
+
+	found, err := repo.FindLive(ctx, "cust-001", "plan-1")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "sub-1", found.ID)
+
+	require.NoError(t, sub.Apply(domain.SubscriptionActionCancel, nil, time.Now()))
+	require.NoError(t, repo.Update(ctx, sub))
+
+	found, err = repo.FindLive(ctx, "cust-001", "plan-1")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
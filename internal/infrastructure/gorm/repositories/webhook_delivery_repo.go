@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"gorm.io/gorm"
+)
+
+type WebhookDeliveryRepo struct {
+	db *gorm.DB
+}
+
+func NewWebhookDeliveryRepo(db *gorm.DB) domain.WebhookDeliveryRepository {
+	return &WebhookDeliveryRepo{db: db}
+}
+
+func (r *WebhookDeliveryRepo) conn(ctx context.Context) *gorm.DB {
+	return gormdb.ExtractTx(ctx, r.db).WithContext(ctx)
+}
+
+func (r *WebhookDeliveryRepo) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return r.conn(ctx).Create(delivery).Error
+}
+
+func (r *WebhookDeliveryRepo) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	var candidates []*domain.WebhookDelivery
+	err := r.conn(ctx).
+		Where("status = ? AND next_attempt_at <= ?", domain.WebhookDeliveryStatusPending, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&candidates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := make([]*domain.WebhookDelivery, 0, len(candidates))
+	for _, delivery := range candidates {
+		result := r.conn(ctx).
+			Model(&domain.WebhookDelivery{}).
+			Where("id = ? AND status = ? AND next_attempt_at = ?", delivery.ID, delivery.Status, delivery.NextAttemptAt).
+			Update("next_attempt_at", leaseUntil)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+		delivery.NextAttemptAt = leaseUntil
+		claimed = append(claimed, delivery)
+	}
+	return claimed, nil
+}
+
+func (r *WebhookDeliveryRepo) FindByEventID(ctx context.Context, eventID string) ([]*domain.WebhookDelivery, error) {
+	var deliveries []*domain.WebhookDelivery
+	err := r.conn(ctx).Where("event_id = ?", eventID).Order("created_at ASC").Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookDeliveryRepo) FindByStatus(ctx context.Context, status domain.WebhookDeliveryStatus, limit int) ([]*domain.WebhookDelivery, error) {
+	var deliveries []*domain.WebhookDelivery
+	err := r.conn(ctx).
+		Where("status = ?", status).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookDeliveryRepo) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return r.conn(ctx).Save(delivery).Error
+}
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"gorm.io/gorm"
+)
+
+type WebhookEndpointRepo struct {
+	db *gorm.DB
+}
+
+func NewWebhookEndpointRepo(db *gorm.DB) domain.WebhookEndpointRepository {
+	return &WebhookEndpointRepo{db: db}
+}
+
+func (r *WebhookEndpointRepo) conn(ctx context.Context) *gorm.DB {
+	return gormdb.ExtractTx(ctx, r.db).WithContext(ctx)
+}
+
+func (r *WebhookEndpointRepo) Create(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	return r.conn(ctx).Create(endpoint).Error
+}
+
+func (r *WebhookEndpointRepo) FindByID(ctx context.Context, id string) (*domain.WebhookEndpoint, error) {
+	var endpoint domain.WebhookEndpoint
+	err := r.conn(ctx).Where("id = ?", id).First(&endpoint).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+func (r *WebhookEndpointRepo) FindEnabled(ctx context.Context) ([]*domain.WebhookEndpoint, error) {
+	var endpoints []*domain.WebhookEndpoint
+	err := r.conn(ctx).Where("enabled = ?", true).Order("created_at ASC").Find(&endpoints).Error
+	if err != nil {
+		return nil, err
+	}
+	return endpoints, nil
+}
+
+func (r *WebhookEndpointRepo) List(ctx context.Context) ([]*domain.WebhookEndpoint, error) {
+	var endpoints []*domain.WebhookEndpoint
+	err := r.conn(ctx).Order("created_at ASC").Find(&endpoints).Error
+	if err != nil {
+		return nil, err
+	}
+	return endpoints, nil
+}
+
+func (r *WebhookEndpointRepo) Delete(ctx context.Context, id string) error {
+	return r.conn(ctx).Where("id = ?", id).Delete(&domain.WebhookEndpoint{}).Error
+}
@@ -15,6 +15,36 @@ func NewTestConnection() (*gorm.DB, error) {
 		return nil, err
 	}
 
-	db.AutoMigrate(&domain.Payment{}, &domain.IdempotencyRecord{})
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	db.AutoMigrate(
+		&domain.Payment{},
+		&domain.IdempotencyRecord{},
+		&domain.OutboxEvent{},
+		&domain.WebhookEndpoint{},
+		&domain.WebhookDelivery{},
+		&domain.ProcessorEvent{},
+		&domain.CurrencyDefinition{},
+		&domain.PaymentMethod{},
+		&domain.Customer{},
+		&domain.PaymentSplit{},
+		&domain.LedgerAccount{},
+		&domain.JournalEntry{},
+		&domain.Posting{},
+		&domain.Reconciliation{},
+		&domain.ReconciliationItem{},
+		&domain.RiskAssessment{},
+		&domain.TokenBucket{},
+		&domain.PaymentBatch{},
+		&domain.PaymentJob{},
+		&domain.ScheduledPayment{},
+		&domain.SubscriptionPlan{},
+		&domain.Subscription{},
+		&domain.Refund{},
+	)
 	return db, nil
 }
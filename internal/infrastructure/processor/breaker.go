@@ -0,0 +1,248 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+)
+
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"
+	BreakerOpen     BreakerState = "open"
+	BreakerHalfOpen BreakerState = "half_open"
+)
+
+const (
+	defaultCallTimeout      = 5 * time.Second
+	defaultFailureThreshold = 5
+	defaultOpenDuration     = 30 * time.Second
+	defaultHalfOpenProbes   = 1
+)
+
+type Breaker struct {
+	name             string
+	next             domain.PaymentProcessor
+	timeout          time.Duration
+	failureThreshold int
+	openDuration     time.Duration
+	halfOpenProbes   int
+	now              func() time.Time
+
+	mu        sync.Mutex
+	state     BreakerState
+	failures  int
+	openedAt  time.Time
+	inFlight  int
+	successes int
+}
+
+type BreakerOption func(*Breaker)
+
+func WithCallTimeout(d time.Duration) BreakerOption {
+	return func(b *Breaker) {
+		b.timeout = d
+	}
+}
+
+func WithFailureThreshold(n int) BreakerOption {
+	return func(b *Breaker) {
+		b.failureThreshold = n
+	}
+}
+
+func WithOpenDuration(d time.Duration) BreakerOption {
+	return func(b *Breaker) {
+		b.openDuration = d
+	}
+}
+
+func WithHalfOpenProbes(n int) BreakerOption {
+	return func(b *Breaker) {
+		if n > 0 {
+			b.halfOpenProbes = n
+		}
+	}
+}
+
+func withClock(now func() time.Time) BreakerOption {
+	return func(b *Breaker) {
+		b.now = now
+	}
+}
+
+func NewBreaker(name string, next domain.PaymentProcessor, opts ...BreakerOption) *Breaker {
+	b := &Breaker{
+		name:             name,
+		next:             next,
+		timeout:          defaultCallTimeout,
+		failureThreshold: defaultFailureThreshold,
+		openDuration:     defaultOpenDuration,
+		halfOpenProbes:   defaultHalfOpenProbes,
+		now:              time.Now,
+		state:            BreakerClosed,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refresh()
+	return b.state
+}
+
+func (b *Breaker) Process(ctx context.Context, idempotencyKey string, req domain.PaymentRequest) (*domain.Payment, error) {
+	return guard(b, ctx, func(callCtx context.Context) (*domain.Payment, error) {
+		return b.next.Process(callCtx, idempotencyKey, req)
+	})
+}
+
+func (b *Breaker) GetStatus(ctx context.Context, paymentID string) (*domain.PaymentStatusUpdate, error) {
+	return guard(b, ctx, func(callCtx context.Context) (*domain.PaymentStatusUpdate, error) {
+		return b.next.GetStatus(callCtx, paymentID)
+	})
+}
+
+func (b *Breaker) LookupByKey(ctx context.Context, idempotencyKey string) (*domain.Payment, error) {
+	return guard(b, ctx, func(callCtx context.Context) (*domain.Payment, error) {
+		return b.next.LookupByKey(callCtx, idempotencyKey)
+	})
+}
+
+func (b *Breaker) Refund(ctx context.Context, idempotencyKey string, payment *domain.Payment, amount int64) (string, error) {
+	refunder, ok := b.next.(domain.PaymentRefunder)
+	if !ok {
+		return "", domain.ErrRefundUnsupported
+	}
+	return guard(b, ctx, func(callCtx context.Context) (string, error) {
+		return refunder.Refund(callCtx, idempotencyKey, payment, amount)
+	})
+}
+
+func (b *Breaker) GenerateSettlementFile(ctx context.Context, date time.Time, w io.Writer) error {
+	generator, ok := b.next.(domain.SettlementFileGenerator)
+	if !ok {
+		return domain.ErrSettlementUnsupported
+	}
+	return generator.GenerateSettlementFile(ctx, date, w)
+}
+
+func guard[T any](b *Breaker, ctx context.Context, call func(context.Context) (T, error)) (T, error) {
+	var zero T
+	if !b.allow() {
+		return zero, fmt.Errorf("%w: %s circuit is open", domain.ErrProcessorUnavailable, b.name)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, b.timeout)
+	defer cancel()
+
+	type outcome struct {
+		value T
+		err   error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		value, err := call(callCtx)
+		done <- outcome{value: value, err: err}
+	}()
+
+	select {
+	case out := <-done:
+		if out.err != nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+			out.err = fmt.Errorf("%w: %s after %s", domain.ErrProcessorTimeout, b.name, b.timeout)
+		}
+		b.record(out.err)
+		return out.value, out.err
+	case <-callCtx.Done():
+		if ctx.Err() != nil {
+			b.release()
+			return zero, ctx.Err()
+		}
+		err := fmt.Errorf("%w: %s after %s", domain.ErrProcessorTimeout, b.name, b.timeout)
+		b.record(err)
+		return zero, err
+	}
+}
+
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refresh()
+
+	switch b.state {
+	case BreakerOpen:
+		return false
+	case BreakerHalfOpen:
+		if b.inFlight >= b.halfOpenProbes {
+			return false
+		}
+	}
+	b.inFlight++
+	return true
+}
+
+func (b *Breaker) refresh() {
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.openDuration {
+		b.transition(BreakerHalfOpen)
+	}
+}
+
+func (b *Breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.inFlight > 0 {
+		b.inFlight--
+	}
+}
+
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.inFlight > 0 {
+		b.inFlight--
+	}
+
+	failed := errors.Is(err, domain.ErrProcessorUnavailable) || errors.Is(err, domain.ErrProcessorTimeout)
+	switch b.state {
+	case BreakerClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failureThreshold > 0 && b.failures >= b.failureThreshold {
+			b.transition(BreakerOpen)
+		}
+	case BreakerHalfOpen:
+		if failed {
+			b.transition(BreakerOpen)
+			return
+		}
+		b.successes++
+		if b.successes >= b.halfOpenProbes {
+			b.transition(BreakerClosed)
+		}
+	}
+}
+
+func (b *Breaker) transition(state BreakerState) {
+	log.Printf("processor %s circuit %s -> %s", b.name, b.state, state)
+	b.state = state
+	b.failures = 0
+	b.successes = 0
+	b.inFlight = 0
+	if state == BreakerOpen {
+		b.openedAt = b.now()
+	}
+}
@@ -0,0 +1,157 @@
+package processor
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubProcessor struct {
+	calls atomic.Int32
+	err   error
+	delay time.Duration
+}
+
+func (p *stubProcessor) Process(ctx context.Context, idempotencyKey string, req domain.PaymentRequest) (*domain.Payment, error) {
+	p.calls.Add(1)
+	time.Sleep(p.delay)
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &domain.Payment{ID: "pay_1", Status: domain.PaymentStatusSucceeded}, nil
+}
+
+func (p *stubProcessor) GetStatus(ctx context.Context, paymentID string) (*domain.PaymentStatusUpdate, error) {
+	return &domain.PaymentStatusUpdate{Status: domain.PaymentStatusSucceeded}, p.err
+}
+
+func (p *stubProcessor) LookupByKey(ctx context.Context, idempotencyKey string) (*domain.Payment, error) {
+	return nil, p.err
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func TestBreaker_TimesOutSlowCalls(t *testing.T) {
+	stub := &stubProcessor{delay: 200 * time.Millisecond}
+	breaker := NewBreaker("sim_a", stub, WithCallTimeout(20*time.Millisecond))
+
+	start := time.Now()
+	_, err := breaker.Process(context.Background(), "", domain.PaymentRequest{})
+	assert.ErrorIs(t, err, domain.ErrProcessorTimeout)
+	assert.Less(t, time.Since(start), 150*time.Millisecond)
+}
+
+func TestBreaker_SimulatorHonoursTimeout(t *testing.T) {
+	sim := NewSimulator(WithLatency(time.Second, time.Second))
+	breaker := NewBreaker("sim_a", sim, WithCallTimeout(20*time.Millisecond))
+
+	_, err := breaker.Process(context.Background(), "", routerRequest(domain.CurrencyIDR, "4111111111111111"))
+	assert.ErrorIs(t, err, domain.ErrProcessorTimeout)
+}
+
+func TestBreaker_OpensAndFailsFast(t *testing.T) {
+	stub := &stubProcessor{err: domain.ErrProcessorUnavailable}
+	clock := &fakeClock{now: time.Now()}
+	breaker := NewBreaker("sim_a", stub, WithFailureThreshold(3), WithOpenDuration(time.Minute), withClock(clock.Now))
+
+	for i := 0; i < 3; i++ {
+		_, err := breaker.Process(context.Background(), "", domain.PaymentRequest{})
+		assert.ErrorIs(t, err, domain.ErrProcessorUnavailable)
+	}
+	assert.Equal(t, BreakerOpen, breaker.State())
+
+	_, err := breaker.Process(context.Background(), "", domain.PaymentRequest{})
+	assert.ErrorIs(t, err, domain.ErrProcessorUnavailable)
+	assert.Equal(t, int32(3), stub.calls.Load())
+}
+
+func TestBreaker_DeclinesDoNotCount(t *testing.T) {
+	stub := &stubProcessor{err: ErrUnsupportedCurrency}
+	breaker := NewBreaker("sim_a", stub, WithFailureThreshold(2))
+
+	for i := 0; i < 5; i++ {
+		_, err := breaker.Process(context.Background(), "", domain.PaymentRequest{})
+		assert.ErrorIs(t, err, ErrUnsupportedCurrency)
+	}
+	assert.Equal(t, BreakerClosed, breaker.State())
+}
+
+func TestBreaker_HalfOpenProbe(t *testing.T) {
+	stub := &stubProcessor{err: domain.ErrProcessorUnavailable}
+	clock := &fakeClock{now: time.Now()}
+	breaker := NewBreaker("sim_a", stub, WithFailureThreshold(1), WithOpenDuration(time.Minute), withClock(clock.Now))
+
+	_, _ = breaker.Process(context.Background(), "", domain.PaymentRequest{})
+	require.Equal(t, BreakerOpen, breaker.State())
+
+	clock.now = clock.now.Add(time.Minute)
+	assert.Equal(t, BreakerHalfOpen, breaker.State())
+	_, err := breaker.Process(context.Background(), "", domain.PaymentRequest{})
+	assert.ErrorIs(t, err, domain.ErrProcessorUnavailable)
+	assert.Equal(t, BreakerOpen, breaker.State())
+	assert.Equal(t, int32(2), stub.calls.Load())
+
+	clock.now = clock.now.Add(time.Minute)
+	stub.err = nil
+	payment, err := breaker.Process(context.Background(), "", domain.PaymentRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "pay_1", payment.ID)
+	assert.Equal(t, BreakerClosed, breaker.State())
+}
+
+func TestBreaker_HalfOpenLimitsConcurrentProbes(t *testing.T) {
+	stub := &stubProcessor{err: domain.ErrProcessorUnavailable}
+	clock := &fakeClock{now: time.Now()}
+	breaker := NewBreaker("sim_a", stub, WithFailureThreshold(1), WithOpenDuration(time.Minute), withClock(clock.Now))
+
+	_, _ = breaker.Process(context.Background(), "", domain.PaymentRequest{})
+	clock.now = clock.now.Add(time.Minute)
+
+	stub.err = nil
+	stub.delay = 100 * time.Millisecond
+	probe := make(chan error, 1)
+	go func() {
+		_, err := breaker.Process(context.Background(), "", domain.PaymentRequest{})
+		probe <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	_, err := breaker.Process(context.Background(), "", domain.PaymentRequest{})
+	assert.ErrorIs(t, err, domain.ErrProcessorUnavailable)
+	require.NoError(t, <-probe)
+	assert.Equal(t, BreakerClosed, breaker.State())
+}
+
+func TestRouter_FailsOverWhenCircuitIsOpen(t *testing.T) {
+	registry := NewRegistry()
+	down := NewBreaker("sim_a", NewSimulator(WithUnavailableRate(1)), WithFailureThreshold(1))
+	require.NoError(t, registry.Register("sim_a", down))
+	require.NoError(t, registry.Register("sim_b", NewBreaker("sim_b", NewSimulator())))
+	router, err := NewRouter(registry, nil, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		payment, err := router.Process(context.Background(), "", routerRequest(domain.CurrencyIDR, "4111111111111111"))
+		require.NoError(t, err)
+		assert.Equal(t, "sim_b", payment.Processor)
+	}
+	assert.Equal(t, BreakerOpen, down.State())
+}
+
+func TestBreaker_RefundUnsupported(t *testing.T) {
+	breaker := NewBreaker("sim_a", &stubProcessor{})
+
+	_, err := breaker.Refund(context.Background(), "idem_rf_1", &domain.Payment{ID: "pay_1"}, 10)
+	assert.ErrorIs(t, err, domain.ErrRefundUnsupported)
+}
@@ -0,0 +1,83 @@
+package processor
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/utils/signature"
+)
+
+const (
+	callbackSignatureHeader = "X-Processor-Signature"
+	callbackTimestampHeader = "X-Processor-Timestamp"
+)
+
+type callbackConfig struct {
+	url     string
+	secret  string
+	repeats int
+	client  *http.Client
+}
+
+func WithCallbacks(url, secret string, repeats int) SimulatorOption {
+	return func(s *Simulator) {
+		if url == "" {
+			return
+		}
+		if repeats < 1 {
+			repeats = 1
+		}
+		s.callbacks = &callbackConfig{
+			url:     url,
+			secret:  secret,
+			repeats: repeats,
+			client:  &http.Client{Timeout: 5 * time.Second},
+		}
+	}
+}
+
+func (s *Simulator) scheduleCallback(paymentID string) {
+	if s.callbacks == nil {
+		return
+	}
+	time.AfterFunc(s.pendingSettleAfter, func() {
+		s.sendCallback(domain.ProcessorEventRequest{
+			EventID:   "evt_" + uuid.New().String(),
+			PaymentID: paymentID,
+			Status:    domain.PaymentStatusSucceeded,
+		})
+	})
+}
+
+func (s *Simulator) sendCallback(event domain.ProcessorEventRequest) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("simulator callback: %v", err)
+		return
+	}
+
+	for i := 0; i < s.callbacks.repeats; i++ {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req, err := http.NewRequest(http.MethodPost, s.callbacks.url, bytes.NewReader(body))
+		if err != nil {
+			log.Printf("simulator callback: %v", err)
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(callbackTimestampHeader, timestamp)
+		req.Header.Set(callbackSignatureHeader, signature.Sign(s.callbacks.secret, timestamp, body))
+
+		resp, err := s.callbacks.client.Do(req)
+		if err != nil {
+			log.Printf("simulator callback %s: %v", event.EventID, err)
+			continue
+		}
+		resp.Body.Close()
+	}
+}
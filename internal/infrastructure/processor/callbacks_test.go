@@ -0,0 +1,75 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/utils/signature"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallbacks_PendingPaymentEmitsSignedCallbacks(t *testing.T) {
+	var mu sync.Mutex
+	var events []domain.ProcessorEventRequest
+	var verifyErrs []error
+	done := make(chan struct{}, 2)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var event domain.ProcessorEventRequest
+		_ = json.Unmarshal(body, &event)
+
+		mu.Lock()
+		events = append(events, event)
+		verifyErrs = append(verifyErrs, signature.Verify(
+			"cb-secret",
+			r.Header.Get(callbackSignatureHeader),
+			r.Header.Get(callbackTimestampHeader),
+			body,
+			time.Minute,
+			time.Now(),
+		))
+		mu.Unlock()
+		done <- struct{}{}
+	}))
+	defer server.Close()
+
+	sim := NewSimulator(WithPendingSettleAfter(0), WithCallbacks(server.URL, "cb-secret", 2))
+	payment, err := sim.Process(context.Background(), "", domain.PaymentRequest{
+		Amount:     "100",
+		Currency:   domain.CurrencyIDR,
+		CustomerID: "cust-1",
+		CardNumber: "4000000000000259",
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("callback not received")
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, events, 2)
+	assert.Equal(t, payment.ID, events[0].PaymentID)
+	assert.Equal(t, domain.PaymentStatusSucceeded, events[0].Status)
+	assert.Equal(t, events[0].EventID, events[1].EventID)
+	assert.NoError(t, verifyErrs[0])
+}
+
+func TestWithCallbacks_EmptyURLDisablesCallbacks(t *testing.T) {
+	sim := NewSimulator(WithCallbacks("", "secret", 1)).(*Simulator)
+
+	assert.Nil(t, sim.callbacks)
+}
@@ -0,0 +1,54 @@
+package processor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+)
+
+type Registry struct {
+	names      []string
+	processors map[string]domain.PaymentProcessor
+}
+
+func NewRegistry() *Registry {
+	return &Registry{processors: make(map[string]domain.PaymentProcessor)}
+}
+
+func (r *Registry) Register(name string, p domain.PaymentProcessor) error {
+	if _, exists := r.processors[name]; exists {
+		return fmt.Errorf("processor %q is already registered", name)
+	}
+	r.names = append(r.names, name)
+	r.processors[name] = p
+	return nil
+}
+
+func (r *Registry) Get(name string) (domain.PaymentProcessor, bool) {
+	p, ok := r.processors[name]
+	return p, ok
+}
+
+func (r *Registry) Names() []string {
+	return append([]string(nil), r.names...)
+}
+
+func ParseUnavailableRates(spec string) (map[string]float64, error) {
+	rates := make(map[string]float64)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(entry, ":")
+		rate, err := strconv.ParseFloat(value, 64)
+		if !ok || err != nil || rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("unavailable rate %q: expected PROCESSOR:RATE with a rate between 0 and 1", entry)
+		}
+		rates[strings.TrimSpace(name)] = rate
+	}
+	return rates, nil
+}
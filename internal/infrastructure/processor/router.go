@@ -0,0 +1,176 @@
+package processor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"log"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+)
+
+var ErrNoProcessors = errors.New("processor: router needs at least one processor")
+
+type Router struct {
+	registry    *Registry
+	routes      []domain.ProcessorRoute
+	weights     []domain.ProcessorWeight
+	totalWeight int
+}
+
+func NewRouter(registry *Registry, routes []domain.ProcessorRoute, weights []domain.ProcessorWeight) (*Router, error) {
+	if len(registry.Names()) == 0 {
+		return nil, ErrNoProcessors
+	}
+
+	r := &Router{registry: registry, routes: routes}
+	for _, route := range routes {
+		for _, name := range route.Processors {
+			if _, ok := registry.Get(name); !ok {
+				return nil, fmt.Errorf("processor route %s:%s: unknown processor %q", route.Kind, route.Value, name)
+			}
+		}
+	}
+	for _, weight := range weights {
+		if _, ok := registry.Get(weight.Processor); !ok {
+			return nil, fmt.Errorf("processor weight: unknown processor %q", weight.Processor)
+		}
+		if weight.Weight > 0 {
+			r.weights = append(r.weights, weight)
+			r.totalWeight += weight.Weight
+		}
+	}
+	return r, nil
+}
+
+func (r *Router) Process(ctx context.Context, idempotencyKey string, req domain.PaymentRequest) (*domain.Payment, error) {
+	var lastErr error
+	for _, name := range r.candidates(idempotencyKey, req) {
+		p, _ := r.registry.Get(name)
+		payment, err := p.Process(ctx, idempotencyKey, req)
+		if err == nil {
+			payment.Processor = name
+			return payment, nil
+		}
+		if !errors.Is(err, domain.ErrProcessorUnavailable) {
+			return nil, err
+		}
+		log.Printf("processor %s unavailable, failing over: %v", name, err)
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+func (r *Router) candidates(idempotencyKey string, req domain.PaymentRequest) []string {
+	for _, route := range r.routes {
+		if route.Matches(req) {
+			return route.Processors
+		}
+	}
+
+	names := r.registry.Names()
+	if r.totalWeight == 0 {
+		return names
+	}
+
+	primary := r.pick(idempotencyKey, req)
+	candidates := []string{primary}
+	for _, name := range names {
+		if name != primary {
+			candidates = append(candidates, name)
+		}
+	}
+	return candidates
+}
+
+func (r *Router) pick(idempotencyKey string, req domain.PaymentRequest) string {
+	seed := idempotencyKey
+	if seed == "" {
+		seed = req.CustomerID + "\x1f" + req.RideID + "\x1f" + req.Amount.String()
+	}
+	h := fnv.New32a()
+	_, _ = io.WriteString(h, seed)
+	point := int(h.Sum32() % uint32(r.totalWeight))
+	for _, weight := range r.weights {
+		if point < weight.Weight {
+			return weight.Processor
+		}
+		point -= weight.Weight
+	}
+	return r.weights[len(r.weights)-1].Processor
+}
+
+func (r *Router) GetStatus(ctx context.Context, paymentID string) (*domain.PaymentStatusUpdate, error) {
+	for _, name := range r.registry.Names() {
+		p, _ := r.registry.Get(name)
+		update, err := p.GetStatus(ctx, paymentID)
+		if errors.Is(err, ErrUnknownPayment) {
+			continue
+		}
+		return update, err
+	}
+	return nil, ErrUnknownPayment
+}
+
+func (r *Router) LookupByKey(ctx context.Context, idempotencyKey string) (*domain.Payment, error) {
+	var lastErr error
+	for _, name := range r.registry.Names() {
+		p, _ := r.registry.Get(name)
+		payment, err := p.LookupByKey(ctx, idempotencyKey)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if payment != nil {
+			payment.Processor = name
+			return payment, nil
+		}
+	}
+	return nil, lastErr
+}
+
+func (r *Router) Refund(ctx context.Context, idempotencyKey string, payment *domain.Payment, amount int64) (string, error) {
+	name := payment.Processor
+	if name == "" {
+		name = r.registry.Names()[0]
+	}
+	p, ok := r.registry.Get(name)
+	if !ok {
+		return "", fmt.Errorf("processor %q is not registered", name)
+	}
+	refunder, ok := p.(domain.PaymentRefunder)
+	if !ok {
+		return "", domain.ErrRefundUnsupported
+	}
+	return refunder.Refund(ctx, idempotencyKey, payment, amount)
+}
+
+func (r *Router) GenerateSettlementFile(ctx context.Context, date time.Time, w io.Writer) error {
+	var rows []domain.SettlementRow
+	for _, name := range r.registry.Names() {
+		p, _ := r.registry.Get(name)
+		generator, ok := p.(domain.SettlementFileGenerator)
+		if !ok {
+			continue
+		}
+
+		var buf bytes.Buffer
+		err := generator.GenerateSettlementFile(ctx, date, &buf)
+		if errors.Is(err, domain.ErrSettlementUnsupported) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		processorRows, err := domain.ParseSettlementCSV(&buf)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, processorRows...)
+	}
+	return domain.WriteSettlementCSV(w, rows)
+}
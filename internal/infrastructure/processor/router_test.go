@@ -0,0 +1,165 @@
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRouter(t *testing.T, routes, weights string, down ...string) *Router {
+	t.Helper()
+	registry := NewRegistry()
+	for _, name := range []string{"sim_a", "sim_b", "sim_c"} {
+		rate := 0.0
+		for _, d := range down {
+			if d == name {
+				rate = 1
+			}
+		}
+		require.NoError(t, registry.Register(name, NewSimulator(WithUnavailableRate(rate))))
+	}
+
+	parsedRoutes, err := domain.ParseProcessorRoutes(routes)
+	require.NoError(t, err)
+	parsedWeights, err := domain.ParseProcessorWeights(weights)
+	require.NoError(t, err)
+	router, err := NewRouter(registry, parsedRoutes, parsedWeights)
+	require.NoError(t, err)
+	return router
+}
+
+func routerRequest(currency domain.Currency, card string) domain.PaymentRequest {
+	return domain.PaymentRequest{
+		Amount:     "100",
+		Currency:   currency,
+		CustomerID: "cust_1",
+		RideID:     "ride_1",
+		CardNumber: card,
+	}
+}
+
+func TestRouter_RoutesByCurrencyAndBIN(t *testing.T) {
+	router := newTestRouter(t, "bin:400000=sim_c,currency:THB=sim_b", "")
+
+	payment, err := router.Process(context.Background(), "", routerRequest(domain.CurrencyTHB, "4111111111111111"))
+	require.NoError(t, err)
+	assert.Equal(t, "sim_b", payment.Processor)
+
+	payment, err = router.Process(context.Background(), "", routerRequest(domain.CurrencyTHB, "4000000000000002"))
+	require.NoError(t, err)
+	assert.Equal(t, "sim_c", payment.Processor)
+
+	payment, err = router.Process(context.Background(), "", routerRequest(domain.CurrencyIDR, "4111111111111111"))
+	require.NoError(t, err)
+	assert.Equal(t, "sim_a", payment.Processor)
+}
+
+func TestRouter_FailsOverOnUnavailable(t *testing.T) {
+	router := newTestRouter(t, "currency:IDR=sim_a>sim_b", "", "sim_a")
+
+	payment, err := router.Process(context.Background(), "", routerRequest(domain.CurrencyIDR, "4000000000000259"))
+	require.NoError(t, err)
+	assert.Equal(t, "sim_b", payment.Processor)
+
+	update, err := router.GetStatus(context.Background(), payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusPending, update.Status)
+}
+
+func TestRouter_DoesNotFailOverOnDecline(t *testing.T) {
+	router := newTestRouter(t, "currency:IDR=sim_a>sim_b", "")
+
+	payment, err := router.Process(context.Background(), "", routerRequest(domain.CurrencyIDR, "4000000000000002"))
+	require.NoError(t, err)
+	assert.Equal(t, "sim_a", payment.Processor)
+	assert.Equal(t, domain.PaymentStatusFailed, payment.Status)
+}
+
+func TestRouter_AllCandidatesUnavailable(t *testing.T) {
+	router := newTestRouter(t, "currency:IDR=sim_a>sim_b", "", "sim_a", "sim_b")
+
+	_, err := router.Process(context.Background(), "", routerRequest(domain.CurrencyIDR, "4111111111111111"))
+	assert.ErrorIs(t, err, domain.ErrProcessorUnavailable)
+}
+
+func TestRouter_WeightedPickIsDeterministic(t *testing.T) {
+	router := newTestRouter(t, "", "sim_b:1")
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, []string{"sim_b", "sim_a", "sim_c"}, router.candidates("", routerRequest(domain.CurrencyIDR, "4111111111111111")))
+	}
+
+	router = newTestRouter(t, "", "sim_a:50,sim_c:50")
+	counts := map[string]int{}
+	for i := 0; i < 200; i++ {
+		req := routerRequest(domain.CurrencyIDR, "4111111111111111")
+		req.RideID = "ride_" + string(rune('a'+i%26)) + string(rune('a'+i/26))
+		first := router.candidates("", req)[0]
+		assert.Equal(t, first, router.candidates("", req)[0])
+		counts[first]++
+	}
+	assert.Zero(t, counts["sim_b"])
+	assert.Greater(t, counts["sim_a"], 50)
+	assert.Greater(t, counts["sim_c"], 50)
+}
+
+func TestNewRouter_RejectsUnknownProcessors(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register("sim_a", NewSimulator()))
+	assert.Error(t, registry.Register("sim_a", NewSimulator()))
+
+	_, err := NewRouter(registry, []domain.ProcessorRoute{{Kind: domain.ProcessorRouteCurrency, Value: "IDR", Processors: []string{"sim_x"}}}, nil)
+	assert.Error(t, err)
+	_, err = NewRouter(registry, nil, []domain.ProcessorWeight{{Processor: "sim_x", Weight: 1}})
+	assert.Error(t, err)
+	_, err = NewRouter(NewRegistry(), nil, nil)
+	assert.ErrorIs(t, err, ErrNoProcessors)
+}
+
+func TestParseUnavailableRates(t *testing.T) {
+	rates, err := ParseUnavailableRates("sim_a:0.25, sim_b:1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"sim_a": 0.25, "sim_b": 1}, rates)
+
+	for _, spec := range []string{"sim_a", "sim_a:x", "sim_a:1.5"} {
+		_, err := ParseUnavailableRates(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestRouter_LookupByKeyNamesProcessor(t *testing.T) {
+	router := newTestRouter(t, "currency:THB=sim_b", "")
+
+	payment, err := router.Process(context.Background(), "idem_1", routerRequest(domain.CurrencyTHB, "4111111111111111"))
+	require.NoError(t, err)
+
+	found, err := router.LookupByKey(context.Background(), "idem_1")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, payment.ID, found.ID)
+	assert.Equal(t, "sim_b", found.Processor)
+
+	missing, err := router.LookupByKey(context.Background(), "idem_2")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestRouter_RefundGoesToChargingProcessor(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register("sim_a", NewSimulator(WithUnavailableRate(1))))
+	require.NoError(t, registry.Register("sim_b", NewBreaker("sim_b", NewSimulator())))
+	router, err := NewRouter(registry, nil, nil)
+	require.NoError(t, err)
+
+	reference, err := router.Refund(context.Background(), "idem_rf_1", &domain.Payment{ID: "pay_1", Processor: "sim_b"}, 50)
+	require.NoError(t, err)
+	assert.NotEmpty(t, reference)
+
+	_, err = router.Refund(context.Background(), "idem_rf_2", &domain.Payment{ID: "pay_2", Processor: "sim_a"}, 50)
+	assert.ErrorIs(t, err, domain.ErrProcessorUnavailable)
+
+	_, err = router.Refund(context.Background(), "idem_rf_3", &domain.Payment{ID: "pay_3", Processor: "sim_z"}, 50)
+	assert.Error(t, err)
+}
@@ -2,38 +2,294 @@ package processor
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
 )
 
-type Simulator struct{}
+var (
+	ErrUnknownPayment       = errors.New("processor: unknown payment")
+	ErrUnsupportedCurrency  = errors.New("processor: unsupported currency")
+	ErrIdempotencyKeyReused = errors.New("processor: idempotency key reused with a different payment")
+	ErrInvalidRefundAmount  = errors.New("processor: refund amount must be greater than 0")
+)
+
+const (
+	defaultPendingSettleAfter = 30 * time.Second
+	defaultMinLatency         = 50 * time.Millisecond
+	defaultMaxLatency         = 200 * time.Millisecond
+	settlementRetention       = 7 * 24 * time.Hour
+	keyRetention              = 24 * time.Hour
+)
 
-func NewSimulator() domain.PaymentProcessor {
-	return &Simulator{}
+type Simulator struct {
+	mu                 sync.Mutex
+	pending            map[string]time.Time
+	keyed              map[string]*domain.Payment
+	keyOrder           []string
+	refunds            map[string]simulatedRefund
+	refundOrder        []string
+	pendingSettleAfter time.Duration
+	callbacks          *callbackConfig
+	settlements        []settlement
+	unavailableRate    float64
+	minLatency         time.Duration
+	maxLatency         time.Duration
 }
 
-func (s *Simulator) Process(_ context.Context, req domain.PaymentRequest) (*domain.Payment, error) {
-	delay := time.Duration(50+rand.Intn(150)) * time.Millisecond
-	time.Sleep(delay)
+type settlement struct {
+	paymentID string
+	amount    domain.Money
+	createdAt time.Time
+	settledAt time.Time
+}
 
-	status, failReason := resolveOutcome(req.CardNumber)
-	cardLast4 := extractLast4(req.CardNumber)
+type simulatedRefund struct {
+	reference string
+	paymentID string
+	amount    int64
+	createdAt time.Time
+}
+
+type SimulatorOption func(*Simulator)
+
+func WithPendingSettleAfter(d time.Duration) SimulatorOption {
+	return func(s *Simulator) {
+		s.pendingSettleAfter = d
+	}
+}
+
+func WithUnavailableRate(rate float64) SimulatorOption {
+	return func(s *Simulator) {
+		s.unavailableRate = rate
+	}
+}
+
+func WithLatency(min, max time.Duration) SimulatorOption {
+	return func(s *Simulator) {
+		s.minLatency = min
+		s.maxLatency = max
+	}
+}
+
+func NewSimulator(opts ...SimulatorOption) domain.PaymentProcessor {
+	s := &Simulator{
+		pending:            make(map[string]time.Time),
+		keyed:              make(map[string]*domain.Payment),
+		refunds:            make(map[string]simulatedRefund),
+		pendingSettleAfter: defaultPendingSettleAfter,
+		minLatency:         defaultMinLatency,
+		maxLatency:         defaultMaxLatency,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
 
-	return &domain.Payment{
+func (s *Simulator) Process(ctx context.Context, idempotencyKey string, req domain.PaymentRequest) (*domain.Payment, error) {
+	if s.unavailableRate > 0 && rand.Float64() < s.unavailableRate {
+		return nil, fmt.Errorf("%w: simulated outage", domain.ErrProcessorUnavailable)
+	}
+
+	if !domain.Currencies().Supported(req.Currency) {
+		return nil, ErrUnsupportedCurrency
+	}
+
+	money, err := req.Money()
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := s.charge(idempotencyKey, req, money)
+	if err != nil {
+		return nil, err
+	}
+
+	delay := s.minLatency
+	if s.maxLatency > s.minLatency {
+		delay += time.Duration(rand.Int63n(int64(s.maxLatency - s.minLatency)))
+	}
+	select {
+	case <-time.After(delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	return payment, nil
+}
+
+func (s *Simulator) charge(idempotencyKey string, req domain.PaymentRequest, money domain.Money) (*domain.Payment, error) {
+	s.mu.Lock()
+	if idempotencyKey != "" {
+		if original, ok := s.keyed[idempotencyKey]; ok {
+			s.mu.Unlock()
+			if original.Money() != money || original.CustomerID != req.CustomerID {
+				return nil, ErrIdempotencyKeyReused
+			}
+			replay := *original
+			return &replay, nil
+		}
+	}
+
+	status, failReason := resolveOutcome(req.CardNumber)
+	payment := &domain.Payment{
 		ID:          uuid.New().String(),
-		Amount:      req.Amount,
+		Amount:      money.Amount,
 		Currency:    req.Currency,
 		CustomerID:  req.CustomerID,
 		RideID:      req.RideID,
 		Status:      status,
-		CardLast4:   cardLast4,
+		CardLast4:   extractLast4(req.CardNumber),
 		Description: req.Description,
 		FailReason:  failReason,
 		CreatedAt:   time.Now(),
-	}, nil
+	}
+
+	if idempotencyKey != "" {
+		s.rememberKey(idempotencyKey, payment)
+	}
+	switch status {
+	case domain.PaymentStatusPending:
+		s.pending[payment.ID] = payment.CreatedAt
+		s.recordSettlement(payment, payment.CreatedAt.Add(s.pendingSettleAfter))
+	case domain.PaymentStatusSucceeded:
+		s.recordSettlement(payment, payment.CreatedAt)
+	}
+	s.mu.Unlock()
+
+	if status == domain.PaymentStatusPending {
+		s.scheduleCallback(payment.ID)
+	}
+
+	result := *payment
+	return &result, nil
+}
+
+func (s *Simulator) rememberKey(idempotencyKey string, payment *domain.Payment) {
+	cutoff := payment.CreatedAt.Add(-keyRetention)
+	expired := 0
+	for expired < len(s.keyOrder) && s.keyed[s.keyOrder[expired]].CreatedAt.Before(cutoff) {
+		delete(s.keyed, s.keyOrder[expired])
+		expired++
+	}
+	s.keyOrder = append(s.keyOrder[expired:], idempotencyKey)
+	s.keyed[idempotencyKey] = payment
+}
+
+func (s *Simulator) GetStatus(_ context.Context, paymentID string) (*domain.PaymentStatusUpdate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	createdAt, ok := s.pending[paymentID]
+	if !ok {
+		return nil, ErrUnknownPayment
+	}
+
+	if time.Since(createdAt) < s.pendingSettleAfter {
+		return &domain.PaymentStatusUpdate{Status: domain.PaymentStatusPending}, nil
+	}
+
+	delete(s.pending, paymentID)
+	return &domain.PaymentStatusUpdate{Status: domain.PaymentStatusSucceeded}, nil
+}
+
+func (s *Simulator) LookupByKey(_ context.Context, idempotencyKey string) (*domain.Payment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	original, ok := s.keyed[idempotencyKey]
+	if !ok {
+		return nil, nil
+	}
+
+	payment := *original
+	if payment.Status == domain.PaymentStatusPending {
+		createdAt, stillPending := s.pending[payment.ID]
+		if !stillPending || time.Since(createdAt) >= s.pendingSettleAfter {
+			payment.Status = domain.PaymentStatusSucceeded
+		}
+	}
+	return &payment, nil
+}
+
+func (s *Simulator) Refund(_ context.Context, idempotencyKey string, payment *domain.Payment, amount int64) (string, error) {
+	if s.unavailableRate > 0 && rand.Float64() < s.unavailableRate {
+		return "", fmt.Errorf("%w: simulated outage", domain.ErrProcessorUnavailable)
+	}
+	if amount <= 0 {
+		return "", ErrInvalidRefundAmount
+	}
+
+	paymentID := payment.SettlementReference()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if original, ok := s.refunds[idempotencyKey]; ok {
+		if original.paymentID != paymentID || original.amount != amount {
+			return "", ErrIdempotencyKeyReused
+		}
+		return original.reference, nil
+	}
+
+	refund := simulatedRefund{
+		reference: "rf_" + uuid.New().String(),
+		paymentID: paymentID,
+		amount:    amount,
+		createdAt: time.Now(),
+	}
+	cutoff := refund.createdAt.Add(-keyRetention)
+	expired := 0
+	for expired < len(s.refundOrder) && s.refunds[s.refundOrder[expired]].createdAt.Before(cutoff) {
+		delete(s.refunds, s.refundOrder[expired])
+		expired++
+	}
+	s.refundOrder = append(s.refundOrder[expired:], idempotencyKey)
+	s.refunds[idempotencyKey] = refund
+	return refund.reference, nil
+}
+
+func (s *Simulator) GenerateSettlementFile(_ context.Context, date time.Time, w io.Writer) error {
+	from := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	now := time.Now()
+
+	s.mu.Lock()
+	var rows []domain.SettlementRow
+	for _, entry := range s.settlements {
+		if entry.createdAt.Before(from) || !entry.createdAt.Before(to) || entry.settledAt.After(now) {
+			continue
+		}
+		settledAt := entry.settledAt
+		rows = append(rows, domain.SettlementRow{
+			ProcessorReference: entry.paymentID,
+			Amount:             entry.amount,
+			SettledAt:          &settledAt,
+		})
+	}
+	s.mu.Unlock()
+
+	return domain.WriteSettlementCSV(w, rows)
+}
+
+func (s *Simulator) recordSettlement(payment *domain.Payment, settledAt time.Time) {
+	cutoff := payment.CreatedAt.Add(-settlementRetention)
+	expired := 0
+	for expired < len(s.settlements) && s.settlements[expired].createdAt.Before(cutoff) {
+		expired++
+	}
+	s.settlements = append(s.settlements[expired:], settlement{
+		paymentID: payment.ID,
+		amount:    payment.Money(),
+		createdAt: payment.CreatedAt,
+		settledAt: settledAt,
+	})
 }
 
 func resolveOutcome(cardNumber string) (domain.PaymentStatus, string) {
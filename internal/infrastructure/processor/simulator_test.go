@@ -1,11 +1,15 @@
 package processor
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestProcess_CardOutcomes(t *testing.T) {
@@ -63,8 +67,8 @@ func TestProcess_CardOutcomes(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			payment, err := sim.Process(context.Background(), domain.PaymentRequest{
-				Amount:     100.0,
+			payment, err := sim.Process(context.Background(), "", domain.PaymentRequest{
+				Amount:     "100",
 				Currency:   domain.CurrencyIDR,
 				CustomerID: "cust-1",
 				RideID:     "ride-1",
@@ -100,8 +104,8 @@ func TestExtractLast4(t *testing.T) {
 
 func TestProcess_PaymentHasUUIDFormatID(t *testing.T) {
 	sim := NewSimulator()
-	payment, err := sim.Process(context.Background(), domain.PaymentRequest{
-		Amount:     50.0,
+	payment, err := sim.Process(context.Background(), "", domain.PaymentRequest{
+		Amount:     "50",
 		Currency:   domain.CurrencyTHB,
 		CustomerID: "cust-1",
 		CardNumber: "4111111111111111",
@@ -114,8 +118,8 @@ func TestProcess_PaymentHasUUIDFormatID(t *testing.T) {
 
 func TestProcess_CardLast4Extracted(t *testing.T) {
 	sim := NewSimulator()
-	payment, err := sim.Process(context.Background(), domain.PaymentRequest{
-		Amount:     100.0,
+	payment, err := sim.Process(context.Background(), "", domain.PaymentRequest{
+		Amount:     "100",
 		Currency:   domain.CurrencyIDR,
 		CustomerID: "cust-1",
 		CardNumber: "4111111111111111",
@@ -137,8 +141,8 @@ func TestProcess_CurrencyPassedThrough(t *testing.T) {
 
 	for _, cur := range currencies {
 		t.Run(string(cur), func(t *testing.T) {
-			payment, err := sim.Process(context.Background(), domain.PaymentRequest{
-				Amount:     200.0,
+			payment, err := sim.Process(context.Background(), "", domain.PaymentRequest{
+				Amount:     "200",
 				Currency:   cur,
 				CustomerID: "cust-1",
 				CardNumber: "4111111111111111",
@@ -151,21 +155,176 @@ func TestProcess_CurrencyPassedThrough(t *testing.T) {
 }
 
 func TestProcess_AmountPassedThrough(t *testing.T) {
-	amounts := []float64{0.01, 1.0, 999.99, 100000.0}
+	amounts := []struct {
+		amount   json.Number
+		currency domain.Currency
+		minor    int64
+	}{
+		{"0.01", domain.CurrencyTHB, 1},
+		{"1", domain.CurrencyPHP, 100},
+		{"999.99", domain.CurrencyTHB, 99999},
+		{"100000", domain.CurrencyIDR, 100000},
+	}
 
 	sim := NewSimulator()
 
-	for _, amt := range amounts {
-		t.Run("", func(t *testing.T) {
-			payment, err := sim.Process(context.Background(), domain.PaymentRequest{
-				Amount:     amt,
-				Currency:   domain.CurrencyIDR,
+	for _, tc := range amounts {
+		t.Run(string(tc.amount), func(t *testing.T) {
+			payment, err := sim.Process(context.Background(), "", domain.PaymentRequest{
+				Amount:     tc.amount,
+				Currency:   tc.currency,
 				CustomerID: "cust-1",
 				CardNumber: "4111111111111111",
 			})
 
 			assert.NoError(t, err)
-			assert.Equal(t, amt, payment.Amount)
+			assert.Equal(t, tc.minor, payment.Amount)
 		})
 	}
 }
+
+func TestGetStatus_PendingSettlesAfterDelay(t *testing.T) {
+	sim := NewSimulator(WithPendingSettleAfter(time.Hour))
+	payment, err := sim.Process(context.Background(), "", domain.PaymentRequest{
+		Amount:     "100",
+		Currency:   domain.CurrencyIDR,
+		CustomerID: "cust-1",
+		CardNumber: "4000000000000259",
+	})
+	require.NoError(t, err)
+
+	update, err := sim.GetStatus(context.Background(), payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusPending, update.Status)
+
+	sim.(*Simulator).pendingSettleAfter = 0
+
+	update, err = sim.GetStatus(context.Background(), payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusSucceeded, update.Status)
+}
+
+func TestGetStatus_UnknownPayment(t *testing.T) {
+	sim := NewSimulator()
+
+	_, err := sim.GetStatus(context.Background(), "unknown-payment")
+	assert.ErrorIs(t, err, ErrUnknownPayment)
+}
+
+func TestGenerateSettlementFile_IncludesSettledPayments(t *testing.T) {
+	sim := NewSimulator(WithPendingSettleAfter(time.Hour))
+	ctx := context.Background()
+
+	succeeded, err := sim.Process(ctx, "", domain.PaymentRequest{Amount: "150.50", Currency: domain.CurrencyTHB, CardNumber: "4111111111111111"})
+	require.NoError(t, err)
+	_, err = sim.Process(ctx, "", domain.PaymentRequest{Amount: "100", Currency: domain.CurrencyIDR, CardNumber: "4000000000000002"})
+	require.NoError(t, err)
+	_, err = sim.Process(ctx, "", domain.PaymentRequest{Amount: "100", Currency: domain.CurrencyIDR, CardNumber: "4000000000000259"})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, sim.(domain.SettlementFileGenerator).GenerateSettlementFile(ctx, succeeded.CreatedAt, &buf))
+
+	rows, err := domain.ParseSettlementCSV(&buf)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, succeeded.ID, rows[0].ProcessorReference)
+	assert.Equal(t, domain.Money{Amount: 15050, Currency: domain.CurrencyTHB}, rows[0].Amount)
+	require.NotNil(t, rows[0].SettledAt)
+
+	buf.Reset()
+	require.NoError(t, sim.(domain.SettlementFileGenerator).GenerateSettlementFile(ctx, succeeded.CreatedAt.AddDate(0, 0, -1), &buf))
+	rows, err = domain.ParseSettlementCSV(&buf)
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+}
+
+func TestProcess_ReplaysIdempotencyKey(t *testing.T) {
+	sim := NewSimulator()
+	ctx := context.Background()
+	req := domain.PaymentRequest{Amount: "100", Currency: domain.CurrencyIDR, CustomerID: "cust-1", CardNumber: "4111111111111111"}
+
+	first, err := sim.Process(ctx, "idem_1", req)
+	require.NoError(t, err)
+	second, err := sim.Process(ctx, "idem_1", req)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+
+	other, err := sim.Process(ctx, "idem_2", req)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, other.ID)
+
+	unkeyed, err := sim.Process(ctx, "", req)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, unkeyed.ID)
+
+	req.Amount = "200"
+	_, err = sim.Process(ctx, "idem_1", req)
+	assert.ErrorIs(t, err, ErrIdempotencyKeyReused)
+}
+
+func TestProcess_ChargesEvenWhenCallerGivesUp(t *testing.T) {
+	sim := NewSimulator(WithLatency(time.Second, time.Second))
+	req := domain.PaymentRequest{Amount: "100", Currency: domain.CurrencyIDR, CustomerID: "cust-1", CardNumber: "4111111111111111"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := sim.Process(ctx, "idem_1", req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	sim.(*Simulator).minLatency = 0
+	sim.(*Simulator).maxLatency = 0
+	replay, err := sim.Process(context.Background(), "idem_1", req)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, sim.(domain.SettlementFileGenerator).GenerateSettlementFile(context.Background(), replay.CreatedAt, &buf))
+	rows, err := domain.ParseSettlementCSV(&buf)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, replay.ID, rows[0].ProcessorReference)
+}
+
+func TestLookupByKey_ReportsCurrentStatus(t *testing.T) {
+	sim := NewSimulator(WithPendingSettleAfter(time.Hour))
+	ctx := context.Background()
+	req := domain.PaymentRequest{Amount: "100", Currency: domain.CurrencyIDR, CustomerID: "cust-1", CardNumber: "4000000000000259"}
+
+	missing, err := sim.LookupByKey(ctx, "idem_1")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	charged, err := sim.Process(ctx, "idem_1", req)
+	require.NoError(t, err)
+
+	found, err := sim.LookupByKey(ctx, "idem_1")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, charged.ID, found.ID)
+	assert.Equal(t, domain.PaymentStatusPending, found.Status)
+
+	sim.(*Simulator).pendingSettleAfter = 0
+	found, err = sim.LookupByKey(ctx, "idem_1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusSucceeded, found.Status)
+}
+
+func TestRefund_ReplaysIdempotencyKey(t *testing.T) {
+	sim := NewSimulator().(domain.PaymentRefunder)
+	ctx := context.Background()
+	payment := &domain.Payment{ID: "pay_1", Amount: 100, Currency: domain.CurrencyIDR}
+
+	first, err := sim.Refund(ctx, "idem_rf_1", payment, 40)
+	require.NoError(t, err)
+	assert.NotEmpty(t, first)
+
+	replay, err := sim.Refund(ctx, "idem_rf_1", payment, 40)
+	require.NoError(t, err)
+	assert.Equal(t, first, replay)
+
+	_, err = sim.Refund(ctx, "idem_rf_1", payment, 60)
+	assert.ErrorIs(t, err, ErrIdempotencyKeyReused)
+
+	_, err = sim.Refund(ctx, "idem_rf_2", payment, 0)
+	assert.ErrorIs(t, err, ErrInvalidRefundAmount)
+}
@@ -0,0 +1,54 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+)
+
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*domain.TokenBucket
+}
+
+func NewMemoryStore() domain.RateLimitStore {
+	return &MemoryStore{buckets: make(map[string]*domain.TokenBucket)}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, limit domain.RateLimit, now time.Time) (domain.RateLimitDecision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &domain.TokenBucket{Key: key}
+		s.buckets[key] = bucket
+	}
+	return bucket.Take(limit, now), nil
+}
+
+func (s *MemoryStore) Release(_ context.Context, key string, limit domain.RateLimit) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if bucket, ok := s.buckets[key]; ok {
+		bucket.Release(limit)
+	}
+	return nil
+}
+
+func (s *MemoryStore) DeleteIdle(_ context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+	for key, bucket := range s.buckets {
+		if bucket.RefilledAt.Before(before) {
+			delete(s.buckets, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
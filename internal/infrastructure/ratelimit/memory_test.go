@@ -0,0 +1,35 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_TakeAndDeleteIdle(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	limit := domain.RateLimit{Limit: 1, Period: time.Second}
+	now := time.Now()
+
+	first, err := store.Take(ctx, "customer:cust-1", limit, now)
+	require.NoError(t, err)
+	assert.True(t, first.Allowed)
+
+	second, err := store.Take(ctx, "customer:cust-1", limit, now)
+	require.NoError(t, err)
+	assert.False(t, second.Allowed)
+	assert.Equal(t, time.Second, second.RetryAfter)
+
+	other, err := store.Take(ctx, "customer:cust-2", limit, now)
+	require.NoError(t, err)
+	assert.True(t, other.Allowed)
+
+	deleted, err := store.DeleteIdle(ctx, now.Add(time.Millisecond))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+}
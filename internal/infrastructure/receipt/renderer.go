@@ -0,0 +1,120 @@
+package receipt
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	texttemplate "text/template"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+)
+
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+var templateExtensions = map[domain.ReceiptFormat]string{
+	domain.ReceiptFormatHTML: "html",
+	domain.ReceiptFormatText: "txt",
+}
+
+type executor interface {
+	Execute(w io.Writer, data any) error
+}
+
+type TemplateRenderer struct {
+	dir      string
+	defaults map[domain.ReceiptFormat]executor
+
+	mu    sync.Mutex
+	cache map[string]executor
+}
+
+func NewTemplateRenderer(dir string) (*TemplateRenderer, error) {
+	r := &TemplateRenderer{
+		dir:      dir,
+		defaults: make(map[domain.ReceiptFormat]executor, len(templateExtensions)),
+		cache:    make(map[string]executor),
+	}
+	for format, ext := range templateExtensions {
+		content, err := defaultTemplates.ReadFile("templates/receipt." + ext + ".tmpl")
+		if err != nil {
+			return nil, err
+		}
+		tmpl, err := parse(format, string(content))
+		if err != nil {
+			return nil, err
+		}
+		r.defaults[format] = tmpl
+	}
+	return r, nil
+}
+
+func (r *TemplateRenderer) Render(tenant string, format domain.ReceiptFormat, receipt *domain.Receipt) ([]byte, error) {
+	tmpl, err := r.lookup(tenant, format, receipt.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, receipt); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (r *TemplateRenderer) lookup(tenant string, format domain.ReceiptFormat, lang string) (executor, error) {
+	ext, ok := templateExtensions[format]
+	if !ok {
+		return nil, errors.New("unsupported receipt format")
+	}
+	if r.dir == "" || tenant == "" {
+		return r.defaults[format], nil
+	}
+
+	for _, name := range []string{"receipt." + lang + "." + ext + ".tmpl", "receipt." + ext + ".tmpl"} {
+		tmpl, err := r.load(format, filepath.Join(r.dir, tenant, name))
+		if err != nil {
+			return nil, err
+		}
+		if tmpl != nil {
+			return tmpl, nil
+		}
+	}
+	return r.defaults[format], nil
+}
+
+func (r *TemplateRenderer) load(format domain.ReceiptFormat, path string) (executor, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if tmpl, ok := r.cache[path]; ok {
+		return tmpl, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := parse(format, string(content))
+	if err != nil {
+		return nil, err
+	}
+	r.cache[path] = tmpl
+	return tmpl, nil
+}
+
+func parse(format domain.ReceiptFormat, content string) (executor, error) {
+	if format == domain.ReceiptFormatHTML {
+		return htmltemplate.New("receipt").Option("missingkey=zero").Parse(content)
+	}
+	return texttemplate.New("receipt").Option("missingkey=zero").Parse(content)
+}
@@ -0,0 +1,79 @@
+package receipt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testReceipt(lang string) *domain.Receipt {
+	payment := &domain.Payment{
+		ID: "pay-1", Amount: 125000, Currency: domain.CurrencyIDR, RideID: "ride-<1>",
+		Status: domain.PaymentStatusSucceeded, CardBrand: domain.CardBrandVisa, CardLast4: "4242",
+		CreatedAt: time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC),
+	}
+	return domain.NewReceipt(payment, nil, lang, time.Now())
+}
+
+func TestTemplateRenderer_Defaults(t *testing.T) {
+	renderer, err := NewTemplateRenderer("")
+	require.NoError(t, err)
+
+	html, err := renderer.Render("", domain.ReceiptFormatHTML, testReceipt("en"))
+	require.NoError(t, err)
+	assert.Contains(t, string(html), "<h1>Payment receipt</h1>")
+	assert.Contains(t, string(html), "Rp 125.000")
+	assert.Contains(t, string(html), "VISA •••• 4242")
+	assert.Contains(t, string(html), "ride-&lt;1&gt;")
+
+	text, err := renderer.Render("acme", domain.ReceiptFormatText, testReceipt("es"))
+	require.NoError(t, err)
+	assert.Contains(t, string(text), "Recibo de pago")
+	assert.Contains(t, string(text), "Viaje: ride-<1>")
+	assert.Contains(t, string(text), "Tarjeta: VISA •••• 4242")
+}
+
+func TestTemplateRenderer_TenantOverrides(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "acme"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "acme", "receipt.txt.tmpl"),
+		[]byte("ACME {{.PaymentID}} {{.Amount}}"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "acme", "receipt.es.txt.tmpl"),
+		[]byte("ACME recibo {{.PaymentID}}"), 0o644))
+
+	renderer, err := NewTemplateRenderer(dir)
+	require.NoError(t, err)
+
+	body, err := renderer.Render("acme", domain.ReceiptFormatText, testReceipt("en"))
+	require.NoError(t, err)
+	assert.Equal(t, "ACME pay-1 Rp 125.000", string(body))
+
+	body, err = renderer.Render("acme", domain.ReceiptFormatText, testReceipt("es"))
+	require.NoError(t, err)
+	assert.Equal(t, "ACME recibo pay-1", string(body))
+
+	body, err = renderer.Render("acme", domain.ReceiptFormatHTML, testReceipt("en"))
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "<h1>Payment receipt</h1>", "formats without an override use the default")
+
+	body, err = renderer.Render("other", domain.ReceiptFormatText, testReceipt("en"))
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "Payment receipt")
+}
+
+func TestTemplateRenderer_InvalidOverride(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "broken"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken", "receipt.txt.tmpl"), []byte("{{.PaymentID"), 0o644))
+
+	renderer, err := NewTemplateRenderer(dir)
+	require.NoError(t, err)
+
+	_, err = renderer.Render("broken", domain.ReceiptFormatText, testReceipt("en"))
+	assert.Error(t, err)
+}
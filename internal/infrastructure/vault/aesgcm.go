@@ -0,0 +1,65 @@
+package vault
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+)
+
+var ErrCiphertextTooShort = errors.New("vault: ciphertext too short")
+
+type AESGCMVault struct {
+	aead           cipher.AEAD
+	fingerprintKey []byte
+}
+
+func NewAESGCMVault(secret string) (domain.CardVault, error) {
+	encryptionKey := deriveKey(secret, "encryption")
+	block, err := aes.NewCipher(encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &AESGCMVault{
+		aead:           aead,
+		fingerprintKey: deriveKey(secret, "fingerprint"),
+	}, nil
+}
+
+func (v *AESGCMVault) Encrypt(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, v.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return v.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (v *AESGCMVault) Decrypt(ciphertext []byte) ([]byte, error) {
+	size := v.aead.NonceSize()
+	if len(ciphertext) < size {
+		return nil, ErrCiphertextTooShort
+	}
+	return v.aead.Open(nil, ciphertext[:size], ciphertext[size:], nil)
+}
+
+func (v *AESGCMVault) Fingerprint(cardNumber string) string {
+	mac := hmac.New(sha256.New, v.fingerprintKey)
+	mac.Write([]byte(cardNumber))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func deriveKey(secret, purpose string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,61 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAESGCMVault_RoundTrip(t *testing.T) {
+	v, err := NewAESGCMVault("test-key")
+	require.NoError(t, err)
+
+	ciphertext, err := v.Encrypt([]byte("4111111111111111"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(ciphertext), "4111111111111111")
+
+	plaintext, err := v.Decrypt(ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "4111111111111111", string(plaintext))
+}
+
+func TestAESGCMVault_NonceMakesCiphertextUnique(t *testing.T) {
+	v, err := NewAESGCMVault("test-key")
+	require.NoError(t, err)
+
+	first, err := v.Encrypt([]byte("4111111111111111"))
+	require.NoError(t, err)
+	second, err := v.Encrypt([]byte("4111111111111111"))
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first, second)
+}
+
+func TestAESGCMVault_WrongKeyFails(t *testing.T) {
+	v1, err := NewAESGCMVault("key-one")
+	require.NoError(t, err)
+	v2, err := NewAESGCMVault("key-two")
+	require.NoError(t, err)
+
+	ciphertext, err := v1.Encrypt([]byte("4111111111111111"))
+	require.NoError(t, err)
+
+	_, err = v2.Decrypt(ciphertext)
+	assert.Error(t, err)
+
+	_, err = v1.Decrypt([]byte("short"))
+	assert.ErrorIs(t, err, ErrCiphertextTooShort)
+}
+
+func TestAESGCMVault_Fingerprint(t *testing.T) {
+	v1, err := NewAESGCMVault("key-one")
+	require.NoError(t, err)
+	v2, err := NewAESGCMVault("key-two")
+	require.NoError(t, err)
+
+	assert.Equal(t, v1.Fingerprint("4111111111111111"), v1.Fingerprint("4111111111111111"))
+	assert.NotEqual(t, v1.Fingerprint("4111111111111111"), v1.Fingerprint("5500000000000004"))
+	assert.NotEqual(t, v1.Fingerprint("4111111111111111"), v2.Fingerprint("4111111111111111"))
+	assert.Len(t, v1.Fingerprint("4111111111111111"), 64)
+}
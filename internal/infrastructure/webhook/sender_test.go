@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/utils/signature"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSend_SignsPayload(t *testing.T) {
+	payload := []byte(`{"id":"evt-1","type":"payment.succeeded"}`)
+	var verifyErr error
+	var receivedID string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		receivedID = r.Header.Get(HeaderWebhookID)
+		verifyErr = signature.Verify(
+			"whsec_test",
+			r.Header.Get(HeaderWebhookSignature),
+			r.Header.Get(HeaderWebhookTimestamp),
+			body,
+			5*time.Minute,
+			time.Now(),
+		)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sender := NewHTTPSender(time.Second)
+	code, err := sender.Send(context.Background(), &domain.WebhookEndpoint{URL: server.URL, Secret: "whsec_test"}, "evt-1", payload)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "evt-1", receivedID)
+	assert.NoError(t, verifyErr)
+}
+
+func TestSend_NonSuccessStatusReturnsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sender := NewHTTPSender(time.Second)
+	code, err := sender.Send(context.Background(), &domain.WebhookEndpoint{URL: server.URL, Secret: "s"}, "evt-1", []byte("{}"))
+
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+}
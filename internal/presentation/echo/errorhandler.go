@@ -2,10 +2,10 @@ package echo
 
 import (
 	"net/http"
-	"strings"
 
 	echofw "github.com/labstack/echo/v4"
 	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/utils/language"
 )
 
 func CustomHTTPErrorHandler(err error, c echofw.Context) {
@@ -13,7 +13,7 @@ func CustomHTTPErrorHandler(err error, c echofw.Context) {
 		return
 	}
 
-	lang := parseAcceptLanguage(c.Request().Header.Get("Accept-Language"))
+	lang := language.ParseAcceptLanguage(c.Request().Header.Get("Accept-Language"))
 
 	if appErr, ok := err.(*apperrors.AppError); ok {
 		localized := appErr.Localize(lang)
@@ -38,16 +38,3 @@ func CustomHTTPErrorHandler(err error, c echofw.Context) {
 		"message": internalErr.Message,
 	})
 }
-
-func parseAcceptLanguage(header string) string {
-	if header == "" {
-		return "en"
-	}
-	parts := strings.Split(header, ",")
-	if len(parts) == 0 {
-		return "en"
-	}
-	lang := strings.TrimSpace(parts[0])
-	lang = strings.Split(lang, ";")[0]
-	return lang
-}
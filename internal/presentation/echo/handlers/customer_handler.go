@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type CustomerHandler struct {
+	createCustomer       *use_cases.CreateCustomerUseCase
+	getCustomer          *use_cases.GetCustomerUseCase
+	listCustomers        *use_cases.ListCustomersUseCase
+	listCustomerPayments *use_cases.ListCustomerPaymentsUseCase
+}
+
+func NewCustomerHandler(container *use_cases.Container) *CustomerHandler {
+	return &CustomerHandler{
+		createCustomer:       container.CreateCustomer,
+		getCustomer:          container.GetCustomer,
+		listCustomers:        container.ListCustomers,
+		listCustomerPayments: container.ListCustomerPayments,
+	}
+}
+
+func (h *CustomerHandler) Create(c echo.Context) error {
+	var req domain.CustomerRequest
+	if err := c.Bind(&req); err != nil {
+		return apperrors.ErrInvalidCustomer("invalid request body")
+	}
+
+	customer, err := h.createCustomer.Execute(c.Request().Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, customer)
+}
+
+func (h *CustomerHandler) Get(c echo.Context) error {
+	customer, err := h.getCustomer.Execute(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, customer)
+}
+
+func (h *CustomerHandler) List(c echo.Context) error {
+	customers, err := h.listCustomers.Execute(c.Request().Context(), c.QueryParam("limit"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, customers)
+}
+
+func (h *CustomerHandler) ListPayments(c echo.Context) error {
+	payments, err := h.listCustomerPayments.Execute(c.Request().Context(), c.Param("id"), use_cases.ListPaymentsQuery{
+		RideID:      c.QueryParam("ride_id"),
+		Status:      c.QueryParam("status"),
+		Currency:    c.QueryParam("currency"),
+		CreatedFrom: c.QueryParam("created_from"),
+		CreatedTo:   c.QueryParam("created_to"),
+		Cursor:      c.QueryParam("cursor"),
+		Limit:       c.QueryParam("limit"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, payments)
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+)
+
+type LedgerHandler struct {
+	getBalances *use_cases.GetLedgerBalancesUseCase
+}
+
+func NewLedgerHandler(container *use_cases.Container) *LedgerHandler {
+	return &LedgerHandler{
+		getBalances: container.GetLedgerBalances,
+	}
+}
+
+func (h *LedgerHandler) Balances(c echo.Context) error {
+	balances, err := h.getBalances.Execute(c.Request().Context(), use_cases.LedgerBalanceQuery{
+		AccountID: c.QueryParam("account_id"),
+		Currency:  c.QueryParam("currency"),
+		AsOf:      c.QueryParam("as_of"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, balances)
+}
@@ -2,17 +2,26 @@ package handlers
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
 	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
 	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/utils/language"
 )
 
 type PaymentHandler struct {
 	createPayment       *use_cases.CreatePaymentUseCase
 	getPayment          *use_cases.GetPaymentUseCase
 	getByIdempotencyKey *use_cases.GetByIdempotencyKeyUseCase
+	listPayments        *use_cases.ListPaymentsUseCase
+	createTip           *use_cases.CreateTipUseCase
+	refundPayment       *use_cases.RefundPaymentUseCase
+	getRiskAssessment   *use_cases.GetRiskAssessmentUseCase
+	createPaymentBatch  *use_cases.CreatePaymentBatchUseCase
+	schedulePayment     *use_cases.SchedulePaymentUseCase
+	getReceipt          *use_cases.GetPaymentReceiptUseCase
 }
 
 func NewPaymentHandler(container *use_cases.Container) *PaymentHandler {
@@ -20,6 +29,13 @@ func NewPaymentHandler(container *use_cases.Container) *PaymentHandler {
 		createPayment:       container.CreatePayment,
 		getPayment:          container.GetPayment,
 		getByIdempotencyKey: container.GetByIdempotencyKey,
+		listPayments:        container.ListPayments,
+		createTip:           container.CreateTip,
+		refundPayment:       container.RefundPayment,
+		getRiskAssessment:   container.GetRiskAssessment,
+		createPaymentBatch:  container.CreatePaymentBatch,
+		schedulePayment:     container.SchedulePayment,
+		getReceipt:          container.GetPaymentReceipt,
 	}
 }
 
@@ -30,6 +46,9 @@ func (h *PaymentHandler) CreatePayment(c echo.Context) error {
 	if err := c.Bind(&req); err != nil {
 		return apperrors.ErrInvalidPaymentRequest("invalid request body")
 	}
+	if req.ScheduledAt != nil {
+		return h.schedule(c, idempotencyKey, req)
+	}
 
 	result, err := h.createPayment.Execute(c.Request().Context(), idempotencyKey, req)
 	if err != nil {
@@ -39,10 +58,51 @@ func (h *PaymentHandler) CreatePayment(c echo.Context) error {
 	if result.Replayed {
 		c.Response().Header().Set("X-Idempotent-Replayed", "true")
 	}
+	if result.Duplicate {
+		c.Response().Header().Set("X-Duplicate-Ride-Payment", "true")
+		return c.JSON(http.StatusOK, result.Payment)
+	}
+	if result.OutcomeUnknown() {
+		c.Response().Header().Set("X-Payment-Outcome-Unknown", "true")
+		return c.JSON(http.StatusAccepted, result.Payment)
+	}
 
 	return c.JSON(http.StatusCreated, result.Payment)
 }
 
+func (h *PaymentHandler) schedule(c echo.Context, idempotencyKey string, req domain.PaymentRequest) error {
+	result, err := h.schedulePayment.Execute(c.Request().Context(), idempotencyKey, req)
+	if err != nil {
+		return err
+	}
+
+	if result.Replayed {
+		c.Response().Header().Set("X-Idempotent-Replayed", "true")
+	}
+	c.Response().Header().Set(echo.HeaderLocation, "/v1/scheduled-payments/"+result.ScheduledPayment.ID)
+	return c.JSON(http.StatusAccepted, result.ScheduledPayment)
+}
+
+func (h *PaymentHandler) CreatePaymentBatch(c echo.Context) error {
+	idempotencyKey := c.Request().Header.Get("X-Idempotency-Key")
+
+	var req domain.BatchPaymentRequest
+	if err := c.Bind(&req); err != nil {
+		return apperrors.ErrInvalidBatchRequest("invalid request body")
+	}
+
+	result, err := h.createPaymentBatch.Execute(c.Request().Context(), idempotencyKey, req)
+	if err != nil {
+		return err
+	}
+
+	if result.Replayed {
+		c.Response().Header().Set("X-Idempotent-Replayed", "true")
+	}
+
+	return c.JSON(http.StatusOK, result.Response)
+}
+
 func (h *PaymentHandler) GetPayment(c echo.Context) error {
 	id := c.Param("id")
 
@@ -54,6 +114,105 @@ func (h *PaymentHandler) GetPayment(c echo.Context) error {
 	return c.JSON(http.StatusOK, payment)
 }
 
+func (h *PaymentHandler) CreateTip(c echo.Context) error {
+	idempotencyKey := c.Request().Header.Get("X-Idempotency-Key")
+
+	var req domain.TipRequest
+	if err := c.Bind(&req); err != nil {
+		return apperrors.ErrInvalidPaymentRequest("invalid request body")
+	}
+
+	result, err := h.createTip.Execute(c.Request().Context(), idempotencyKey, c.Param("id"), req)
+	if err != nil {
+		return err
+	}
+
+	if result.Replayed {
+		c.Response().Header().Set("X-Idempotent-Replayed", "true")
+	}
+
+	return c.JSON(http.StatusCreated, result.Payment)
+}
+
+func (h *PaymentHandler) Refund(c echo.Context) error {
+	idempotencyKey := c.Request().Header.Get("X-Idempotency-Key")
+
+	var req domain.RefundRequest
+	if err := c.Bind(&req); err != nil {
+		return apperrors.ErrInvalidRefund("invalid request body")
+	}
+
+	result, err := h.refundPayment.Execute(c.Request().Context(), idempotencyKey, c.Param("id"), req)
+	if err != nil {
+		return err
+	}
+
+	if result.Replayed {
+		c.Response().Header().Set("X-Idempotent-Replayed", "true")
+	}
+
+	return c.JSON(http.StatusCreated, result.Refund)
+}
+
+func (h *PaymentHandler) ListPayments(c echo.Context) error {
+	page, err := h.listPayments.Execute(c.Request().Context(), use_cases.ListPaymentsQuery{
+		CustomerID:  c.QueryParam("customer_id"),
+		RideID:      c.QueryParam("ride_id"),
+		Status:      c.QueryParam("status"),
+		Currency:    c.QueryParam("currency"),
+		CreatedFrom: c.QueryParam("created_from"),
+		CreatedTo:   c.QueryParam("created_to"),
+		Cursor:      c.QueryParam("cursor"),
+		Limit:       c.QueryParam("limit"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+func (h *PaymentHandler) GetRiskAssessment(c echo.Context) error {
+	assessment, err := h.getRiskAssessment.Execute(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, assessment)
+}
+
+func (h *PaymentHandler) GetReceipt(c echo.Context) error {
+	receipt, err := h.getReceipt.Execute(c.Request().Context(), c.Param("id"), use_cases.ReceiptQuery{
+		Language: language.ParseAcceptLanguage(c.Request().Header.Get("Accept-Language")),
+		Format:   receiptFormat(c),
+		Tenant:   c.Request().Header.Get("X-Tenant-ID"),
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("Content-Language", receipt.Language)
+	if receipt.Format == domain.ReceiptFormatText {
+		return c.Blob(http.StatusOK, echo.MIMETextPlainCharsetUTF8, receipt.Body)
+	}
+	return c.HTMLBlob(http.StatusOK, receipt.Body)
+}
+
+func receiptFormat(c echo.Context) string {
+	if format := c.QueryParam("format"); format != "" {
+		return format
+	}
+	for _, accepted := range strings.Split(c.Request().Header.Get("Accept"), ",") {
+		switch strings.TrimSpace(strings.Split(accepted, ";")[0]) {
+		case echo.MIMETextHTML:
+			return string(domain.ReceiptFormatHTML)
+		case echo.MIMETextPlain:
+			return string(domain.ReceiptFormatText)
+		}
+	}
+	return ""
+}
+
 func (h *PaymentHandler) GetByIdempotencyKey(c echo.Context) error {
 	key := c.Param("key")
 
@@ -18,7 +18,7 @@ import (
 func samplePayment() *domain.Payment {
 	return &domain.Payment{
 		ID:         "pay-123",
-		Amount:     100.0,
+		Amount:     100,
 		Currency:   domain.CurrencyIDR,
 		CustomerID: "cust-1",
 		RideID:     "ride-1",
@@ -66,6 +66,21 @@ func TestGetPayment_ValidID_Returns200(t *testing.T) {
 	var resp domain.Payment
 	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
 	assert.Equal(t, "pay-123", resp.ID)
+	assert.Equal(t, int64(100), resp.Amount)
+}
+
+func TestPaymentJSON_CompatibleAmountEncoding(t *testing.T) {
+	payment := samplePayment()
+	payment.Amount = 45025
+	payment.Currency = domain.CurrencyTHB
+
+	body, err := json.Marshal(payment)
+	assert.NoError(t, err)
+
+	var raw map[string]any
+	assert.NoError(t, json.Unmarshal(body, &raw))
+	assert.Equal(t, 450.25, raw["amount"])
+	assert.Equal(t, float64(45025), raw["amount_minor"])
 }
 
 func TestGetByIdempotencyKey_Response(t *testing.T) {
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type PaymentJobHandler struct {
+	createJob *use_cases.CreatePaymentJobUseCase
+	getJob    *use_cases.GetPaymentJobUseCase
+}
+
+func NewPaymentJobHandler(container *use_cases.Container) *PaymentJobHandler {
+	return &PaymentJobHandler{
+		createJob: container.CreatePaymentJob,
+		getJob:    container.GetPaymentJob,
+	}
+}
+
+func (h *PaymentJobHandler) Create(c echo.Context) error {
+	fileName, content, err := readUploadedFile(c, apperrors.ErrInvalidPaymentJobFile)
+	if err != nil {
+		return err
+	}
+
+	job, err := h.createJob.Execute(c.Request().Context(), use_cases.CreatePaymentJobCommand{
+		FileName: fileName,
+		Content:  content,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, "/v1/jobs/"+job.ID)
+	return c.JSON(http.StatusAccepted, job)
+}
+
+func (h *PaymentJobHandler) Get(c echo.Context) error {
+	job, err := h.getJob.Execute(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
+
+func (h *PaymentJobHandler) Result(c echo.Context) error {
+	id := c.Param("id")
+	content, err := h.getJob.Result(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="payment-job-`+id+`-result.csv"`)
+	return c.Blob(http.StatusOK, "text/csv; charset=utf-8", content)
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type PaymentMethodHandler struct {
+	createPaymentMethod *use_cases.CreatePaymentMethodUseCase
+	listPaymentMethods  *use_cases.ListPaymentMethodsUseCase
+	deletePaymentMethod *use_cases.DeletePaymentMethodUseCase
+}
+
+func NewPaymentMethodHandler(container *use_cases.Container) *PaymentMethodHandler {
+	return &PaymentMethodHandler{
+		createPaymentMethod: container.CreatePaymentMethod,
+		listPaymentMethods:  container.ListPaymentMethods,
+		deletePaymentMethod: container.DeletePaymentMethod,
+	}
+}
+
+func (h *PaymentMethodHandler) Create(c echo.Context) error {
+	var req domain.PaymentMethodRequest
+	if err := c.Bind(&req); err != nil {
+		return apperrors.ErrInvalidPaymentMethod("invalid request body")
+	}
+
+	result, err := h.createPaymentMethod.Execute(c.Request().Context(), c.Param("id"), req)
+	if err != nil {
+		return err
+	}
+
+	if result.Existing {
+		return c.JSON(http.StatusOK, result.PaymentMethod)
+	}
+	return c.JSON(http.StatusCreated, result.PaymentMethod)
+}
+
+func (h *PaymentMethodHandler) List(c echo.Context) error {
+	methods, err := h.listPaymentMethods.Execute(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, methods)
+}
+
+func (h *PaymentMethodHandler) Delete(c echo.Context) error {
+	if err := h.deletePaymentMethod.Execute(c.Request().Context(), c.Param("id"), c.Param("method_id")); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
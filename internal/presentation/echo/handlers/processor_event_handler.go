@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+const (
+	HeaderProcessorSignature = "X-Processor-Signature"
+	HeaderProcessorTimestamp = "X-Processor-Timestamp"
+)
+
+type processorEventResponse struct {
+	*domain.ProcessorEvent
+	Duplicate bool `json:"duplicate"`
+}
+
+type ProcessorEventHandler struct {
+	handleProcessorEvent *use_cases.HandleProcessorEventUseCase
+}
+
+func NewProcessorEventHandler(container *use_cases.Container) *ProcessorEventHandler {
+	return &ProcessorEventHandler{
+		handleProcessorEvent: container.HandleProcessorEvent,
+	}
+}
+
+func (h *ProcessorEventHandler) Receive(c echo.Context) error {
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return apperrors.ErrInvalidProcessorEvent("invalid request body")
+	}
+
+	result, err := h.handleProcessorEvent.Execute(
+		c.Request().Context(),
+		body,
+		c.Request().Header.Get(HeaderProcessorSignature),
+		c.Request().Header.Get(HeaderProcessorTimestamp),
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, processorEventResponse{
+		ProcessorEvent: result.Event,
+		Duplicate:      result.Duplicate,
+	})
+}
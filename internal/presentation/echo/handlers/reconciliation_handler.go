@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type ReconciliationHandler struct {
+	reconcile         *use_cases.ReconcileSettlementUseCase
+	getReconciliation *use_cases.GetReconciliationUseCase
+	generateFile      *use_cases.GenerateSettlementFileUseCase
+}
+
+func NewReconciliationHandler(container *use_cases.Container) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		reconcile:         container.ReconcileSettlement,
+		getReconciliation: container.GetReconciliation,
+		generateFile:      container.GenerateSettlementFile,
+	}
+}
+
+func (h *ReconciliationHandler) Create(c echo.Context) error {
+	fileName, content, err := readUploadedFile(c, apperrors.ErrInvalidSettlementFile)
+	if err != nil {
+		return err
+	}
+
+	reconciliation, err := h.reconcile.Execute(c.Request().Context(), use_cases.ReconcileSettlementCommand{
+		SettlementDate: c.QueryParam("settlement_date"),
+		FileName:       fileName,
+		Content:        content,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, reconciliation)
+}
+
+func (h *ReconciliationHandler) Get(c echo.Context) error {
+	reconciliation, err := h.getReconciliation.Execute(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, reconciliation)
+}
+
+func (h *ReconciliationHandler) SettlementFile(c echo.Context) error {
+	settlementDate := c.QueryParam("settlement_date")
+	content, err := h.generateFile.Execute(c.Request().Context(), settlementDate)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="settlement-`+settlementDate+`.csv"`)
+	return c.Blob(http.StatusOK, "text/csv; charset=utf-8", content)
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+)
+
+type ScheduledPaymentHandler struct {
+	list   *use_cases.ListScheduledPaymentsUseCase
+	get    *use_cases.GetScheduledPaymentUseCase
+	cancel *use_cases.CancelScheduledPaymentUseCase
+}
+
+func NewScheduledPaymentHandler(container *use_cases.Container) *ScheduledPaymentHandler {
+	return &ScheduledPaymentHandler{
+		list:   container.ListScheduledPayments,
+		get:    container.GetScheduledPayment,
+		cancel: container.CancelScheduledPayment,
+	}
+}
+
+func (h *ScheduledPaymentHandler) List(c echo.Context) error {
+	scheduled, err := h.list.Execute(c.Request().Context(), use_cases.ListScheduledPaymentsQuery{
+		CustomerID: c.QueryParam("customer_id"),
+		Status:     c.QueryParam("status"),
+		Limit:      c.QueryParam("limit"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, scheduled)
+}
+
+func (h *ScheduledPaymentHandler) Get(c echo.Context) error {
+	scheduled, err := h.get.Execute(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, scheduled)
+}
+
+func (h *ScheduledPaymentHandler) Cancel(c echo.Context) error {
+	scheduled, err := h.cancel.Execute(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, scheduled)
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type SubscriptionHandler struct {
+	createPlan         *use_cases.CreatePlanUseCase
+	getPlan            *use_cases.GetPlanUseCase
+	listPlans          *use_cases.ListPlansUseCase
+	createSubscription *use_cases.CreateSubscriptionUseCase
+	getSubscription    *use_cases.GetSubscriptionUseCase
+	listSubscriptions  *use_cases.ListSubscriptionsUseCase
+	transition         *use_cases.TransitionSubscriptionUseCase
+}
+
+func NewSubscriptionHandler(container *use_cases.Container) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		createPlan:         container.CreatePlan,
+		getPlan:            container.GetPlan,
+		listPlans:          container.ListPlans,
+		createSubscription: container.CreateSubscription,
+		getSubscription:    container.GetSubscription,
+		listSubscriptions:  container.ListSubscriptions,
+		transition:         container.TransitionSubscription,
+	}
+}
+
+func (h *SubscriptionHandler) CreatePlan(c echo.Context) error {
+	var req domain.PlanRequest
+	if err := c.Bind(&req); err != nil {
+		return apperrors.ErrInvalidPlan("invalid request body")
+	}
+
+	plan, err := h.createPlan.Execute(c.Request().Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, plan)
+}
+
+func (h *SubscriptionHandler) GetPlan(c echo.Context) error {
+	plan, err := h.getPlan.Execute(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, plan)
+}
+
+func (h *SubscriptionHandler) ListPlans(c echo.Context) error {
+	plans, err := h.listPlans.Execute(c.Request().Context(), c.QueryParam("limit"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, plans)
+}
+
+func (h *SubscriptionHandler) Create(c echo.Context) error {
+	var req domain.SubscriptionRequest
+	if err := c.Bind(&req); err != nil {
+		return apperrors.ErrInvalidSubscription("invalid request body")
+	}
+
+	sub, err := h.createSubscription.Execute(c.Request().Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, sub)
+}
+
+func (h *SubscriptionHandler) Get(c echo.Context) error {
+	sub, err := h.getSubscription.Execute(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, sub)
+}
+
+func (h *SubscriptionHandler) List(c echo.Context) error {
+	subs, err := h.listSubscriptions.Execute(c.Request().Context(), use_cases.ListSubscriptionsQuery{
+		CustomerID: c.QueryParam("customer_id"),
+		Status:     c.QueryParam("status"),
+		Limit:      c.QueryParam("limit"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, subs)
+}
+
+func (h *SubscriptionHandler) Pause(c echo.Context) error {
+	return h.apply(c, domain.SubscriptionActionPause)
+}
+
+func (h *SubscriptionHandler) Resume(c echo.Context) error {
+	return h.apply(c, domain.SubscriptionActionResume)
+}
+
+func (h *SubscriptionHandler) Cancel(c echo.Context) error {
+	return h.apply(c, domain.SubscriptionActionCancel)
+}
+
+func (h *SubscriptionHandler) apply(c echo.Context, action domain.SubscriptionAction) error {
+	sub, err := h.transition.Execute(c.Request().Context(), c.Param("id"), action)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, sub)
+}
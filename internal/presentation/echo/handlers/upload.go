@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"io"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+const maxUploadSize = 10 << 20
+
+func readUploadedFile(c echo.Context, invalid func(detail string) *apperrors.AppError) (string, []byte, error) {
+	var (
+		name   string
+		source io.Reader
+	)
+
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		header, err := c.FormFile("file")
+		if err != nil {
+			return "", nil, invalid("multipart field \"file\" is required")
+		}
+		file, err := header.Open()
+		if err != nil {
+			return "", nil, invalid("file could not be read")
+		}
+		defer file.Close()
+		name, source = header.Filename, file
+	} else {
+		source = c.Request().Body
+	}
+
+	content, err := io.ReadAll(io.LimitReader(source, maxUploadSize+1))
+	if err != nil {
+		return "", nil, invalid("file could not be read")
+	}
+	if len(content) > maxUploadSize {
+		return "", nil, invalid("file exceeds 10 MiB")
+	}
+	if len(content) == 0 {
+		return "", nil, invalid("file is empty")
+	}
+	return name, content, nil
+}
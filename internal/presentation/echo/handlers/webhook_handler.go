@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+)
+
+type WebhookHandler struct {
+	registerEndpoint *use_cases.RegisterWebhookEndpointUseCase
+	listEndpoints    *use_cases.ListWebhookEndpointsUseCase
+	deleteEndpoint   *use_cases.DeleteWebhookEndpointUseCase
+	listDeliveries   *use_cases.ListWebhookDeliveriesUseCase
+	redeliverEvent   *use_cases.RedeliverEventUseCase
+}
+
+func NewWebhookHandler(container *use_cases.Container) *WebhookHandler {
+	return &WebhookHandler{
+		registerEndpoint: container.RegisterWebhookEndpoint,
+		listEndpoints:    container.ListWebhookEndpoints,
+		deleteEndpoint:   container.DeleteWebhookEndpoint,
+		listDeliveries:   container.ListWebhookDeliveries,
+		redeliverEvent:   container.RedeliverEvent,
+	}
+}
+
+func (h *WebhookHandler) RegisterEndpoint(c echo.Context) error {
+	var req domain.WebhookEndpointRequest
+	if err := c.Bind(&req); err != nil {
+		return apperrors.ErrInvalidWebhookEndpoint("invalid request body")
+	}
+
+	endpoint, err := h.registerEndpoint.Execute(c.Request().Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, endpoint)
+}
+
+func (h *WebhookHandler) ListEndpoints(c echo.Context) error {
+	endpoints, err := h.listEndpoints.Execute(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, endpoints)
+}
+
+func (h *WebhookHandler) DeleteEndpoint(c echo.Context) error {
+	if err := h.deleteEndpoint.Execute(c.Request().Context(), c.Param("id")); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *WebhookHandler) ListDeliveries(c echo.Context) error {
+	status := domain.WebhookDeliveryStatus(c.QueryParam("status"))
+
+	deliveries, err := h.listDeliveries.Execute(c.Request().Context(), status)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, deliveries)
+}
+
+func (h *WebhookHandler) RedeliverEvent(c echo.Context) error {
+	deliveries, err := h.redeliverEvent.Execute(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusAccepted, deliveries)
+}
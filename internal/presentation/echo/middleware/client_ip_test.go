@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func spoofedRequest(remoteAddr string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/v1/payments", nil)
+	req.RemoteAddr = remoteAddr
+	req.Header.Set(echo.HeaderXForwardedFor, "203.0.113.9, 198.51.100.7")
+	req.Header.Set(echo.HeaderXRealIP, "203.0.113.9")
+	return req
+}
+
+func TestIPExtractor_IgnoresForwardedHeadersWithoutTrustedProxies(t *testing.T) {
+	extract, err := IPExtractor("")
+	require.NoError(t, err)
+
+	assert.Equal(t, "192.0.2.1", extract(spoofedRequest("192.0.2.1:4321")))
+	assert.Equal(t, "10.0.0.5", extract(spoofedRequest("10.0.0.5:4321")), "private addresses are not trusted by default")
+}
+
+func TestIPExtractor_ReadsForwardedForOnlyFromTrustedProxies(t *testing.T) {
+	extract, err := IPExtractor("10.0.0.0/8, 192.0.2.50")
+	require.NoError(t, err)
+
+	assert.Equal(t, "198.51.100.7", extract(spoofedRequest("10.1.2.3:4321")))
+	assert.Equal(t, "198.51.100.7", extract(spoofedRequest("192.0.2.50:4321")))
+	assert.Equal(t, "192.0.2.1", extract(spoofedRequest("192.0.2.1:4321")))
+
+	_, err = IPExtractor("10.0.0.0/40")
+	assert.Error(t, err)
+}
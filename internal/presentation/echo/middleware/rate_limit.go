@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+)
+
+const maxRateLimitPeekSize = 64 << 10
+
+func RateLimit(uc *use_cases.CheckRateLimitUseCase) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if uc == nil {
+			return next
+		}
+		return func(c echo.Context) error {
+			decision, err := uc.Execute(c.Request().Context(), use_cases.RateLimitSubject{
+				APIKey:     c.Request().Header.Get("X-Api-Key"),
+				CustomerID: peekCustomerID(c.Request()),
+				IP:         c.RealIP(),
+			})
+			if decision != nil {
+				header := c.Response().Header()
+				header.Set("RateLimit-Limit", strconv.Itoa(decision.Limit))
+				header.Set("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+				header.Set("RateLimit-Reset", ceilSeconds(decision.ResetAfter))
+				if !decision.Allowed {
+					header.Set("Retry-After", ceilSeconds(decision.RetryAfter))
+				}
+			}
+			if err != nil {
+				return err
+			}
+			return next(c)
+		}
+	}
+}
+
+func peekCustomerID(req *http.Request) string {
+	if req.Body == nil || req.ContentLength > maxRateLimitPeekSize ||
+		!strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		return ""
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxRateLimitPeekSize+1))
+	req.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), req.Body))
+	if err != nil || len(body) > maxRateLimitPeekSize {
+		return ""
+	}
+
+	var payload struct {
+		CustomerID string `json:"customer_id"`
+	}
+	if json.Unmarshal(body, &payload) != nil {
+		return ""
+	}
+	return payload.CustomerID
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRateLimitedHandler(policy use_cases.RateLimitPolicy) echo.HandlerFunc {
+	uc := use_cases.NewCheckRateLimitUseCase(ratelimit.NewMemoryStore(), policy)
+	return RateLimit(uc)(func(c echo.Context) error {
+		body, _ := io.ReadAll(c.Request().Body)
+		return c.String(http.StatusOK, string(body))
+	})
+}
+
+func serveRateLimited(handler echo.HandlerFunc, body string) (*httptest.ResponseRecorder, error) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/v1/payments", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-Api-Key", "key-1")
+	rec := httptest.NewRecorder()
+	return rec, handler(e.NewContext(req, rec))
+}
+
+func TestRateLimit_SetsHeadersAndRejectsWhenExhausted(t *testing.T) {
+	handler := newRateLimitedHandler(use_cases.RateLimitPolicy{
+		domain.RateLimitScopeIP: {Limit: 1, Period: time.Minute},
+	})
+
+	rec, err := serveRateLimited(handler, `{}`)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "60", rec.Header().Get("RateLimit-Reset"))
+	assert.Empty(t, rec.Header().Get("Retry-After"))
+
+	rec, err = serveRateLimited(handler, `{}`)
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusTooManyRequests, appErr.HTTPCode)
+	assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+	assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+}
+
+func TestRateLimit_CustomerScopeReadsBodyWithoutConsumingIt(t *testing.T) {
+	handler := newRateLimitedHandler(use_cases.RateLimitPolicy{
+		domain.RateLimitScopeCustomer: {Limit: 1, Period: time.Minute},
+		domain.RateLimitScopeAPIKey:   {Limit: 10, Period: time.Minute},
+	})
+
+	body := `{"customer_id":"cust-001","amount":100}`
+	rec, err := serveRateLimited(handler, body)
+	require.NoError(t, err)
+	assert.Equal(t, body, rec.Body.String())
+	assert.Equal(t, "1", rec.Header().Get("RateLimit-Limit"))
+
+	_, err = serveRateLimited(handler, `{"customer_id":"cust-002"}`)
+	require.NoError(t, err)
+
+	_, err = serveRateLimited(handler, body)
+	assert.Error(t, err)
+}
+
+func TestRateLimit_NilUseCasePassesThrough(t *testing.T) {
+	handler := RateLimit(nil)(func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	rec, err := serveRateLimited(handler, `{}`)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, rec.Header().Get("RateLimit-Limit"))
+}
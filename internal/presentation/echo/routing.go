@@ -16,8 +16,66 @@ func ConfigureRoutes(e *echofw.Echo, container *use_cases.Container) {
 	e.GET("/health", healthHandler.Check)
 
 	paymentHandler := handlers.NewPaymentHandler(container)
-	v1 := e.Group("/v1")
+	v1 := e.Group("/v1", middleware.RateLimit(container.CheckRateLimit))
 	v1.POST("/payments", paymentHandler.CreatePayment)
+	v1.POST("/payments/batch", paymentHandler.CreatePaymentBatch)
+	v1.GET("/payments", paymentHandler.ListPayments)
 	v1.GET("/payments/:id", paymentHandler.GetPayment)
+	v1.POST("/payments/:id/tips", paymentHandler.CreateTip)
+	v1.POST("/payments/:id/refunds", paymentHandler.Refund)
+	v1.GET("/payments/:id/risk", paymentHandler.GetRiskAssessment)
+	v1.GET("/payments/:id/receipt", paymentHandler.GetReceipt)
 	v1.GET("/idempotency/:key", paymentHandler.GetByIdempotencyKey)
+
+	webhookHandler := handlers.NewWebhookHandler(container)
+	v1.POST("/webhooks", webhookHandler.RegisterEndpoint)
+	v1.GET("/webhooks", webhookHandler.ListEndpoints)
+	v1.DELETE("/webhooks/:id", webhookHandler.DeleteEndpoint)
+
+	customerHandler := handlers.NewCustomerHandler(container)
+	v1.POST("/customers", customerHandler.Create)
+	v1.GET("/customers", customerHandler.List)
+	v1.GET("/customers/:id", customerHandler.Get)
+	v1.GET("/customers/:id/payments", customerHandler.ListPayments)
+
+	paymentMethodHandler := handlers.NewPaymentMethodHandler(container)
+	v1.POST("/customers/:id/payment-methods", paymentMethodHandler.Create)
+	v1.GET("/customers/:id/payment-methods", paymentMethodHandler.List)
+	v1.DELETE("/customers/:id/payment-methods/:method_id", paymentMethodHandler.Delete)
+
+	scheduledPaymentHandler := handlers.NewScheduledPaymentHandler(container)
+	v1.GET("/scheduled-payments", scheduledPaymentHandler.List)
+	v1.GET("/scheduled-payments/:id", scheduledPaymentHandler.Get)
+	v1.POST("/scheduled-payments/:id/cancel", scheduledPaymentHandler.Cancel)
+
+	subscriptionHandler := handlers.NewSubscriptionHandler(container)
+	v1.POST("/plans", subscriptionHandler.CreatePlan)
+	v1.GET("/plans", subscriptionHandler.ListPlans)
+	v1.GET("/plans/:id", subscriptionHandler.GetPlan)
+	v1.POST("/subscriptions", subscriptionHandler.Create)
+	v1.GET("/subscriptions", subscriptionHandler.List)
+	v1.GET("/subscriptions/:id", subscriptionHandler.Get)
+	v1.POST("/subscriptions/:id/pause", subscriptionHandler.Pause)
+	v1.POST("/subscriptions/:id/resume", subscriptionHandler.Resume)
+	v1.POST("/subscriptions/:id/cancel", subscriptionHandler.Cancel)
+
+	paymentJobHandler := handlers.NewPaymentJobHandler(container)
+	v1.POST("/jobs", paymentJobHandler.Create)
+	v1.GET("/jobs/:id", paymentJobHandler.Get)
+	v1.GET("/jobs/:id/result", paymentJobHandler.Result)
+
+	ledgerHandler := handlers.NewLedgerHandler(container)
+	v1.GET("/ledger/balances", ledgerHandler.Balances)
+
+	processorEventHandler := handlers.NewProcessorEventHandler(container)
+	e.POST("/v1/processor-events", processorEventHandler.Receive)
+
+	admin := v1.Group("/admin")
+	admin.GET("/webhook-deliveries", webhookHandler.ListDeliveries)
+	admin.POST("/events/:id/redeliver", webhookHandler.RedeliverEvent)
+
+	reconciliationHandler := handlers.NewReconciliationHandler(container)
+	admin.POST("/reconciliations", reconciliationHandler.Create)
+	admin.GET("/reconciliations/:id", reconciliationHandler.Get)
+	admin.GET("/simulator/settlement-file", reconciliationHandler.SettlementFile)
 }
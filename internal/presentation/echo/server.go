@@ -10,6 +10,7 @@ import (
 
 	echofw "github.com/labstack/echo/v4"
 	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/presentation/echo/middleware"
 	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/utils/config"
 )
 
@@ -18,17 +19,23 @@ type Server struct {
 	config *config.Config
 }
 
-func NewServer(cfg *config.Config, container *use_cases.Container) *Server {
+func NewServer(cfg *config.Config, container *use_cases.Container) (*Server, error) {
+	ipExtractor, err := middleware.IPExtractor(cfg.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
 	e := echofw.New()
 	e.HideBanner = true
 	e.HTTPErrorHandler = CustomHTTPErrorHandler
+	e.IPExtractor = ipExtractor
 
 	ConfigureRoutes(e, container)
 
 	return &Server{
 		echo:   e,
 		config: cfg,
-	}
+	}, nil
 }
 
 func (s *Server) Start() <-chan error {
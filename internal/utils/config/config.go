@@ -1,7 +1,11 @@
 package config
 
 import (
+	"fmt"
+	"log"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -15,18 +19,106 @@ const (
 	EnvProduction  Environment = "prod"
 )
 
+const (
+	DevProcessorWebhookSecret     = "processor_dev_secret"
+	DevPaymentMethodEncryptionKey = "payment_methods_dev_key"
+)
+
 type Config struct {
-	AppEnv           Environment
-	AppPort          string
-	DBHost           string
-	DBPort           string
-	DBUser           string
-	DBPassword       string
-	DBName           string
-	DBSSLMode        string
+	AppEnv            Environment
+	AppPort           string
+	DBHost            string
+	DBPort            string
+	DBUser            string
+	DBPassword        string
+	DBName            string
+	DBSSLMode         string
 	IdempotencyKeyTTL time.Duration
-	CleanupInterval  time.Duration
-	GracefulTimeout  time.Duration
+	CleanupInterval   time.Duration
+	GracefulTimeout   time.Duration
+
+	PendingCheckInterval   time.Duration
+	PendingBackoffBase     time.Duration
+	PendingBackoffMax      time.Duration
+	PendingMaxAge          time.Duration
+	PendingBatchSize       int
+	SimulatorPendingSettle time.Duration
+
+	WebhookDispatchInterval time.Duration
+	WebhookTimeout          time.Duration
+	WebhookMaxAttempts      int
+	WebhookBackoffBase      time.Duration
+	WebhookBackoffMax       time.Duration
+	WebhookBatchSize        int
+
+	ProcessorWebhookSecret   string
+	ProcessorEventTolerance  time.Duration
+	SimulatorCallbackURL     string
+	SimulatorCallbackRepeats int
+
+	CurrencySource          string
+	Currencies              string
+	CurrencyRefreshInterval time.Duration
+
+	DuplicateRidePolicy string
+	DuplicateRideWindow time.Duration
+
+	PaymentMethodEncryptionKey string
+
+	RequireExistingCustomer bool
+
+	TipMaxRatio float64
+
+	LedgerProcessorFeeBps int
+
+	RiskVelocityWindow        time.Duration
+	RiskVelocityCustomerLimit int
+	RiskVelocityCardLimit     int
+	RiskMaxAmounts            string
+	RiskBlockedBINs           string
+	RiskBINCountries          string
+
+	RateLimitStore       string
+	RateLimitPeriod      time.Duration
+	RateLimitPerAPIKey   int
+	RateLimitPerCustomer int
+	RateLimitPerIP       int
+	TrustedProxies       string
+
+	BatchMaxItems    int
+	BatchConcurrency int
+
+	JobWorkerInterval time.Duration
+	JobChunkSize      int
+	JobConcurrency    int
+	JobMaxRows        int
+
+	SchedulerInterval  time.Duration
+	SchedulerBatchSize int
+	ScheduleMaxAhead   time.Duration
+
+	SubscriptionBillingInterval time.Duration
+	SubscriptionBatchSize       int
+	SubscriptionDunningSchedule string
+
+	ReceiptTemplateDir string
+
+	Processors                string
+	ProcessorRoutes           string
+	ProcessorWeights          string
+	SimulatorUnavailableRates string
+
+	ProcessorTimeout               time.Duration
+	ProcessorBreakerFailures       int
+	ProcessorBreakerOpenFor        time.Duration
+	ProcessorBreakerHalfOpenProbes int
+
+	UnknownCheckInterval   time.Duration
+	UnknownBackoffBase     time.Duration
+	UnknownBackoffMax      time.Duration
+	UnknownNotChargedGrace time.Duration
+	UnknownMaxAge          time.Duration
+	UnknownBatchSize       int
 }
 
 func (c *Config) IsDev() bool {
@@ -45,18 +137,121 @@ func Load() *Config {
 	_ = godotenv.Load()
 
 	return &Config{
-		AppEnv:           parseEnv(getEnv("APP_ENV", "dev")),
-		AppPort:          getEnv("APP_PORT", "8080"),
-		DBHost:           getEnv("DB_HOST", "localhost"),
-		DBPort:           getEnv("DB_PORT", "5432"),
-		DBUser:           getEnv("DB_USER", "idempotency"),
-		DBPassword:       getEnv("DB_PASSWORD", "idempotency123"),
-		DBName:           getEnv("DB_NAME", "idempotency_db"),
-		DBSSLMode:        getEnv("DB_SSLMODE", "disable"),
+		AppEnv:            parseEnv(getEnv("APP_ENV", "dev")),
+		AppPort:           getEnv("APP_PORT", "8080"),
+		DBHost:            getEnv("DB_HOST", "localhost"),
+		DBPort:            getEnv("DB_PORT", "5432"),
+		DBUser:            getEnv("DB_USER", "idempotency"),
+		DBPassword:        getEnv("DB_PASSWORD", "idempotency123"),
+		DBName:            getEnv("DB_NAME", "idempotency_db"),
+		DBSSLMode:         getEnv("DB_SSLMODE", "disable"),
 		IdempotencyKeyTTL: parseDuration(getEnv("IDEMPOTENCY_KEY_TTL", "24h"), 24*time.Hour),
-		CleanupInterval:  parseDuration(getEnv("CLEANUP_INTERVAL", "1h"), time.Hour),
-		GracefulTimeout:  parseDuration(getEnv("GRACEFUL_TIMEOUT", "5s"), 5*time.Second),
+		CleanupInterval:   parseDuration(getEnv("CLEANUP_INTERVAL", "1h"), time.Hour),
+		GracefulTimeout:   parseDuration(getEnv("GRACEFUL_TIMEOUT", "5s"), 5*time.Second),
+
+		PendingCheckInterval:   parseDuration(getEnv("PENDING_CHECK_INTERVAL", "10s"), 10*time.Second),
+		PendingBackoffBase:     parseDuration(getEnv("PENDING_BACKOFF_BASE", "5s"), 5*time.Second),
+		PendingBackoffMax:      parseDuration(getEnv("PENDING_BACKOFF_MAX", "5m"), 5*time.Minute),
+		PendingMaxAge:          parseDuration(getEnv("PENDING_MAX_AGE", "1h"), time.Hour),
+		PendingBatchSize:       parseInt(getEnv("PENDING_BATCH_SIZE", "50"), 50),
+		SimulatorPendingSettle: parseDuration(getEnv("SIMULATOR_PENDING_SETTLE", "30s"), 30*time.Second),
+
+		WebhookDispatchInterval: parseDuration(getEnv("WEBHOOK_DISPATCH_INTERVAL", "5s"), 5*time.Second),
+		WebhookTimeout:          parseDuration(getEnv("WEBHOOK_TIMEOUT", "10s"), 10*time.Second),
+		WebhookMaxAttempts:      parseInt(getEnv("WEBHOOK_MAX_ATTEMPTS", "8"), 8),
+		WebhookBackoffBase:      parseDuration(getEnv("WEBHOOK_BACKOFF_BASE", "10s"), 10*time.Second),
+		WebhookBackoffMax:       parseDuration(getEnv("WEBHOOK_BACKOFF_MAX", "1h"), time.Hour),
+		WebhookBatchSize:        parseInt(getEnv("WEBHOOK_BATCH_SIZE", "50"), 50),
+
+		ProcessorWebhookSecret:   getEnv("PROCESSOR_WEBHOOK_SECRET", DevProcessorWebhookSecret),
+		ProcessorEventTolerance:  parseDuration(getEnv("PROCESSOR_EVENT_TOLERANCE", "5m"), 5*time.Minute),
+		SimulatorCallbackURL:     getEnv("SIMULATOR_CALLBACK_URL", ""),
+		SimulatorCallbackRepeats: parseInt(getEnv("SIMULATOR_CALLBACK_REPEATS", "1"), 1),
+
+		CurrencySource:          getEnv("CURRENCY_SOURCE", "config"),
+		Currencies:              getEnv("CURRENCIES", ""),
+		CurrencyRefreshInterval: parseDuration(getEnv("CURRENCY_REFRESH_INTERVAL", "1m"), time.Minute),
+
+		DuplicateRidePolicy: getEnv("DUPLICATE_RIDE_POLICY", "off"),
+		DuplicateRideWindow: parseDuration(getEnv("DUPLICATE_RIDE_WINDOW", "10m"), 10*time.Minute),
+
+		PaymentMethodEncryptionKey: getEnv("PAYMENT_METHOD_ENCRYPTION_KEY", DevPaymentMethodEncryptionKey),
+
+		RequireExistingCustomer: parseBool(getEnv("REQUIRE_EXISTING_CUSTOMER", "false"), false),
+
+		TipMaxRatio: parseFloat(getEnv("TIP_MAX_RATIO", "0.5"), 0.5),
+
+		LedgerProcessorFeeBps: parseInt(getEnv("LEDGER_PROCESSOR_FEE_BPS", "0"), 0),
+
+		RiskVelocityWindow:        parseDuration(getEnv("RISK_VELOCITY_WINDOW", "1h"), time.Hour),
+		RiskVelocityCustomerLimit: parseInt(getEnv("RISK_VELOCITY_CUSTOMER_LIMIT", "0"), 0),
+		RiskVelocityCardLimit:     parseInt(getEnv("RISK_VELOCITY_CARD_LIMIT", "0"), 0),
+		RiskMaxAmounts:            getEnv("RISK_MAX_AMOUNTS", ""),
+		RiskBlockedBINs:           getEnv("RISK_BLOCKED_BINS", ""),
+		RiskBINCountries:          getEnv("RISK_BIN_COUNTRIES", ""),
+
+		RateLimitStore:       getEnv("RATE_LIMIT_STORE", "database"),
+		RateLimitPeriod:      parseDuration(getEnv("RATE_LIMIT_PERIOD", "1m"), time.Minute),
+		RateLimitPerAPIKey:   parseInt(getEnv("RATE_LIMIT_PER_API_KEY", "600"), 600),
+		RateLimitPerCustomer: parseInt(getEnv("RATE_LIMIT_PER_CUSTOMER", "60"), 60),
+		RateLimitPerIP:       parseInt(getEnv("RATE_LIMIT_PER_IP", "300"), 300),
+		TrustedProxies:       getEnv("TRUSTED_PROXIES", ""),
+
+		BatchMaxItems:    parseInt(getEnv("BATCH_MAX_ITEMS", "100"), 100),
+		BatchConcurrency: parseInt(getEnv("BATCH_CONCURRENCY", "8"), 8),
+
+		JobWorkerInterval: parseDuration(getEnv("JOB_WORKER_INTERVAL", "2s"), 2*time.Second),
+		JobChunkSize:      parseInt(getEnv("JOB_CHUNK_SIZE", "100"), 100),
+		JobConcurrency:    parseInt(getEnv("JOB_CONCURRENCY", "4"), 4),
+		JobMaxRows:        parseInt(getEnv("JOB_MAX_ROWS", "10000"), 10000),
+
+		SchedulerInterval:  parseDuration(getEnv("SCHEDULER_INTERVAL", "5s"), 5*time.Second),
+		SchedulerBatchSize: parseInt(getEnv("SCHEDULER_BATCH_SIZE", "50"), 50),
+		ScheduleMaxAhead:   parseDuration(getEnv("SCHEDULE_MAX_AHEAD", "720h"), 720*time.Hour),
+
+		SubscriptionBillingInterval: parseDuration(getEnv("SUBSCRIPTION_BILLING_INTERVAL", "30s"), 30*time.Second),
+		SubscriptionBatchSize:       parseInt(getEnv("SUBSCRIPTION_BATCH_SIZE", "50"), 50),
+		SubscriptionDunningSchedule: getEnv("SUBSCRIPTION_DUNNING_SCHEDULE", "24h,72h,168h"),
+
+		ReceiptTemplateDir: getEnv("RECEIPT_TEMPLATE_DIR", ""),
+
+		Processors:                getEnv("PROCESSORS", "simulator"),
+		ProcessorRoutes:           getEnv("PROCESSOR_ROUTES", ""),
+		ProcessorWeights:          getEnv("PROCESSOR_WEIGHTS", ""),
+		SimulatorUnavailableRates: getEnv("SIMULATOR_UNAVAILABLE_RATES", ""),
+
+		ProcessorTimeout:               parseDuration(getEnv("PROCESSOR_TIMEOUT", "5s"), 5*time.Second),
+		ProcessorBreakerFailures:       parseInt(getEnv("PROCESSOR_BREAKER_FAILURES", "5"), 5),
+		ProcessorBreakerOpenFor:        parseDuration(getEnv("PROCESSOR_BREAKER_OPEN_FOR", "30s"), 30*time.Second),
+		ProcessorBreakerHalfOpenProbes: parseInt(getEnv("PROCESSOR_BREAKER_HALF_OPEN_PROBES", "1"), 1),
+
+		UnknownCheckInterval:   parseDuration(getEnv("UNKNOWN_CHECK_INTERVAL", "10s"), 10*time.Second),
+		UnknownBackoffBase:     parseDuration(getEnv("UNKNOWN_BACKOFF_BASE", "30s"), 30*time.Second),
+		UnknownBackoffMax:      parseDuration(getEnv("UNKNOWN_BACKOFF_MAX", "10m"), 10*time.Minute),
+		UnknownNotChargedGrace: parseDuration(getEnv("UNKNOWN_NOT_CHARGED_GRACE", "30s"), 30*time.Second),
+		UnknownMaxAge:          parseDuration(getEnv("UNKNOWN_MAX_AGE", "24h"), 24*time.Hour),
+		UnknownBatchSize:       parseInt(getEnv("UNKNOWN_BATCH_SIZE", "50"), 50),
+	}
+}
+
+func (c *Config) CheckSecrets() error {
+	var insecure []string
+	if c.ProcessorWebhookSecret == "" || c.ProcessorWebhookSecret == DevProcessorWebhookSecret {
+		insecure = append(insecure, "PROCESSOR_WEBHOOK_SECRET")
+	}
+	if c.PaymentMethodEncryptionKey == "" || c.PaymentMethodEncryptionKey == DevPaymentMethodEncryptionKey {
+		insecure = append(insecure, "PAYMENT_METHOD_ENCRYPTION_KEY")
+	}
+	if len(insecure) == 0 {
+		return nil
+	}
+
+	names := strings.Join(insecure, ", ")
+	if c.IsProd() {
+		return fmt.Errorf("config: %s must be set to a non-default value in production", names)
 	}
+	log.Printf("warning: %s is unset or uses the public development default", names)
+	return nil
 }
 
 func (c *Config) DSN() string {
@@ -83,6 +278,30 @@ func parseDuration(value string, fallback time.Duration) time.Duration {
 	return d
 }
 
+func parseInt(value string, fallback int) int {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fallback
+	}
+	return n
+}
+
+func parseFloat(value string, fallback float64) float64 {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fallback
+	}
+	return f
+}
+
+func parseBool(value string, fallback bool) bool {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return fallback
+	}
+	return b
+}
+
 func parseEnv(value string) Environment {
 	switch value {
 	case "prod", "production":
@@ -14,6 +14,29 @@ func clearEnvVars(t *testing.T) {
 		"APP_ENV", "APP_PORT", "DB_HOST", "DB_PORT", "DB_USER",
 		"DB_PASSWORD", "DB_NAME", "DB_SSLMODE",
 		"IDEMPOTENCY_KEY_TTL", "CLEANUP_INTERVAL", "GRACEFUL_TIMEOUT",
+		"PENDING_CHECK_INTERVAL", "PENDING_BACKOFF_BASE", "PENDING_BACKOFF_MAX",
+		"PENDING_MAX_AGE", "PENDING_BATCH_SIZE", "SIMULATOR_PENDING_SETTLE",
+		"WEBHOOK_DISPATCH_INTERVAL", "WEBHOOK_TIMEOUT", "WEBHOOK_MAX_ATTEMPTS",
+		"WEBHOOK_BACKOFF_BASE", "WEBHOOK_BACKOFF_MAX", "WEBHOOK_BATCH_SIZE",
+		"PROCESSOR_WEBHOOK_SECRET", "PROCESSOR_EVENT_TOLERANCE",
+		"SIMULATOR_CALLBACK_URL", "SIMULATOR_CALLBACK_REPEATS",
+		"CURRENCY_SOURCE", "CURRENCIES", "CURRENCY_REFRESH_INTERVAL",
+		"DUPLICATE_RIDE_POLICY", "DUPLICATE_RIDE_WINDOW",
+		"PAYMENT_METHOD_ENCRYPTION_KEY", "REQUIRE_EXISTING_CUSTOMER",
+		"TIP_MAX_RATIO", "LEDGER_PROCESSOR_FEE_BPS",
+		"RISK_VELOCITY_WINDOW", "RISK_VELOCITY_CUSTOMER_LIMIT", "RISK_VELOCITY_CARD_LIMIT",
+		"RISK_MAX_AMOUNTS", "RISK_BLOCKED_BINS", "RISK_BIN_COUNTRIES",
+		"RATE_LIMIT_STORE", "RATE_LIMIT_PERIOD", "RATE_LIMIT_PER_API_KEY",
+		"RATE_LIMIT_PER_CUSTOMER", "RATE_LIMIT_PER_IP", "TRUSTED_PROXIES",
+		"BATCH_MAX_ITEMS", "BATCH_CONCURRENCY",
+		"JOB_WORKER_INTERVAL", "JOB_CHUNK_SIZE", "JOB_CONCURRENCY", "JOB_MAX_ROWS",
+		"SCHEDULER_INTERVAL", "SCHEDULER_BATCH_SIZE", "SCHEDULE_MAX_AHEAD",
+		"SUBSCRIPTION_BILLING_INTERVAL", "SUBSCRIPTION_BATCH_SIZE", "SUBSCRIPTION_DUNNING_SCHEDULE",
+		"RECEIPT_TEMPLATE_DIR",
+		"PROCESSORS", "PROCESSOR_ROUTES", "PROCESSOR_WEIGHTS", "SIMULATOR_UNAVAILABLE_RATES",
+		"PROCESSOR_TIMEOUT", "PROCESSOR_BREAKER_FAILURES", "PROCESSOR_BREAKER_OPEN_FOR", "PROCESSOR_BREAKER_HALF_OPEN_PROBES",
+		"UNKNOWN_CHECK_INTERVAL", "UNKNOWN_BACKOFF_BASE", "UNKNOWN_BACKOFF_MAX", "UNKNOWN_BATCH_SIZE",
+		"UNKNOWN_NOT_CHARGED_GRACE", "UNKNOWN_MAX_AGE",
 	}
 	for _, v := range vars {
 		os.Unsetenv(v)
@@ -36,6 +59,70 @@ func TestLoad_DefaultValues(t *testing.T) {
 	assert.Equal(t, 24*time.Hour, cfg.IdempotencyKeyTTL)
 	assert.Equal(t, time.Hour, cfg.CleanupInterval)
 	assert.Equal(t, 5*time.Second, cfg.GracefulTimeout)
+	assert.Equal(t, 10*time.Second, cfg.PendingCheckInterval)
+	assert.Equal(t, 5*time.Second, cfg.PendingBackoffBase)
+	assert.Equal(t, 5*time.Minute, cfg.PendingBackoffMax)
+	assert.Equal(t, time.Hour, cfg.PendingMaxAge)
+	assert.Equal(t, 50, cfg.PendingBatchSize)
+	assert.Equal(t, 30*time.Second, cfg.SimulatorPendingSettle)
+	assert.Equal(t, 5*time.Second, cfg.WebhookDispatchInterval)
+	assert.Equal(t, 10*time.Second, cfg.WebhookTimeout)
+	assert.Equal(t, 8, cfg.WebhookMaxAttempts)
+	assert.Equal(t, 10*time.Second, cfg.WebhookBackoffBase)
+	assert.Equal(t, time.Hour, cfg.WebhookBackoffMax)
+	assert.Equal(t, 50, cfg.WebhookBatchSize)
+	assert.Equal(t, "processor_dev_secret", cfg.ProcessorWebhookSecret)
+	assert.Equal(t, 5*time.Minute, cfg.ProcessorEventTolerance)
+	assert.Equal(t, "", cfg.SimulatorCallbackURL)
+	assert.Equal(t, 1, cfg.SimulatorCallbackRepeats)
+	assert.Equal(t, "config", cfg.CurrencySource)
+	assert.Empty(t, cfg.Currencies)
+	assert.Equal(t, time.Minute, cfg.CurrencyRefreshInterval)
+	assert.Equal(t, "off", cfg.DuplicateRidePolicy)
+	assert.Equal(t, 10*time.Minute, cfg.DuplicateRideWindow)
+	assert.Equal(t, "payment_methods_dev_key", cfg.PaymentMethodEncryptionKey)
+	assert.False(t, cfg.RequireExistingCustomer)
+	assert.Equal(t, 0.5, cfg.TipMaxRatio)
+	assert.Equal(t, 0, cfg.LedgerProcessorFeeBps)
+	assert.Equal(t, time.Hour, cfg.RiskVelocityWindow)
+	assert.Equal(t, 0, cfg.RiskVelocityCustomerLimit)
+	assert.Equal(t, 0, cfg.RiskVelocityCardLimit)
+	assert.Empty(t, cfg.RiskMaxAmounts)
+	assert.Empty(t, cfg.RiskBlockedBINs)
+	assert.Empty(t, cfg.RiskBINCountries)
+	assert.Equal(t, "database", cfg.RateLimitStore)
+	assert.Equal(t, time.Minute, cfg.RateLimitPeriod)
+	assert.Equal(t, 600, cfg.RateLimitPerAPIKey)
+	assert.Equal(t, 60, cfg.RateLimitPerCustomer)
+	assert.Equal(t, 300, cfg.RateLimitPerIP)
+	assert.Empty(t, cfg.TrustedProxies)
+	assert.Equal(t, 100, cfg.BatchMaxItems)
+	assert.Equal(t, 8, cfg.BatchConcurrency)
+	assert.Equal(t, 2*time.Second, cfg.JobWorkerInterval)
+	assert.Equal(t, 100, cfg.JobChunkSize)
+	assert.Equal(t, 4, cfg.JobConcurrency)
+	assert.Equal(t, 10000, cfg.JobMaxRows)
+	assert.Equal(t, 5*time.Second, cfg.SchedulerInterval)
+	assert.Equal(t, 50, cfg.SchedulerBatchSize)
+	assert.Equal(t, 720*time.Hour, cfg.ScheduleMaxAhead)
+	assert.Equal(t, 30*time.Second, cfg.SubscriptionBillingInterval)
+	assert.Equal(t, 50, cfg.SubscriptionBatchSize)
+	assert.Equal(t, "24h,72h,168h", cfg.SubscriptionDunningSchedule)
+	assert.Empty(t, cfg.ReceiptTemplateDir)
+	assert.Equal(t, "simulator", cfg.Processors)
+	assert.Empty(t, cfg.ProcessorRoutes)
+	assert.Empty(t, cfg.ProcessorWeights)
+	assert.Empty(t, cfg.SimulatorUnavailableRates)
+	assert.Equal(t, 5*time.Second, cfg.ProcessorTimeout)
+	assert.Equal(t, 5, cfg.ProcessorBreakerFailures)
+	assert.Equal(t, 30*time.Second, cfg.ProcessorBreakerOpenFor)
+	assert.Equal(t, 1, cfg.ProcessorBreakerHalfOpenProbes)
+	assert.Equal(t, 10*time.Second, cfg.UnknownCheckInterval)
+	assert.Equal(t, 30*time.Second, cfg.UnknownBackoffBase)
+	assert.Equal(t, 10*time.Minute, cfg.UnknownBackoffMax)
+	assert.Equal(t, 30*time.Second, cfg.UnknownNotChargedGrace)
+	assert.Equal(t, 24*time.Hour, cfg.UnknownMaxAge)
+	assert.Equal(t, 50, cfg.UnknownBatchSize)
 }
 
 func TestLoad_ReadsEnvVars(t *testing.T) {
@@ -78,6 +165,22 @@ func TestParseDuration_InvalidFallback(t *testing.T) {
 	assert.Equal(t, 5*time.Second, d)
 }
 
+func TestParseInt(t *testing.T) {
+	assert.Equal(t, 25, parseInt("25", 10))
+	assert.Equal(t, 10, parseInt("not-a-number", 10))
+}
+
+func TestParseFloat(t *testing.T) {
+	assert.Equal(t, 0.25, parseFloat("0.25", 0.5))
+	assert.Equal(t, 0.5, parseFloat("half", 0.5))
+}
+
+func TestParseBool(t *testing.T) {
+	assert.True(t, parseBool("true", false))
+	assert.False(t, parseBool("0", true))
+	assert.True(t, parseBool("maybe", true))
+}
+
 func TestParseEnv(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -124,3 +227,33 @@ func TestLoad_AppEnvFromEnvVar(t *testing.T) {
 
 	assert.Equal(t, EnvProduction, cfg.AppEnv)
 }
+
+func TestCheckSecrets(t *testing.T) {
+	devDefaults := &Config{
+		AppEnv:                     EnvDevelopment,
+		ProcessorWebhookSecret:     DevProcessorWebhookSecret,
+		PaymentMethodEncryptionKey: DevPaymentMethodEncryptionKey,
+	}
+	assert.NoError(t, devDefaults.CheckSecrets())
+
+	prodDefaults := &Config{
+		AppEnv:                     EnvProduction,
+		ProcessorWebhookSecret:     DevProcessorWebhookSecret,
+		PaymentMethodEncryptionKey: DevPaymentMethodEncryptionKey,
+	}
+	err := prodDefaults.CheckSecrets()
+	assert.ErrorContains(t, err, "PROCESSOR_WEBHOOK_SECRET")
+	assert.ErrorContains(t, err, "PAYMENT_METHOD_ENCRYPTION_KEY")
+
+	prodEmptyKey := &Config{AppEnv: EnvProduction, ProcessorWebhookSecret: "whsec_live"}
+	err = prodEmptyKey.CheckSecrets()
+	assert.ErrorContains(t, err, "PAYMENT_METHOD_ENCRYPTION_KEY")
+	assert.NotContains(t, err.Error(), "PROCESSOR_WEBHOOK_SECRET")
+
+	prodConfigured := &Config{
+		AppEnv:                     EnvProduction,
+		ProcessorWebhookSecret:     "whsec_live",
+		PaymentMethodEncryptionKey: "vault_live_key",
+	}
+	assert.NoError(t, prodConfigured.CheckSecrets())
+}
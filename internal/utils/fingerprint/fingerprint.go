@@ -9,7 +9,25 @@ import (
 )
 
 func Compute(req domain.PaymentRequest) string {
+	req.CVV = ""
 	data, _ := json.Marshal(req)
 	hash := sha256.Sum256(data)
 	return fmt.Sprintf("%x", hash)
 }
+
+func ComputeBatch(items []domain.BatchPaymentItem) string {
+	hash := sha256.New()
+	for _, item := range items {
+		fmt.Fprintf(hash, "%s|%s\n", item.IdempotencyKey, Compute(item.PaymentRequest))
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
+
+func ComputeRefund(paymentID string, req domain.RefundRequest) string {
+	data, _ := json.Marshal(struct {
+		PaymentID string `json:"payment_id"`
+		domain.RefundRequest
+	}{PaymentID: paymentID, RefundRequest: req})
+	hash := sha256.Sum256(data)
+	return fmt.Sprintf("%x", hash)
+}
@@ -1,6 +1,9 @@
 package fingerprint
 
 import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
@@ -9,7 +12,7 @@ import (
 
 func baseRequest() domain.PaymentRequest {
 	return domain.PaymentRequest{
-		Amount:     100.0,
+		Amount:     "100",
 		Currency:   domain.CurrencyIDR,
 		CustomerID: "cust-1",
 		RideID:     "ride-1",
@@ -28,7 +31,7 @@ func TestCompute_ConsistentHash(t *testing.T) {
 func TestCompute_DifferentAmount(t *testing.T) {
 	req1 := baseRequest()
 	req2 := baseRequest()
-	req2.Amount = 200.0
+	req2.Amount = "200"
 
 	assert.NotEqual(t, Compute(req1), Compute(req2))
 }
@@ -62,3 +65,66 @@ func TestCompute_HashLength64(t *testing.T) {
 
 	assert.Len(t, hash, 64)
 }
+
+func TestCompute_UnchangedWithoutCardDetails(t *testing.T) {
+	req := baseRequest()
+	data, _ := json.Marshal(req)
+	legacy := sha256.Sum256(data)
+
+	assert.Equal(t, fmt.Sprintf("%x", legacy), Compute(req))
+}
+
+func TestCompute_ExcludesCVV(t *testing.T) {
+	req1 := baseRequest()
+	req1.ExpMonth = 12
+	req1.ExpYear = 2030
+	req1.CVV = "123"
+
+	req2 := req1
+	req2.CVV = "456"
+
+	req3 := req1
+	req3.ExpYear = 2031
+
+	assert.Equal(t, Compute(req1), Compute(req2))
+	assert.NotEqual(t, Compute(req1), Compute(req3))
+	assert.NotEqual(t, Compute(baseRequest()), Compute(req1))
+}
+
+func TestCompute_DifferentSplits(t *testing.T) {
+	req1 := baseRequest()
+	req1.Splits = []domain.SplitRequest{
+		{Recipient: "driver", Percentage: "80"},
+		{Recipient: "platform", Percentage: "20"},
+	}
+
+	req2 := baseRequest()
+	req2.Splits = []domain.SplitRequest{
+		{Recipient: "driver", Percentage: "75"},
+		{Recipient: "platform", Percentage: "25"},
+	}
+
+	assert.NotEqual(t, Compute(req1), Compute(req2))
+	assert.NotEqual(t, Compute(baseRequest()), Compute(req1))
+}
+
+func TestComputeBatch_KeysAndOrderMatter(t *testing.T) {
+	items := []domain.BatchPaymentItem{
+		{IdempotencyKey: "item-1", PaymentRequest: baseRequest()},
+		{IdempotencyKey: "item-2", PaymentRequest: baseRequest()},
+	}
+	assert.Equal(t, ComputeBatch(items), ComputeBatch(append([]domain.BatchPaymentItem{}, items...)))
+
+	reordered := []domain.BatchPaymentItem{items[1], items[0]}
+	assert.NotEqual(t, ComputeBatch(items), ComputeBatch(reordered))
+
+	renamed := []domain.BatchPaymentItem{items[0], {IdempotencyKey: "item-3", PaymentRequest: baseRequest()}}
+	assert.NotEqual(t, ComputeBatch(items), ComputeBatch(renamed))
+}
+
+func TestComputeRefund_PaymentAndAmountMatter(t *testing.T) {
+	req := domain.RefundRequest{Amount: "40", Reason: "ride canceled"}
+	assert.Equal(t, ComputeRefund("pay-1", req), ComputeRefund("pay-1", req))
+	assert.NotEqual(t, ComputeRefund("pay-1", req), ComputeRefund("pay-2", req))
+	assert.NotEqual(t, ComputeRefund("pay-1", req), ComputeRefund("pay-1", domain.RefundRequest{Reason: "ride canceled"}))
+}
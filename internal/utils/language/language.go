@@ -0,0 +1,23 @@
+package language
+
+import "strings"
+
+const Default = "en"
+
+func ParseAcceptLanguage(header string) string {
+	if header == "" {
+		return Default
+	}
+	parts := strings.Split(header, ",")
+	if len(parts) == 0 {
+		return Default
+	}
+	lang := strings.TrimSpace(parts[0])
+	lang = strings.Split(lang, ";")[0]
+	return lang
+}
+
+func Base(lang string) string {
+	base := strings.SplitN(lang, "-", 2)[0]
+	return strings.TrimSpace(strings.ToLower(base))
+}
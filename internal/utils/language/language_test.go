@@ -0,0 +1,18 @@
+package language
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	assert.Equal(t, "en", ParseAcceptLanguage(""))
+	assert.Equal(t, "es-MX", ParseAcceptLanguage("es-MX,es;q=0.9,en;q=0.8"))
+	assert.Equal(t, "th", ParseAcceptLanguage(" th;q=0.9 "))
+}
+
+func TestBase(t *testing.T) {
+	assert.Equal(t, "es", Base("es-MX"))
+	assert.Equal(t, "en", Base(" EN "))
+}
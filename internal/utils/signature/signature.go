@@ -0,0 +1,50 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const version = "v1"
+
+var (
+	ErrInvalidSignature   = errors.New("signature: invalid signature")
+	ErrInvalidTimestamp   = errors.New("signature: invalid timestamp")
+	ErrTimestampTooSkewed = errors.New("signature: timestamp outside tolerance")
+)
+
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return version + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func Verify(secret, header, timestamp string, body []byte, tolerance time.Duration, now time.Time) error {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+
+	skew := now.Sub(time.Unix(unix, 0))
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > tolerance {
+		return ErrTimestampTooSkewed
+	}
+
+	expected := Sign(secret, timestamp, body)
+	for _, candidate := range strings.Split(header, ",") {
+		if hmac.Equal([]byte(strings.TrimSpace(candidate)), []byte(expected)) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
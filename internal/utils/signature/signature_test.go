@@ -0,0 +1,57 @@
+package signature
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign_Deterministic(t *testing.T) {
+	body := []byte(`{"id":"evt-1"}`)
+
+	assert.Equal(t, Sign("secret", "1700000000", body), Sign("secret", "1700000000", body))
+	assert.NotEqual(t, Sign("secret", "1700000000", body), Sign("other", "1700000000", body))
+	assert.Contains(t, Sign("secret", "1700000000", body), "v1=")
+}
+
+func TestVerify_ValidSignature(t *testing.T) {
+	now := time.Now()
+	ts := strconv.FormatInt(now.Unix(), 10)
+	body := []byte(`{"id":"evt-1"}`)
+
+	err := Verify("secret", Sign("secret", ts, body), ts, body, 5*time.Minute, now)
+	assert.NoError(t, err)
+}
+
+func TestVerify_AcceptsAnyOfMultipleSignatures(t *testing.T) {
+	now := time.Now()
+	ts := strconv.FormatInt(now.Unix(), 10)
+	body := []byte(`{"id":"evt-1"}`)
+	header := Sign("old-secret", ts, body) + ", " + Sign("secret", ts, body)
+
+	assert.NoError(t, Verify("secret", header, ts, body, 5*time.Minute, now))
+}
+
+func TestVerify_TamperedBody(t *testing.T) {
+	now := time.Now()
+	ts := strconv.FormatInt(now.Unix(), 10)
+
+	err := Verify("secret", Sign("secret", ts, []byte("a")), ts, []byte("b"), 5*time.Minute, now)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestVerify_TimestampOutsideTolerance(t *testing.T) {
+	now := time.Now()
+	ts := strconv.FormatInt(now.Add(-10*time.Minute).Unix(), 10)
+	body := []byte("payload")
+
+	err := Verify("secret", Sign("secret", ts, body), ts, body, 5*time.Minute, now)
+	assert.ErrorIs(t, err, ErrTimestampTooSkewed)
+}
+
+func TestVerify_MalformedTimestamp(t *testing.T) {
+	err := Verify("secret", "v1=abc", "not-a-number", nil, time.Minute, time.Now())
+	assert.ErrorIs(t, err, ErrInvalidTimestamp)
+}
@@ -18,7 +18,11 @@ func main() {
 		os.Exit(1)
 	}
 
-	server := echoserver.NewServer(cfg, container)
+	server, err := echoserver.NewServer(cfg, container)
+	if err != nil {
+		log.Printf("failed to initialize server: %v", err)
+		os.Exit(1)
+	}
 
 	if err := <-server.Start(); err != nil {
 		log.Printf("server error: %v", err)
@@ -0,0 +1,215 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm/repositories"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/processor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type batchEnv struct {
+	createPayment *use_cases.CreatePaymentUseCase
+	createBatch   *use_cases.CreatePaymentBatchUseCase
+	paymentRepo   domain.PaymentRepository
+}
+
+func setupBatch(t *testing.T, maxItems int) *batchEnv {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+
+	paymentRepo := repositories.NewPaymentRepo(db)
+	createPayment := use_cases.NewCreatePaymentUseCase(
+		gormdb.NewTransactionManager(db), repositories.NewIdempotencyRepo(db), paymentRepo,
+		processor.NewSimulator(), 24*time.Hour,
+	)
+
+	return &batchEnv{
+		createPayment: createPayment,
+		createBatch: use_cases.NewCreatePaymentBatchUseCase(
+			repositories.NewPaymentBatchRepo(db), createPayment, maxItems, 4, 24*time.Hour,
+		),
+		paymentRepo: paymentRepo,
+	}
+}
+
+func batchItem(key, rideID string) domain.BatchPaymentItem {
+	req := validRequest()
+	req.RideID = rideID
+	return domain.BatchPaymentItem{IdempotencyKey: key, PaymentRequest: req}
+}
+
+func TestBatch_ReportsOutcomePerItem(t *testing.T) {
+	env := setupBatch(t, 10)
+	ctx := context.Background()
+
+	_, err := env.createPayment.Execute(ctx, "item-replayed", batchItem("item-replayed", "ride-replayed").PaymentRequest)
+	require.NoError(t, err)
+	_, err = env.createPayment.Execute(ctx, "item-conflict", batchItem("item-conflict", "ride-original").PaymentRequest)
+	require.NoError(t, err)
+
+	invalid := batchItem("item-invalid", "ride-invalid")
+	invalid.Amount = "-5"
+
+	result, err := env.createBatch.Execute(ctx, "batch-outcomes", domain.BatchPaymentRequest{Items: []domain.BatchPaymentItem{
+		batchItem("item-created", "ride-created"),
+		batchItem("item-replayed", "ride-replayed"),
+		batchItem("item-conflict", "ride-changed"),
+		invalid,
+	}})
+	require.NoError(t, err)
+	assert.False(t, result.Replayed)
+
+	items := result.Response.Items
+	require.Len(t, items, 4)
+
+	assert.Equal(t, domain.BatchItemCreated, items[0].Outcome)
+	assert.Equal(t, http.StatusCreated, items[0].HTTPStatus)
+	require.NotNil(t, items[0].Payment)
+	assert.Equal(t, "ride-created", items[0].Payment.RideID)
+
+	assert.Equal(t, domain.BatchItemReplayed, items[1].Outcome)
+	require.NotNil(t, items[1].Payment)
+
+	assert.Equal(t, domain.BatchItemConflict, items[2].Outcome)
+	assert.Equal(t, http.StatusConflict, items[2].HTTPStatus)
+	require.NotNil(t, items[2].Error)
+	assert.Equal(t, "IDEMPOTENCY_KEY_CONFLICT", items[2].Error.Code)
+	assert.Nil(t, items[2].Payment)
+
+	assert.Equal(t, domain.BatchItemFailed, items[3].Outcome)
+	assert.Equal(t, http.StatusBadRequest, items[3].HTTPStatus)
+	require.NotNil(t, items[3].Error)
+
+	for i, item := range items {
+		assert.Equal(t, i, item.Index)
+	}
+	assert.Equal(t, domain.BatchSummary{Created: 1, Replayed: 1, Conflicts: 1, Errors: 1}, result.Response.Summary)
+}
+
+func TestBatch_SameKeyReplaysWholeResponse(t *testing.T) {
+	env := setupBatch(t, 10)
+	ctx := context.Background()
+	req := domain.BatchPaymentRequest{Items: []domain.BatchPaymentItem{
+		batchItem("replay-item-1", "ride-1"),
+		batchItem("replay-item-2", "ride-2"),
+	}}
+
+	first, err := env.createBatch.Execute(ctx, "batch-replay", req)
+	require.NoError(t, err)
+	assert.Equal(t, 2, first.Response.Summary.Created)
+
+	second, err := env.createBatch.Execute(ctx, "batch-replay", req)
+	require.NoError(t, err)
+	assert.True(t, second.Replayed)
+	assert.Equal(t, 2, second.Response.Summary.Created)
+	assert.Equal(t, first.Response.Items[0].Payment.ID, second.Response.Items[0].Payment.ID)
+	assert.Equal(t, first.Response.Items[1].Payment.ID, second.Response.Items[1].Payment.ID)
+
+	payments, err := env.paymentRepo.List(ctx, domain.PaymentFilter{Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, payments, 2)
+}
+
+func TestBatch_SameKeyDifferentItemsConflicts(t *testing.T) {
+	env := setupBatch(t, 10)
+	ctx := context.Background()
+
+	_, err := env.createBatch.Execute(ctx, "batch-conflict", domain.BatchPaymentRequest{Items: []domain.BatchPaymentItem{
+		batchItem("conflict-item-1", "ride-1"),
+	}})
+	require.NoError(t, err)
+
+	_, err = env.createBatch.Execute(ctx, "batch-conflict", domain.BatchPaymentRequest{Items: []domain.BatchPaymentItem{
+		batchItem("conflict-item-2", "ride-2"),
+	}})
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "IDEMPOTENCY_KEY_CONFLICT", appErr.Code)
+}
+
+func TestBatch_RejectsInvalidEnvelope(t *testing.T) {
+	env := setupBatch(t, 2)
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		key   string
+		items []domain.BatchPaymentItem
+		code  string
+	}{
+		{"missing batch key", "", []domain.BatchPaymentItem{batchItem("a", "ride-a")}, "IDEMPOTENCY_KEY_MISSING"},
+		{"empty items", "batch-empty", nil, "INVALID_BATCH_REQUEST"},
+		{"too many items", "batch-big", []domain.BatchPaymentItem{
+			batchItem("a", "ride-a"), batchItem("b", "ride-b"), batchItem("c", "ride-c"),
+		}, "INVALID_BATCH_REQUEST"},
+		{"missing item key", "batch-nokey", []domain.BatchPaymentItem{batchItem("", "ride-a")}, "INVALID_BATCH_REQUEST"},
+		{"duplicate item keys", "batch-dup", []domain.BatchPaymentItem{
+			batchItem("a", "ride-a"), batchItem("a", "ride-b"),
+		}, "INVALID_BATCH_REQUEST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := env.createBatch.Execute(ctx, tt.key, domain.BatchPaymentRequest{Items: tt.items})
+			var appErr *apperrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, tt.code, appErr.Code)
+		})
+	}
+}
+
+func TestBatch_ReplayRerunsTransientItemFailures(t *testing.T) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	txManager := gormdb.NewTransactionManager(db)
+	idempotencyRepo := repositories.NewIdempotencyRepo(db)
+	paymentRepo := repositories.NewPaymentRepo(db)
+	batchRepo := repositories.NewPaymentBatchRepo(db)
+	newBatch := func(p domain.PaymentProcessor) *use_cases.CreatePaymentBatchUseCase {
+		createPayment := use_cases.NewCreatePaymentUseCase(txManager, idempotencyRepo, paymentRepo, p, 24*time.Hour)
+		return use_cases.NewCreatePaymentBatchUseCase(batchRepo, createPayment, 10, 4, 24*time.Hour)
+	}
+
+	invalid := batchItem("transient-invalid", "ride-invalid")
+	invalid.Amount = "-5"
+	req := domain.BatchPaymentRequest{Items: []domain.BatchPaymentItem{
+		batchItem("transient-item-1", "ride-1"),
+		invalid,
+	}}
+
+	first, err := newBatch(processor.NewSimulator(processor.WithUnavailableRate(1))).Execute(ctx, "batch-transient", req)
+	require.NoError(t, err)
+	require.NotNil(t, first.Response.Items[0].Error)
+	assert.Equal(t, "PROCESSOR_UNAVAILABLE", first.Response.Items[0].Error.Code)
+	assert.Equal(t, domain.BatchSummary{Errors: 2}, first.Response.Summary)
+
+	up := newBatch(processor.NewSimulator())
+	second, err := up.Execute(ctx, "batch-transient", req)
+	require.NoError(t, err)
+	assert.False(t, second.Replayed)
+	assert.Equal(t, domain.BatchItemCreated, second.Response.Items[0].Outcome)
+	assert.Equal(t, 0, second.Response.Items[0].Index)
+	require.NotNil(t, second.Response.Items[0].Payment)
+	assert.Equal(t, http.StatusBadRequest, second.Response.Items[1].HTTPStatus, "permanent failures stay cached")
+	assert.Equal(t, domain.BatchSummary{Created: 1, Errors: 1}, second.Response.Summary)
+
+	third, err := up.Execute(ctx, "batch-transient", req)
+	require.NoError(t, err)
+	assert.True(t, third.Replayed)
+	assert.Equal(t, second.Response.Items[0].Payment.ID, third.Response.Items[0].Payment.ID)
+
+	payments, err := paymentRepo.List(ctx, domain.PaymentFilter{Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, payments, 1)
+}
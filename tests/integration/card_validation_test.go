@@ -0,0 +1,92 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePayment_CardValidationErrors(t *testing.T) {
+	env := setupIntegration(t)
+	ctx := context.Background()
+	nextYear := time.Now().Year() + 1
+
+	tests := []struct {
+		name   string
+		modify func(req *domain.PaymentRequest)
+		code   string
+	}{
+		{"non numeric", func(req *domain.PaymentRequest) { req.CardNumber = "4111abcd11111111" }, "INVALID_CARD_NUMBER"},
+		{"too short", func(req *domain.PaymentRequest) { req.CardNumber = "41111111" }, "INVALID_CARD_NUMBER"},
+		{"luhn failure", func(req *domain.PaymentRequest) { req.CardNumber = "4111111111111112" }, "INVALID_CARD_CHECKSUM"},
+		{"month out of range", func(req *domain.PaymentRequest) { req.ExpMonth, req.ExpYear = 13, nextYear }, "INVALID_CARD_EXPIRY"},
+		{"year missing", func(req *domain.PaymentRequest) { req.ExpMonth = 5 }, "INVALID_CARD_EXPIRY"},
+		{"expired", func(req *domain.PaymentRequest) { req.ExpMonth, req.ExpYear = 1, 2020 }, "CARD_EXPIRED"},
+		{"cvv too short", func(req *domain.PaymentRequest) { req.CVV = "12" }, "INVALID_CVV"},
+		{"cvv not numeric", func(req *domain.PaymentRequest) { req.CVV = "12a" }, "INVALID_CVV"},
+		{"amex needs four digits", func(req *domain.PaymentRequest) {
+			req.CardNumber = "378282246310005"
+			req.CVV = "123"
+		}, "INVALID_CVV"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validRequest()
+			tt.modify(&req)
+
+			_, err := env.createPayment.Execute(ctx, "card-"+tt.name, req)
+			var appErr *apperrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, tt.code, appErr.Code)
+		})
+	}
+}
+
+func TestCreatePayment_CardBrandStoredAndSecretsNotPersisted(t *testing.T) {
+	env := setupIntegration(t)
+	ctx := context.Background()
+
+	req := validRequest()
+	req.CardNumber = "5500 0000 0000 0004"
+	req.ExpMonth = 12
+	req.ExpYear = time.Now().Year() + 2
+	req.CVV = "987"
+
+	result, err := env.createPayment.Execute(ctx, "card-brand-key", req)
+	require.NoError(t, err)
+	assert.Equal(t, domain.CardBrandMastercard, result.Payment.CardBrand)
+	assert.Equal(t, "0004", result.Payment.CardLast4)
+
+	found, err := env.getPayment.Execute(ctx, result.Payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.CardBrandMastercard, found.CardBrand)
+
+	record, err := env.getByIdempotencyKey.Execute(ctx, "card-brand-key")
+	require.NoError(t, err)
+	stored, err := json.Marshal(record)
+	require.NoError(t, err)
+	assert.NotContains(t, string(stored), "987")
+	assert.NotContains(t, string(stored), "5500000000000004")
+
+	replay, err := env.createPayment.Execute(ctx, "card-brand-key", req)
+	require.NoError(t, err)
+	assert.True(t, replay.Replayed)
+
+	req.CVV = "123"
+	replay, err = env.createPayment.Execute(ctx, "card-brand-key", req)
+	require.NoError(t, err)
+	assert.True(t, replay.Replayed, "the CVV is not part of the fingerprint")
+
+	req.ExpYear++
+	_, err = env.createPayment.Execute(ctx, "card-brand-key", req)
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "IDEMPOTENCY_KEY_CONFLICT", appErr.Code)
+}
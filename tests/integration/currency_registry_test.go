@@ -0,0 +1,104 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadCurrencies(t *testing.T, definitions []domain.CurrencyDefinition) {
+	domain.Currencies().Load(definitions)
+	t.Cleanup(func() {
+		domain.Currencies().Load(domain.DefaultCurrencyDefinitions())
+	})
+}
+
+func TestCurrencyRegistry_NewCurrencyFromConfig(t *testing.T) {
+	definitions, err := domain.ParseCurrencyDefinitions("IDR:0,SGD:2:500:100000,MYR:2:0:0:false")
+	require.NoError(t, err)
+	loadCurrencies(t, definitions)
+
+	env := setupIntegration(t)
+	ctx := context.Background()
+
+	req := validRequest()
+	req.Currency = "SGD"
+	req.Amount = "12.50"
+
+	result, err := env.createPayment.Execute(ctx, "sgd-key", req)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Currency("SGD"), result.Payment.Currency)
+	assert.Equal(t, int64(1250), result.Payment.Amount)
+
+	req.Currency = "MYR"
+	_, err = env.createPayment.Execute(ctx, "myr-key", req)
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "INVALID_CURRENCY", appErr.Code)
+	assert.Contains(t, appErr.Message, "valid currencies: IDR, SGD")
+}
+
+func TestCurrencyRegistry_AmountBounds(t *testing.T) {
+	loadCurrencies(t, []domain.CurrencyDefinition{
+		{Code: domain.CurrencyTHB, Exponent: 2, MinAmount: 2000, MaxAmount: 5000000, Enabled: true},
+	})
+
+	env := setupIntegration(t)
+	ctx := context.Background()
+
+	req := validRequest()
+	req.Currency = domain.CurrencyTHB
+
+	req.Amount = "19.99"
+	_, err := env.createPayment.Execute(ctx, "thb-low", req)
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "AMOUNT_BELOW_MINIMUM", appErr.Code)
+	assert.Contains(t, appErr.Message, "at least 20")
+
+	req.Amount = "50000.01"
+	_, err = env.createPayment.Execute(ctx, "thb-high", req)
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "AMOUNT_ABOVE_MAXIMUM", appErr.Code)
+
+	req.Amount = "50000"
+	_, err = env.createPayment.Execute(ctx, "thb-max", req)
+	assert.NoError(t, err)
+}
+
+func TestRefreshCurrencies_LoadsFromDatabase(t *testing.T) {
+	loadCurrencies(t, domain.DefaultCurrencyDefinitions())
+
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+	require.NoError(t, db.Create(&[]domain.CurrencyDefinition{
+		{Code: domain.CurrencyIDR, Exponent: 0, Enabled: true},
+		{Code: "MYR", Exponent: 2, Enabled: true},
+	}).Error)
+
+	refresh := use_cases.NewRefreshCurrenciesUseCase(repositories.NewCurrencyRepo(db), domain.Currencies())
+	loaded, err := refresh.Execute(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, loaded)
+	assert.True(t, domain.Currencies().Supported("MYR"))
+	assert.False(t, domain.Currencies().Supported(domain.CurrencyTHB))
+}
+
+func TestRefreshCurrencies_EmptyTableKeepsRegistry(t *testing.T) {
+	loadCurrencies(t, domain.DefaultCurrencyDefinitions())
+
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+
+	refresh := use_cases.NewRefreshCurrenciesUseCase(repositories.NewCurrencyRepo(db), domain.Currencies())
+	_, err = refresh.Execute(context.Background())
+	assert.Error(t, err)
+	assert.True(t, domain.Currencies().Supported(domain.CurrencyTHB))
+}
@@ -0,0 +1,153 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm/repositories"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/processor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type customerEnv struct {
+	createPayment        *use_cases.CreatePaymentUseCase
+	createCustomer       *use_cases.CreateCustomerUseCase
+	getCustomer          *use_cases.GetCustomerUseCase
+	listCustomers        *use_cases.ListCustomersUseCase
+	listCustomerPayments *use_cases.ListCustomerPaymentsUseCase
+}
+
+func setupCustomers(t *testing.T, requireExisting bool) *customerEnv {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+
+	customerRepo := repositories.NewCustomerRepo(db)
+	paymentRepo := repositories.NewPaymentRepo(db)
+
+	var opts []use_cases.CreatePaymentOption
+	if requireExisting {
+		opts = append(opts, use_cases.WithCustomerValidation(customerRepo))
+	}
+
+	return &customerEnv{
+		createPayment: use_cases.NewCreatePaymentUseCase(
+			gormdb.NewTransactionManager(db),
+			repositories.NewIdempotencyRepo(db),
+			paymentRepo,
+			processor.NewSimulator(),
+			24*time.Hour,
+			opts...,
+		),
+		createCustomer:       use_cases.NewCreateCustomerUseCase(customerRepo),
+		getCustomer:          use_cases.NewGetCustomerUseCase(customerRepo),
+		listCustomers:        use_cases.NewListCustomersUseCase(customerRepo),
+		listCustomerPayments: use_cases.NewListCustomerPaymentsUseCase(customerRepo, paymentRepo),
+	}
+}
+
+func TestCustomers_CreateAndGet(t *testing.T) {
+	env := setupCustomers(t, false)
+	ctx := context.Background()
+
+	customer, err := env.createCustomer.Execute(ctx, domain.CustomerRequest{ID: "cust-001", Name: "Ayu", Email: "ayu@example.com", Country: "id"})
+	require.NoError(t, err)
+	assert.Equal(t, "ID", customer.Country)
+
+	generated, err := env.createCustomer.Execute(ctx, domain.CustomerRequest{Name: "Somchai"})
+	require.NoError(t, err)
+	assert.Contains(t, generated.ID, "cust_")
+
+	_, err = env.createCustomer.Execute(ctx, domain.CustomerRequest{ID: "cust-001", Name: "Other"})
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "CUSTOMER_ALREADY_EXISTS", appErr.Code)
+
+	_, err = env.createCustomer.Execute(ctx, domain.CustomerRequest{Name: "Bad", Email: "not-an-email"})
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "INVALID_CUSTOMER", appErr.Code)
+
+	found, err := env.getCustomer.Execute(ctx, "cust-001")
+	require.NoError(t, err)
+	assert.Equal(t, "Ayu", found.Name)
+
+	_, err = env.getCustomer.Execute(ctx, "nobody")
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "CUSTOMER_NOT_FOUND", appErr.Code)
+
+	customers, err := env.listCustomers.Execute(ctx, "")
+	require.NoError(t, err)
+	assert.Len(t, customers, 2)
+}
+
+func TestCustomers_RequireExistingCustomer(t *testing.T) {
+	env := setupCustomers(t, true)
+	ctx := context.Background()
+
+	_, err := env.createPayment.Execute(ctx, "unknown-customer-key", validRequest())
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "UNKNOWN_CUSTOMER", appErr.Code)
+
+	_, err = env.createCustomer.Execute(ctx, domain.CustomerRequest{ID: "cust-001", Name: "Ayu"})
+	require.NoError(t, err)
+
+	result, err := env.createPayment.Execute(ctx, "unknown-customer-key", validRequest())
+	require.NoError(t, err)
+	assert.Equal(t, "cust-001", result.Payment.CustomerID)
+}
+
+func TestCustomers_PaymentsWithTotals(t *testing.T) {
+	env := setupCustomers(t, false)
+	ctx := context.Background()
+
+	_, err := env.createCustomer.Execute(ctx, domain.CustomerRequest{ID: "cust-001", Name: "Ayu"})
+	require.NoError(t, err)
+
+	first := validRequest()
+	_, err = env.createPayment.Execute(ctx, "totals-key-1", first)
+	require.NoError(t, err)
+
+	second := validRequest()
+	second.RideID = "ride-002"
+	second.Amount = "250"
+	_, err = env.createPayment.Execute(ctx, "totals-key-2", second)
+	require.NoError(t, err)
+
+	third := validRequest()
+	third.RideID = "ride-003"
+	third.Currency = domain.CurrencyTHB
+	third.Amount = "10.50"
+	_, err = env.createPayment.Execute(ctx, "totals-key-3", third)
+	require.NoError(t, err)
+
+	other := validRequest()
+	other.CustomerID = "cust-002"
+	_, err = env.createPayment.Execute(ctx, "totals-key-4", other)
+	require.NoError(t, err)
+
+	result, err := env.listCustomerPayments.Execute(ctx, "cust-001", use_cases.ListPaymentsQuery{Limit: "2"})
+	require.NoError(t, err)
+	assert.Len(t, result.Data, 2)
+	assert.True(t, result.HasMore)
+	require.Len(t, result.Totals, 2)
+	assert.Equal(t, domain.CurrencyIDR, result.Totals[0].Currency)
+	assert.Equal(t, int64(350), result.Totals[0].Amount)
+	assert.Equal(t, int64(2), result.Totals[0].Count)
+	assert.Equal(t, int64(1050), result.Totals[1].Amount)
+
+	body, err := json.Marshal(result.Totals[1])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"currency":"THB","amount":10.5,"amount_minor":1050,"count":1}`, string(body))
+
+	_, err = env.listCustomerPayments.Execute(ctx, "nobody", use_cases.ListPaymentsQuery{})
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "CUSTOMER_NOT_FOUND", appErr.Code)
+}
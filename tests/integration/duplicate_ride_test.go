@@ -0,0 +1,177 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicateRide_Reject(t *testing.T) {
+	env := setupIntegration(t, use_cases.WithDuplicateRideGuard(use_cases.DuplicateRidePolicyReject, 10*time.Minute))
+	ctx := context.Background()
+
+	first, err := env.createPayment.Execute(ctx, "ride-key-1", validRequest())
+	require.NoError(t, err)
+
+	_, err = env.createPayment.Execute(ctx, "ride-key-2", validRequest())
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "DUPLICATE_RIDE_PAYMENT", appErr.Code)
+	assert.Equal(t, 409, appErr.HTTPCode)
+	assert.Contains(t, appErr.Message, first.Payment.ID)
+
+	_, err = env.getByIdempotencyKey.Execute(ctx, "ride-key-2")
+	assert.Error(t, err)
+
+	replay, err := env.createPayment.Execute(ctx, "ride-key-1", validRequest())
+	require.NoError(t, err)
+	assert.True(t, replay.Replayed)
+}
+
+func TestDuplicateRide_ReturnOriginal(t *testing.T) {
+	env := setupIntegration(t, use_cases.WithDuplicateRideGuard(use_cases.DuplicateRidePolicyReturnOriginal, 10*time.Minute))
+	ctx := context.Background()
+
+	first, err := env.createPayment.Execute(ctx, "ride-key-1", validRequest())
+	require.NoError(t, err)
+
+	second, err := env.createPayment.Execute(ctx, "ride-key-2", validRequest())
+	require.NoError(t, err)
+	assert.True(t, second.Duplicate)
+	assert.Equal(t, first.Payment.ID, second.Payment.ID)
+
+	retry, err := env.createPayment.Execute(ctx, "ride-key-2", validRequest())
+	require.NoError(t, err)
+	assert.True(t, retry.Replayed)
+	assert.Equal(t, first.Payment.ID, retry.Payment.ID)
+}
+
+func TestDuplicateRide_ReturnOriginalIncludesSplits(t *testing.T) {
+	env := setupIntegration(t, use_cases.WithDuplicateRideGuard(use_cases.DuplicateRidePolicyReturnOriginal, 10*time.Minute))
+	ctx := context.Background()
+
+	first, err := env.createPayment.Execute(ctx, "ride-split-1", splitRequest())
+	require.NoError(t, err)
+	require.Len(t, first.Payment.Splits, 2)
+
+	second, err := env.createPayment.Execute(ctx, "ride-split-2", splitRequest())
+	require.NoError(t, err)
+	assert.True(t, second.Duplicate)
+	assert.Equal(t, first.Payment.Splits, second.Payment.Splits)
+
+	retry, err := env.createPayment.Execute(ctx, "ride-split-2", splitRequest())
+	require.NoError(t, err)
+	assert.True(t, retry.Replayed)
+	require.Len(t, retry.Payment.Splits, 2)
+	assert.Equal(t, "driver-77", retry.Payment.Splits[0].Recipient)
+}
+
+func TestDuplicateRide_PendingChargeBlocksRetry(t *testing.T) {
+	env := setupIntegration(t, use_cases.WithDuplicateRideGuard(use_cases.DuplicateRidePolicyReject, 10*time.Minute))
+	ctx := context.Background()
+
+	req := validRequest()
+	req.CardNumber = "4000000000000259"
+	first, err := env.createPayment.Execute(ctx, "ride-pending-1", req)
+	require.NoError(t, err)
+	require.Equal(t, domain.PaymentStatusPending, first.Payment.Status)
+
+	_, err = env.createPayment.Execute(ctx, "ride-pending-2", validRequest())
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "DUPLICATE_RIDE_PAYMENT", appErr.Code)
+}
+
+func TestDuplicateRide_ConcurrentKeysChargeOnce(t *testing.T) {
+	env := setupIntegration(t, use_cases.WithDuplicateRideGuard(use_cases.DuplicateRidePolicyReject, 10*time.Minute))
+	ctx := context.Background()
+
+	const attempts = 5
+	errs := make([]error, attempts)
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = env.createPayment.Execute(ctx, fmt.Sprintf("ride-concurrent-%d", i), validRequest())
+		}(i)
+	}
+	wg.Wait()
+
+	charged := 0
+	for _, err := range errs {
+		if err == nil {
+			charged++
+			continue
+		}
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, "DUPLICATE_RIDE_PAYMENT", appErr.Code)
+	}
+	assert.Equal(t, 1, charged)
+}
+
+func TestDuplicateRide_AllowDuplicateOverride(t *testing.T) {
+	env := setupIntegration(t, use_cases.WithDuplicateRideGuard(use_cases.DuplicateRidePolicyReject, 10*time.Minute))
+	ctx := context.Background()
+
+	first, err := env.createPayment.Execute(ctx, "ride-key-1", validRequest())
+	require.NoError(t, err)
+
+	req := validRequest()
+	req.AllowDuplicate = true
+	second, err := env.createPayment.Execute(ctx, "ride-key-2", req)
+	require.NoError(t, err)
+	assert.False(t, second.Duplicate)
+	assert.NotEqual(t, first.Payment.ID, second.Payment.ID)
+}
+
+func TestDuplicateRide_DifferentAmountOrFailedPaymentAllowed(t *testing.T) {
+	env := setupIntegration(t, use_cases.WithDuplicateRideGuard(use_cases.DuplicateRidePolicyReject, 10*time.Minute))
+	ctx := context.Background()
+
+	declined := validRequest()
+	declined.CardNumber = "4000000000000002"
+	_, err := env.createPayment.Execute(ctx, "ride-declined", declined)
+	require.NoError(t, err)
+
+	_, err = env.createPayment.Execute(ctx, "ride-retry-card", validRequest())
+	require.NoError(t, err)
+
+	other := validRequest()
+	other.Amount = "250"
+	_, err = env.createPayment.Execute(ctx, "ride-other-amount", other)
+	assert.NoError(t, err)
+}
+
+func TestDuplicateRide_OutsideWindow(t *testing.T) {
+	env := setupIntegration(t, use_cases.WithDuplicateRideGuard(use_cases.DuplicateRidePolicyReject, time.Millisecond))
+	ctx := context.Background()
+
+	_, err := env.createPayment.Execute(ctx, "ride-key-1", validRequest())
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+
+	_, err = env.createPayment.Execute(ctx, "ride-key-2", validRequest())
+	assert.NoError(t, err)
+}
+
+func TestDuplicateRide_DisabledByDefault(t *testing.T) {
+	env := setupIntegration(t)
+	ctx := context.Background()
+
+	_, err := env.createPayment.Execute(ctx, "ride-key-1", validRequest())
+	require.NoError(t, err)
+
+	second, err := env.createPayment.Execute(ctx, "ride-key-2", validRequest())
+	require.NoError(t, err)
+	assert.False(t, second.Duplicate)
+}
@@ -0,0 +1,174 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm/repositories"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/processor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ledgerEnv struct {
+	createPayment *use_cases.CreatePaymentUseCase
+	handleEvent   *use_cases.HandleProcessorEventUseCase
+	getBalances   *use_cases.GetLedgerBalancesUseCase
+	ledgerRepo    domain.LedgerRepository
+	paymentRepo   domain.PaymentRepository
+}
+
+func setupLedger(t *testing.T, feeBasisPoints int64) *ledgerEnv {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+
+	txManager := gormdb.NewTransactionManager(db)
+	idempotencyRepo := repositories.NewIdempotencyRepo(db)
+	paymentRepo := repositories.NewPaymentRepo(db)
+	ledgerRepo := repositories.NewLedgerRepo(db)
+	ledger := use_cases.NewLedgerRecorder(ledgerRepo, feeBasisPoints)
+
+	return &ledgerEnv{
+		createPayment: use_cases.NewCreatePaymentUseCase(
+			txManager, idempotencyRepo, paymentRepo, processor.NewSimulator(), 24*time.Hour,
+			use_cases.WithLedger(ledger),
+		),
+		handleEvent: use_cases.NewHandleProcessorEventUseCase(
+			txManager, repositories.NewProcessorEventRepo(db), idempotencyRepo, paymentRepo, nil,
+			processorSecret, 5*time.Minute,
+			use_cases.WithStatusLedger(ledger),
+		),
+		getBalances: use_cases.NewGetLedgerBalancesUseCase(ledgerRepo),
+		ledgerRepo:  ledgerRepo,
+		paymentRepo: paymentRepo,
+	}
+}
+
+func balancesByAccount(t *testing.T, env *ledgerEnv, query use_cases.LedgerBalanceQuery) map[string]int64 {
+	balances, err := env.getBalances.Execute(context.Background(), query)
+	require.NoError(t, err)
+	byAccount := make(map[string]int64, len(balances))
+	for _, b := range balances {
+		byAccount[b.AccountID] = b.Balance
+	}
+	return byAccount
+}
+
+func TestLedger_ChargeAndFeeBalance(t *testing.T) {
+	env := setupLedger(t, 250)
+	ctx := context.Background()
+
+	result, err := env.createPayment.Execute(ctx, "ledger-key-1", splitRequest())
+	require.NoError(t, err)
+
+	entries, err := env.ledgerRepo.ListEntriesByPayment(ctx, result.Payment.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, domain.JournalEntryCharge, entries[0].Type)
+	assert.Equal(t, domain.JournalEntryFee, entries[1].Type)
+	for _, entry := range entries {
+		assert.NoError(t, entry.Validate())
+	}
+
+	balances := balancesByAccount(t, env, use_cases.LedgerBalanceQuery{Currency: "IDR"})
+	assert.Equal(t, int64(150000-3750), balances["processor_receivable:IDR"])
+	assert.Equal(t, int64(120000), balances["recipient:driver-77:IDR"])
+	assert.Equal(t, int64(30000), balances["recipient:platform:IDR"])
+	assert.Equal(t, int64(3750), balances["processing_fees:IDR"])
+
+	_, err = env.createPayment.Execute(ctx, "ledger-key-1", splitRequest())
+	require.NoError(t, err)
+	entries, err = env.ledgerRepo.ListEntriesByPayment(ctx, result.Payment.ID)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "replays do not post again")
+}
+
+func TestLedger_BalanceAtPointInTime(t *testing.T) {
+	env := setupLedger(t, 0)
+	ctx := context.Background()
+
+	_, err := env.createPayment.Execute(ctx, "ledger-key-2", validRequest())
+	require.NoError(t, err)
+	between := time.Now().Add(time.Second).Truncate(time.Second)
+	time.Sleep(1100 * time.Millisecond)
+
+	second := validRequest()
+	second.RideID = "ride-002"
+	_, err = env.createPayment.Execute(ctx, "ledger-key-3", second)
+	require.NoError(t, err)
+
+	past := balancesByAccount(t, env, use_cases.LedgerBalanceQuery{AccountID: "merchant_payable:IDR", AsOf: between.Format(time.RFC3339)})
+	assert.Equal(t, int64(100), past["merchant_payable:IDR"])
+
+	now := balancesByAccount(t, env, use_cases.LedgerBalanceQuery{AccountID: "merchant_payable:IDR"})
+	assert.Equal(t, int64(200), now["merchant_payable:IDR"])
+
+	_, err = env.getBalances.Execute(ctx, use_cases.LedgerBalanceQuery{AccountID: "missing:IDR"})
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "LEDGER_ACCOUNT_NOT_FOUND", appErr.Code)
+
+	_, err = env.getBalances.Execute(ctx, use_cases.LedgerBalanceQuery{AsOf: "yesterday"})
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "INVALID_LEDGER_QUERY", appErr.Code)
+}
+
+func TestLedger_SplitRoundingToZeroIsRejected(t *testing.T) {
+	env := setupLedger(t, 0)
+	ctx := context.Background()
+
+	req := validRequest()
+	req.Amount = "1000"
+	req.Splits = []domain.SplitRequest{
+		{Recipient: "driver-77", Percentage: "99.99"},
+		{Recipient: "platform", Percentage: "0.01"},
+	}
+	_, err := env.createPayment.Execute(ctx, "ledger-key-zero-split", req)
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "INVALID_SPLIT", appErr.Code)
+
+	payments, err := env.paymentRepo.List(ctx, domain.PaymentFilter{Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, payments)
+	assert.Empty(t, balancesByAccount(t, env, use_cases.LedgerBalanceQuery{}))
+}
+
+func TestLedger_FailedPaymentsAreReversed(t *testing.T) {
+	env := setupLedger(t, 100)
+	ctx := context.Background()
+
+	declinedReq := validRequest()
+	declinedReq.CardNumber = "4000000000000002"
+	declined, err := env.createPayment.Execute(ctx, "ledger-key-4", declinedReq)
+	require.NoError(t, err)
+	entries, err := env.ledgerRepo.ListEntriesByPayment(ctx, declined.Payment.ID)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	pending, err := env.createPayment.Execute(ctx, "ledger-key-5", pendingRequest())
+	require.NoError(t, err)
+	require.Equal(t, domain.PaymentStatusPending, pending.Payment.Status)
+
+	body, sig, ts := signedEvent(t, domain.ProcessorEventRequest{
+		EventID:   "evt-ledger-fail",
+		PaymentID: pending.Payment.ID,
+		Status:    domain.PaymentStatusFailed,
+	})
+	_, err = env.handleEvent.Execute(ctx, body, sig, ts)
+	require.NoError(t, err)
+
+	entries, err = env.ledgerRepo.ListEntriesByPayment(ctx, pending.Payment.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, domain.JournalEntryReversal, entries[2].Type)
+
+	for account, balance := range balancesByAccount(t, env, use_cases.LedgerBalanceQuery{}) {
+		assert.Zero(t, balance, account)
+	}
+}
@@ -0,0 +1,112 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupListPayments(t *testing.T) (*use_cases.ListPaymentsUseCase, domain.PaymentRepository) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+
+	paymentRepo := repositories.NewPaymentRepo(db)
+	return use_cases.NewListPaymentsUseCase(paymentRepo), paymentRepo
+}
+
+func seedPayments(t *testing.T, repo domain.PaymentRepository) {
+	base := time.Now().Add(-time.Hour)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		status := domain.PaymentStatusSucceeded
+		if i%2 == 1 {
+			status = domain.PaymentStatusFailed
+		}
+		require.NoError(t, repo.Create(ctx, &domain.Payment{
+			ID:         fmt.Sprintf("pay-list-%d", i),
+			Amount:     100,
+			Currency:   domain.CurrencyIDR,
+			CustomerID: "cust-list",
+			RideID:     fmt.Sprintf("ride-list-%d", i),
+			Status:     status,
+			CreatedAt:  base.Add(time.Duration(i) * time.Minute),
+		}))
+	}
+	require.NoError(t, repo.Create(ctx, &domain.Payment{
+		ID:         "pay-list-other",
+		Amount:     50,
+		Currency:   domain.CurrencyTHB,
+		CustomerID: "cust-other",
+		RideID:     "ride-other",
+		Status:     domain.PaymentStatusSucceeded,
+		CreatedAt:  base,
+	}))
+}
+
+func TestListPayments_CursorPagination(t *testing.T) {
+	list, repo := setupListPayments(t)
+	seedPayments(t, repo)
+	ctx := context.Background()
+
+	var ids []string
+	cursor := ""
+	for {
+		page, err := list.Execute(ctx, use_cases.ListPaymentsQuery{CustomerID: "cust-list", Limit: "2", Cursor: cursor})
+		require.NoError(t, err)
+		for _, p := range page.Data {
+			ids = append(ids, p.ID)
+		}
+		if !page.HasMore {
+			assert.Empty(t, page.NextCursor)
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	assert.Equal(t, []string{"pay-list-4", "pay-list-3", "pay-list-2", "pay-list-1", "pay-list-0"}, ids)
+}
+
+func TestListPayments_Filters(t *testing.T) {
+	list, repo := setupListPayments(t)
+	seedPayments(t, repo)
+	ctx := context.Background()
+
+	failed, err := list.Execute(ctx, use_cases.ListPaymentsQuery{Status: "FAILED"})
+	require.NoError(t, err)
+	assert.Len(t, failed.Data, 2)
+
+	thb, err := list.Execute(ctx, use_cases.ListPaymentsQuery{Currency: "THB"})
+	require.NoError(t, err)
+	require.Len(t, thb.Data, 1)
+	assert.Equal(t, "pay-list-other", thb.Data[0].ID)
+
+	ride, err := list.Execute(ctx, use_cases.ListPaymentsQuery{RideID: "ride-list-3"})
+	require.NoError(t, err)
+	require.Len(t, ride.Data, 1)
+	assert.Equal(t, "pay-list-3", ride.Data[0].ID)
+
+	empty, err := list.Execute(ctx, use_cases.ListPaymentsQuery{CustomerID: "nobody"})
+	require.NoError(t, err)
+	assert.NotNil(t, empty.Data)
+	assert.Empty(t, empty.Data)
+}
+
+func TestListPayments_CreatedAtRange(t *testing.T) {
+	list, repo := setupListPayments(t)
+	seedPayments(t, repo)
+	ctx := context.Background()
+
+	from := time.Now().Add(-time.Hour).Add(90 * time.Second).Format(time.RFC3339)
+	page, err := list.Execute(ctx, use_cases.ListPaymentsQuery{CustomerID: "cust-list", CreatedFrom: from})
+	require.NoError(t, err)
+	assert.Len(t, page.Data, 3)
+}
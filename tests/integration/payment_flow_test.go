@@ -2,6 +2,7 @@ package integration
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -21,7 +22,7 @@ type testEnv struct {
 	getByIdempotencyKey *use_cases.GetByIdempotencyKeyUseCase
 }
 
-func setupIntegration(t *testing.T) *testEnv {
+func setupIntegration(t *testing.T, opts ...use_cases.CreatePaymentOption) *testEnv {
 	db, err := gormdb.NewTestConnection()
 	require.NoError(t, err)
 
@@ -32,7 +33,7 @@ func setupIntegration(t *testing.T) *testEnv {
 	keyTTL := 24 * time.Hour
 
 	return &testEnv{
-		createPayment:       use_cases.NewCreatePaymentUseCase(txManager, idempotencyRepo, paymentRepo, paymentProcessor, keyTTL),
+		createPayment:       use_cases.NewCreatePaymentUseCase(txManager, idempotencyRepo, paymentRepo, paymentProcessor, keyTTL, opts...),
 		getPayment:          use_cases.NewGetPaymentUseCase(paymentRepo),
 		getByIdempotencyKey: use_cases.NewGetByIdempotencyKeyUseCase(idempotencyRepo),
 	}
@@ -40,7 +41,7 @@ func setupIntegration(t *testing.T) *testEnv {
 
 func validRequest() domain.PaymentRequest {
 	return domain.PaymentRequest{
-		Amount:      100.00,
+		Amount:      "100",
 		Currency:    domain.CurrencyIDR,
 		CustomerID:  "cust-001",
 		RideID:      "ride-001",
@@ -61,7 +62,7 @@ func TestCreatePayment_NewKey_Succeeds(t *testing.T) {
 	assert.False(t, result.Replayed)
 	assert.NotEmpty(t, result.Payment.ID)
 	assert.Equal(t, domain.PaymentStatusSucceeded, result.Payment.Status)
-	assert.Equal(t, 100.00, result.Payment.Amount)
+	assert.Equal(t, int64(100), result.Payment.Amount)
 	assert.Equal(t, domain.CurrencyIDR, result.Payment.Currency)
 	assert.Equal(t, "cust-001", result.Payment.CustomerID)
 	assert.Equal(t, "ride-001", result.Payment.RideID)
@@ -97,7 +98,7 @@ func TestCreatePayment_ConflictDetection(t *testing.T) {
 	require.NoError(t, err)
 
 	req2 := validRequest()
-	req2.Amount = 999.99
+	req2.Amount = "999"
 
 	_, err = env.createPayment.Execute(ctx, "conflict-key", req2)
 	require.Error(t, err)
@@ -238,7 +239,7 @@ func TestCreatePayment_InvalidRequest_ZeroAmount(t *testing.T) {
 	ctx := context.Background()
 
 	req := validRequest()
-	req.Amount = 0
+	req.Amount = "0"
 
 	_, err := env.createPayment.Execute(ctx, "invalid-amount-key", req)
 	require.Error(t, err)
@@ -253,7 +254,7 @@ func TestCreatePayment_InvalidRequest_NegativeAmount(t *testing.T) {
 	ctx := context.Background()
 
 	req := validRequest()
-	req.Amount = -500
+	req.Amount = "-500"
 
 	_, err := env.createPayment.Execute(ctx, "invalid-neg-key", req)
 	require.Error(t, err)
@@ -329,12 +330,13 @@ func TestCreatePayment_AllCurrencies(t *testing.T) {
 
 	currencies := []struct {
 		currency domain.Currency
-		amount   float64
+		amount   json.Number
+		minor    int64
 	}{
-		{domain.CurrencyIDR, 85000},
-		{domain.CurrencyTHB, 450},
-		{domain.CurrencyVND, 250000},
-		{domain.CurrencyPHP, 350},
+		{domain.CurrencyIDR, "85000", 85000},
+		{domain.CurrencyTHB, "450.25", 45025},
+		{domain.CurrencyVND, "250000", 250000},
+		{domain.CurrencyPHP, "350", 35000},
 	}
 
 	for _, tc := range currencies {
@@ -348,7 +350,7 @@ func TestCreatePayment_AllCurrencies(t *testing.T) {
 			require.NotNil(t, result.Payment)
 
 			assert.Equal(t, tc.currency, result.Payment.Currency)
-			assert.Equal(t, tc.amount, result.Payment.Amount)
+			assert.Equal(t, tc.minor, result.Payment.Amount)
 			assert.Equal(t, domain.PaymentStatusSucceeded, result.Payment.Status)
 		})
 	}
@@ -517,14 +519,14 @@ func TestCreatePayment_LargeAmount(t *testing.T) {
 	ctx := context.Background()
 
 	req := validRequest()
-	req.Amount = 999999999.99
-	req.Currency = domain.CurrencyVND
+	req.Amount = "999999999.99"
+	req.Currency = domain.CurrencyTHB
 
 	result, err := env.createPayment.Execute(ctx, "large-amount-key", req)
 	require.NoError(t, err)
 	require.NotNil(t, result.Payment)
 
-	assert.Equal(t, 999999999.99, result.Payment.Amount)
+	assert.Equal(t, int64(99999999999), result.Payment.Amount)
 }
 
 func TestCreatePayment_CardLast4Masked(t *testing.T) {
@@ -545,7 +547,7 @@ func TestEndToEnd_FullPaymentLifecycle(t *testing.T) {
 	ctx := context.Background()
 
 	req := domain.PaymentRequest{
-		Amount:      85000,
+		Amount:      "85000",
 		Currency:    domain.CurrencyIDR,
 		CustomerID:  "cust_jakarta_001",
 		RideID:      "ride_jkt_001",
@@ -572,3 +574,53 @@ func TestEndToEnd_FullPaymentLifecycle(t *testing.T) {
 	assert.Equal(t, domain.IdempotencyStatusCompleted, record.Status)
 	assert.Equal(t, created.Payment.ID, record.PaymentID)
 }
+
+func TestCreatePayment_InvalidAmountPrecision(t *testing.T) {
+	env := setupIntegration(t)
+	ctx := context.Background()
+
+	cases := []struct {
+		currency domain.Currency
+		amount   json.Number
+	}{
+		{domain.CurrencyIDR, "100.5"},
+		{domain.CurrencyVND, "25000.01"},
+		{domain.CurrencyTHB, "45.001"},
+		{domain.CurrencyPHP, "0.125"},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.currency), func(t *testing.T) {
+			req := validRequest()
+			req.Currency = tc.currency
+			req.Amount = tc.amount
+
+			_, err := env.createPayment.Execute(ctx, "precision-"+string(tc.currency), req)
+			require.Error(t, err)
+
+			var appErr *apperrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, "INVALID_AMOUNT_PRECISION", appErr.Code)
+		})
+	}
+}
+
+func TestCreatePayment_EquivalentDecimalsReplay(t *testing.T) {
+	env := setupIntegration(t)
+	ctx := context.Background()
+
+	req := validRequest()
+	req.Currency = domain.CurrencyTHB
+	req.Amount = "120.50"
+
+	first, err := env.createPayment.Execute(ctx, "decimal-key", req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12050), first.Payment.Amount)
+
+	req.Amount = "120.5"
+	second, err := env.createPayment.Execute(ctx, "decimal-key", req)
+	require.NoError(t, err)
+	assert.True(t, second.Replayed)
+	assert.Equal(t, first.Payment.ID, second.Payment.ID)
+	assert.Equal(t, int64(12050), second.Payment.Amount)
+}
@@ -0,0 +1,162 @@
+package integration
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type paymentJobEnv struct {
+	createJob   *use_cases.CreatePaymentJobUseCase
+	getJob      *use_cases.GetPaymentJobUseCase
+	processJobs *use_cases.ProcessPaymentJobsUseCase
+	paymentRepo domain.PaymentRepository
+	file        string
+}
+
+func setupPaymentJobs(t *testing.T, maxRows int) *paymentJobEnv {
+	methods := setupPaymentMethods(t)
+	jobRepo := repositories.NewPaymentJobRepo(methods.db)
+
+	file := "amount,currency,customer_id,ride_id,payment_method_id\n"
+	rows := []struct{ amount, currency, card string }{
+		{"100", "IDR", "4242424242424242"},
+		{"200", "IDR", "4242424242424242"},
+		{"300", "XXX", "4242424242424242"},
+		{"400", "IDR", "4000000000000002"},
+		{"500", "IDR", "4242424242424242"},
+	}
+	for i, row := range rows {
+		customerID := fmt.Sprintf("cust-%03d", i+1)
+		created, err := methods.createMethod.Execute(context.Background(), customerID, cardRequest(row.card))
+		require.NoError(t, err)
+		file += fmt.Sprintf("%s,%s,%s,inv-%03d,%s\n", row.amount, row.currency, customerID, i+1, created.PaymentMethod.ID)
+	}
+
+	return &paymentJobEnv{
+		createJob:   use_cases.NewCreatePaymentJobUseCase(jobRepo, maxRows),
+		getJob:      use_cases.NewGetPaymentJobUseCase(jobRepo),
+		processJobs: use_cases.NewProcessPaymentJobsUseCase(jobRepo, methods.createPayment, 2, 2),
+		paymentRepo: repositories.NewPaymentRepo(methods.db),
+		file:        file,
+	}
+}
+
+func readJobResult(t *testing.T, content []byte) [][]string {
+	records, err := csv.NewReader(strings.NewReader(string(content))).ReadAll()
+	require.NoError(t, err)
+	return records
+}
+
+func TestPaymentJobs_ProcessesRowsAndProducesResultFile(t *testing.T) {
+	env := setupPaymentJobs(t, 100)
+	ctx := context.Background()
+
+	job, err := env.createJob.Execute(ctx, use_cases.CreatePaymentJobCommand{FileName: "invoices.csv", Content: []byte(env.file)})
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentJobQueued, job.Status)
+	assert.Equal(t, 5, job.TotalRows)
+
+	_, err = env.getJob.Result(ctx, job.ID)
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "PAYMENT_JOB_NOT_FINISHED", appErr.Code)
+
+	finished, err := env.processJobs.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, finished)
+
+	done, err := env.getJob.Execute(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentJobCompleted, done.Status)
+	assert.Equal(t, 5, done.ProcessedRows)
+	assert.Equal(t, domain.BatchSummary{Created: 4, Errors: 1}, done.Summary)
+	assert.Equal(t, "/v1/jobs/"+job.ID+"/result", done.ResultURL)
+	assert.NotNil(t, done.StartedAt)
+	assert.NotNil(t, done.CompletedAt)
+
+	content, err := env.getJob.Result(ctx, job.ID)
+	require.NoError(t, err)
+	records := readJobResult(t, content)
+	require.Len(t, records, 6)
+	assert.Equal(t, "line", records[0][0])
+	assert.Equal(t, []string{"2", "CREATED", "SUCCEEDED"}, []string{records[1][0], records[1][2], records[1][5]})
+	assert.Equal(t, []string{"4", "ERROR", "INVALID_CURRENCY"}, []string{records[3][0], records[3][2], records[3][6]})
+	assert.Equal(t, []string{"5", "CREATED", "FAILED"}, []string{records[4][0], records[4][2], records[4][5]})
+}
+
+func TestPaymentJobs_ResubmittedFileReplaysRows(t *testing.T) {
+	env := setupPaymentJobs(t, 100)
+	ctx := context.Background()
+
+	first, err := env.createJob.Execute(ctx, use_cases.CreatePaymentJobCommand{Content: []byte(env.file)})
+	require.NoError(t, err)
+	_, err = env.processJobs.Execute(ctx)
+	require.NoError(t, err)
+
+	second, err := env.createJob.Execute(ctx, use_cases.CreatePaymentJobCommand{Content: []byte(env.file)})
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, second.ID)
+	_, err = env.processJobs.Execute(ctx)
+	require.NoError(t, err)
+
+	done, err := env.getJob.Execute(ctx, second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.BatchSummary{Replayed: 4, Errors: 1}, done.Summary)
+
+	firstResult, err := env.getJob.Result(ctx, first.ID)
+	require.NoError(t, err)
+	secondResult, err := env.getJob.Result(ctx, second.ID)
+	require.NoError(t, err)
+	firstRecords, secondRecords := readJobResult(t, firstResult), readJobResult(t, secondResult)
+	for i := 1; i < len(firstRecords); i++ {
+		assert.Equal(t, firstRecords[i][1], secondRecords[i][1])
+		assert.Equal(t, firstRecords[i][4], secondRecords[i][4])
+	}
+
+	payments, err := env.paymentRepo.List(ctx, domain.PaymentFilter{Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, payments, 4)
+}
+
+func TestPaymentJobs_RejectsInvalidFiles(t *testing.T) {
+	env := setupPaymentJobs(t, 2)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"missing columns", "amount,currency\n100,IDR\n"},
+		{"no rows", "amount,currency,customer_id,ride_id\n"},
+		{"too many rows", env.file},
+		{"card details", "amount,currency,customer_id,ride_id,card_number\n100,IDR,cust-001,inv-001,4242424242424242\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := env.createJob.Execute(ctx, use_cases.CreatePaymentJobCommand{Content: []byte(tt.content)})
+			var appErr *apperrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, "INVALID_PAYMENT_JOB_FILE", appErr.Code)
+		})
+	}
+}
+
+func TestPaymentJobs_UnknownJob(t *testing.T) {
+	env := setupPaymentJobs(t, 100)
+
+	_, err := env.getJob.Execute(context.Background(), "00000000-0000-0000-0000-000000000000")
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "PAYMENT_JOB_NOT_FOUND", appErr.Code)
+}
@@ -0,0 +1,193 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm/repositories"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/processor"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/vault"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type paymentMethodEnv struct {
+	db            *gorm.DB
+	createPayment *use_cases.CreatePaymentUseCase
+	createMethod  *use_cases.CreatePaymentMethodUseCase
+	listMethods   *use_cases.ListPaymentMethodsUseCase
+	deleteMethod  *use_cases.DeletePaymentMethodUseCase
+}
+
+func setupPaymentMethods(t *testing.T) *paymentMethodEnv {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+
+	cardVault, err := vault.NewAESGCMVault("integration-key")
+	require.NoError(t, err)
+
+	methodRepo := repositories.NewPaymentMethodRepo(db)
+	return &paymentMethodEnv{
+		db: db,
+		createPayment: use_cases.NewCreatePaymentUseCase(
+			gormdb.NewTransactionManager(db),
+			repositories.NewIdempotencyRepo(db),
+			repositories.NewPaymentRepo(db),
+			processor.NewSimulator(),
+			24*time.Hour,
+			use_cases.WithPaymentMethods(methodRepo, cardVault),
+		),
+		createMethod: use_cases.NewCreatePaymentMethodUseCase(methodRepo, cardVault),
+		listMethods:  use_cases.NewListPaymentMethodsUseCase(methodRepo),
+		deleteMethod: use_cases.NewDeletePaymentMethodUseCase(methodRepo),
+	}
+}
+
+func cardRequest(number string) domain.PaymentMethodRequest {
+	return domain.PaymentMethodRequest{CardNumber: number, ExpMonth: 12, ExpYear: time.Now().Year() + 3}
+}
+
+func TestPaymentMethods_TokenizeAndCharge(t *testing.T) {
+	env := setupPaymentMethods(t)
+	ctx := context.Background()
+
+	created, err := env.createMethod.Execute(ctx, "cust-001", cardRequest("4000 0000 0000 0002"))
+	require.NoError(t, err)
+	assert.False(t, created.Existing)
+	method := created.PaymentMethod
+	assert.Equal(t, domain.CardBrandVisa, method.CardBrand)
+	assert.Equal(t, "0002", method.CardLast4)
+
+	var stored domain.PaymentMethod
+	require.NoError(t, env.db.First(&stored, "id = ?", method.ID).Error)
+	assert.NotContains(t, string(stored.EncryptedCard), "4000000000000002")
+
+	req := validRequest()
+	req.CardNumber = ""
+	req.PaymentMethodID = method.ID
+	result, err := env.createPayment.Execute(ctx, "pm-charge-key", req)
+	require.NoError(t, err)
+	assert.Equal(t, method.ID, result.Payment.PaymentMethodID)
+	assert.Equal(t, domain.CardBrandVisa, result.Payment.CardBrand)
+	assert.Equal(t, "0002", result.Payment.CardLast4)
+	assert.Equal(t, domain.PaymentStatusFailed, result.Payment.Status, "decrypted card drives the processor outcome")
+
+	replay, err := env.createPayment.Execute(ctx, "pm-charge-key", req)
+	require.NoError(t, err)
+	assert.True(t, replay.Replayed)
+}
+
+func TestPaymentMethods_SameCardReturnsExisting(t *testing.T) {
+	env := setupPaymentMethods(t)
+	ctx := context.Background()
+
+	first, err := env.createMethod.Execute(ctx, "cust-001", cardRequest("4111111111111111"))
+	require.NoError(t, err)
+	second, err := env.createMethod.Execute(ctx, "cust-001", cardRequest("4111-1111-1111-1111"))
+	require.NoError(t, err)
+	assert.True(t, second.Existing)
+	assert.Equal(t, first.PaymentMethod.ID, second.PaymentMethod.ID)
+
+	other, err := env.createMethod.Execute(ctx, "cust-002", cardRequest("4111111111111111"))
+	require.NoError(t, err)
+	assert.False(t, other.Existing)
+}
+
+func TestPaymentMethods_ValidationErrors(t *testing.T) {
+	env := setupPaymentMethods(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		req  domain.PaymentMethodRequest
+		code string
+	}{
+		{"missing card", domain.PaymentMethodRequest{ExpMonth: 1, ExpYear: 2099}, "INVALID_PAYMENT_METHOD"},
+		{"missing expiry", domain.PaymentMethodRequest{CardNumber: "4111111111111111"}, "INVALID_PAYMENT_METHOD"},
+		{"luhn", cardRequest("4111111111111112"), "INVALID_CARD_CHECKSUM"},
+		{"expired", domain.PaymentMethodRequest{CardNumber: "4111111111111111", ExpMonth: 1, ExpYear: 2020}, "CARD_EXPIRED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := env.createMethod.Execute(ctx, "cust-001", tt.req)
+			var appErr *apperrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, tt.code, appErr.Code)
+		})
+	}
+}
+
+func TestPaymentMethods_ChargeRequiresOwnership(t *testing.T) {
+	env := setupPaymentMethods(t)
+	ctx := context.Background()
+
+	created, err := env.createMethod.Execute(ctx, "cust-other", cardRequest("4111111111111111"))
+	require.NoError(t, err)
+
+	req := validRequest()
+	req.CardNumber = ""
+	req.PaymentMethodID = created.PaymentMethod.ID
+	_, err = env.createPayment.Execute(ctx, "pm-foreign", req)
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "PAYMENT_METHOD_NOT_FOUND", appErr.Code)
+
+	req.PaymentMethodID = "pm_missing"
+	_, err = env.createPayment.Execute(ctx, "pm-missing", req)
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "PAYMENT_METHOD_NOT_FOUND", appErr.Code)
+}
+
+func TestPaymentMethods_RequestShapeValidation(t *testing.T) {
+	env := setupPaymentMethods(t)
+	ctx := context.Background()
+
+	both := validRequest()
+	both.PaymentMethodID = "pm_1"
+	_, err := env.createPayment.Execute(ctx, "pm-both", both)
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "INVALID_PAYMENT_REQUEST", appErr.Code)
+
+	neither := validRequest()
+	neither.CardNumber = ""
+	_, err = env.createPayment.Execute(ctx, "pm-neither", neither)
+	require.ErrorAs(t, err, &appErr)
+	assert.Contains(t, appErr.Message, "card_number or payment_method_id is required")
+}
+
+func TestPaymentMethods_ListAndDelete(t *testing.T) {
+	env := setupPaymentMethods(t)
+	ctx := context.Background()
+
+	empty, err := env.listMethods.Execute(ctx, "cust-001")
+	require.NoError(t, err)
+	assert.NotNil(t, empty)
+	assert.Empty(t, empty)
+
+	visa, err := env.createMethod.Execute(ctx, "cust-001", cardRequest("4111111111111111"))
+	require.NoError(t, err)
+	_, err = env.createMethod.Execute(ctx, "cust-001", cardRequest("5500000000000004"))
+	require.NoError(t, err)
+
+	methods, err := env.listMethods.Execute(ctx, "cust-001")
+	require.NoError(t, err)
+	assert.Len(t, methods, 2)
+
+	err = env.deleteMethod.Execute(ctx, "cust-002", visa.PaymentMethod.ID)
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "PAYMENT_METHOD_NOT_FOUND", appErr.Code)
+
+	require.NoError(t, env.deleteMethod.Execute(ctx, "cust-001", visa.PaymentMethod.ID))
+	methods, err = env.listMethods.Execute(ctx, "cust-001")
+	require.NoError(t, err)
+	assert.Len(t, methods, 1)
+}
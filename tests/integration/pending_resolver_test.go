@@ -0,0 +1,142 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm/repositories"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/processor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type resolverEnv struct {
+	createPayment  *use_cases.CreatePaymentUseCase
+	resolvePending *use_cases.ResolvePendingPaymentsUseCase
+	paymentRepo    domain.PaymentRepository
+}
+
+func setupResolver(t *testing.T, settleAfter, maxAge time.Duration, opts ...use_cases.CreatePaymentOption) *resolverEnv {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+
+	txManager := gormdb.NewTransactionManager(db)
+	idempotencyRepo := repositories.NewIdempotencyRepo(db)
+	paymentRepo := repositories.NewPaymentRepo(db)
+	paymentProcessor := processor.NewSimulator(processor.WithPendingSettleAfter(settleAfter))
+
+	return &resolverEnv{
+		createPayment: use_cases.NewCreatePaymentUseCase(txManager, idempotencyRepo, paymentRepo, paymentProcessor, 24*time.Hour, opts...),
+		resolvePending: use_cases.NewResolvePendingPaymentsUseCase(
+			txManager, idempotencyRepo, paymentRepo, repositories.NewOutboxRepo(db), paymentProcessor,
+			time.Second, time.Minute, maxAge, 10,
+		),
+		paymentRepo: paymentRepo,
+	}
+}
+
+func pendingRequest() domain.PaymentRequest {
+	req := validRequest()
+	req.CardNumber = "4000000000000259"
+	return req
+}
+
+func TestResolvePending_SettledPaymentUpdatesReplay(t *testing.T) {
+	env := setupResolver(t, 0, time.Hour)
+	ctx := context.Background()
+
+	created, err := env.createPayment.Execute(ctx, "resolver-settle-key", pendingRequest())
+	require.NoError(t, err)
+	require.Equal(t, domain.PaymentStatusPending, created.Payment.Status)
+
+	resolved, err := env.resolvePending.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resolved)
+
+	found, err := env.paymentRepo.FindByID(ctx, created.Payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusSucceeded, found.Status)
+
+	replay, err := env.createPayment.Execute(ctx, "resolver-settle-key", pendingRequest())
+	require.NoError(t, err)
+	assert.True(t, replay.Replayed)
+	assert.Equal(t, domain.PaymentStatusSucceeded, replay.Payment.Status)
+}
+
+func TestResolvePending_SettledPaymentUpdatesDuplicateRideReplay(t *testing.T) {
+	env := setupResolver(t, 0, time.Hour, use_cases.WithDuplicateRideGuard(use_cases.DuplicateRidePolicyReturnOriginal, 10*time.Minute))
+	ctx := context.Background()
+
+	created, err := env.createPayment.Execute(ctx, "resolver-ride-key-1", pendingRequest())
+	require.NoError(t, err)
+	require.Equal(t, domain.PaymentStatusPending, created.Payment.Status)
+
+	duplicate, err := env.createPayment.Execute(ctx, "resolver-ride-key-2", validRequest())
+	require.NoError(t, err)
+	require.True(t, duplicate.Duplicate)
+	assert.Equal(t, created.Payment.ID, duplicate.Payment.ID)
+
+	resolved, err := env.resolvePending.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resolved)
+
+	requests := map[string]domain.PaymentRequest{
+		"resolver-ride-key-1": pendingRequest(),
+		"resolver-ride-key-2": validRequest(),
+	}
+	for key, req := range requests {
+		replay, err := env.createPayment.Execute(ctx, key, req)
+		require.NoError(t, err)
+		assert.True(t, replay.Replayed, key)
+		assert.Equal(t, domain.PaymentStatusSucceeded, replay.Payment.Status, key)
+	}
+}
+
+func TestResolvePending_StillPendingSchedulesBackoff(t *testing.T) {
+	env := setupResolver(t, time.Hour, time.Hour)
+	ctx := context.Background()
+
+	created, err := env.createPayment.Execute(ctx, "resolver-backoff-key", pendingRequest())
+	require.NoError(t, err)
+
+	resolved, err := env.resolvePending.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, resolved)
+
+	found, err := env.paymentRepo.FindByID(ctx, created.Payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusPending, found.Status)
+	assert.Equal(t, 1, found.StatusChecks)
+	require.NotNil(t, found.NextStatusCheckAt)
+	assert.True(t, found.NextStatusCheckAt.After(time.Now()))
+
+	resolved, err = env.resolvePending.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, resolved)
+
+	found, err = env.paymentRepo.FindByID(ctx, created.Payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, found.StatusChecks)
+}
+
+func TestResolvePending_MaxAgeFailsPayment(t *testing.T) {
+	env := setupResolver(t, time.Hour, 0)
+	ctx := context.Background()
+
+	created, err := env.createPayment.Execute(ctx, "resolver-timeout-key", pendingRequest())
+	require.NoError(t, err)
+
+	resolved, err := env.resolvePending.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resolved)
+
+	replay, err := env.createPayment.Execute(ctx, "resolver-timeout-key", pendingRequest())
+	require.NoError(t, err)
+	assert.Equal(t, created.Payment.ID, replay.Payment.ID)
+	assert.Equal(t, domain.PaymentStatusFailed, replay.Payment.Status)
+	assert.Equal(t, "pending_timeout", replay.Payment.FailReason)
+}
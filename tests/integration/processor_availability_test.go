@@ -0,0 +1,113 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm/repositories"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/processor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePayment_ProcessorUnavailableIsNotCached(t *testing.T) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+
+	txManager := gormdb.NewTransactionManager(db)
+	idempotencyRepo := repositories.NewIdempotencyRepo(db)
+	paymentRepo := repositories.NewPaymentRepo(db)
+	getByKey := use_cases.NewGetByIdempotencyKeyUseCase(idempotencyRepo)
+	ctx := context.Background()
+
+	breaker := processor.NewBreaker("sim_a", processor.NewSimulator(processor.WithUnavailableRate(1)),
+		processor.WithFailureThreshold(1),
+		processor.WithOpenDuration(time.Hour),
+	)
+	down := use_cases.NewCreatePaymentUseCase(txManager, idempotencyRepo, paymentRepo, breaker, 24*time.Hour)
+
+	for i := 0; i < 2; i++ {
+		_, err = down.Execute(ctx, "outage-key", validRequest())
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, "PROCESSOR_UNAVAILABLE", appErr.Code)
+		assert.Equal(t, http.StatusServiceUnavailable, appErr.HTTPCode)
+	}
+	assert.Equal(t, processor.BreakerOpen, breaker.State())
+
+	_, err = getByKey.Execute(ctx, "outage-key")
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "IDEMPOTENCY_KEY_NOT_FOUND", appErr.Code)
+
+	up := use_cases.NewCreatePaymentUseCase(txManager, idempotencyRepo, paymentRepo, processor.NewSimulator(), 24*time.Hour)
+	result, err := up.Execute(ctx, "outage-key", validRequest())
+	require.NoError(t, err)
+	assert.False(t, result.Replayed)
+	assert.Equal(t, domain.PaymentStatusSucceeded, result.Payment.Status)
+}
+
+func TestCreatePayment_ProcessorTimeoutReturnsUnknown(t *testing.T) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+
+	slow := processor.NewBreaker("sim_a",
+		processor.NewSimulator(processor.WithLatency(time.Second, time.Second)),
+		processor.WithCallTimeout(50*time.Millisecond),
+	)
+	createPayment := use_cases.NewCreatePaymentUseCase(
+		gormdb.NewTransactionManager(db), repositories.NewIdempotencyRepo(db), repositories.NewPaymentRepo(db), slow, 24*time.Hour,
+	)
+
+	start := time.Now()
+	result, err := createPayment.Execute(context.Background(), "slow-key", validRequest())
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusUnknown, result.Payment.Status)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestCreatePayment_RetryAfterTimeoutChargesOnce(t *testing.T) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+
+	txManager := gormdb.NewTransactionManager(db)
+	idempotencyRepo := repositories.NewIdempotencyRepo(db)
+	paymentRepo := repositories.NewPaymentRepo(db)
+	sim := processor.NewSimulator(processor.WithLatency(200*time.Millisecond, 200*time.Millisecond))
+	ctx := context.Background()
+
+	impatient := use_cases.NewCreatePaymentUseCase(txManager, idempotencyRepo, paymentRepo,
+		processor.NewBreaker("sim", sim, processor.WithCallTimeout(20*time.Millisecond)), 24*time.Hour)
+	first, err := impatient.Execute(ctx, "timeout-retry-key", validRequest())
+	require.NoError(t, err)
+	require.Equal(t, domain.PaymentStatusUnknown, first.Payment.Status)
+
+	patient := use_cases.NewCreatePaymentUseCase(txManager, idempotencyRepo, paymentRepo,
+		processor.NewBreaker("sim", sim, processor.WithCallTimeout(5*time.Second)), 24*time.Hour)
+	result, err := patient.Execute(ctx, "timeout-retry-key", validRequest())
+	require.NoError(t, err)
+	assert.True(t, result.Replayed)
+	assert.Equal(t, first.Payment.ID, result.Payment.ID)
+
+	charged, err := sim.LookupByKey(ctx, domain.ProcessorIdempotencyKey("timeout-retry-key"))
+	require.NoError(t, err)
+	require.NotNil(t, charged)
+
+	var buf bytes.Buffer
+	require.NoError(t, sim.(domain.SettlementFileGenerator).GenerateSettlementFile(ctx, charged.CreatedAt, &buf))
+	rows, err := domain.ParseSettlementCSV(&buf)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, charged.ID, rows[0].ProcessorReference)
+
+	other, err := patient.Execute(ctx, "another-key", validRequest())
+	require.NoError(t, err)
+	assert.NotEqual(t, result.Payment.ID, other.Payment.ID)
+}
@@ -0,0 +1,171 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm/repositories"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/processor"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/utils/signature"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const processorSecret = "processor-test-secret"
+
+type processorEventsEnv struct {
+	createPayment *use_cases.CreatePaymentUseCase
+	handleEvent   *use_cases.HandleProcessorEventUseCase
+	outboxRepo    domain.OutboxRepository
+}
+
+func setupProcessorEvents(t *testing.T) *processorEventsEnv {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+
+	txManager := gormdb.NewTransactionManager(db)
+	idempotencyRepo := repositories.NewIdempotencyRepo(db)
+	paymentRepo := repositories.NewPaymentRepo(db)
+	outboxRepo := repositories.NewOutboxRepo(db)
+
+	return &processorEventsEnv{
+		createPayment: use_cases.NewCreatePaymentUseCase(
+			txManager, idempotencyRepo, paymentRepo, processor.NewSimulator(), 24*time.Hour,
+			use_cases.WithOutbox(outboxRepo),
+		),
+		handleEvent: use_cases.NewHandleProcessorEventUseCase(
+			txManager, repositories.NewProcessorEventRepo(db), idempotencyRepo, paymentRepo, outboxRepo,
+			processorSecret, 5*time.Minute,
+		),
+		outboxRepo: outboxRepo,
+	}
+}
+
+func signedEvent(t *testing.T, event domain.ProcessorEventRequest) ([]byte, string, string) {
+	body, err := json.Marshal(event)
+	require.NoError(t, err)
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	return body, signature.Sign(processorSecret, ts, body), ts
+}
+
+func TestProcessorEvent_AppliesTransitionAndDeduplicates(t *testing.T) {
+	env := setupProcessorEvents(t)
+	ctx := context.Background()
+
+	created, err := env.createPayment.Execute(ctx, "callback-key", pendingRequest())
+	require.NoError(t, err)
+	require.Equal(t, domain.PaymentStatusPending, created.Payment.Status)
+
+	body, sig, ts := signedEvent(t, domain.ProcessorEventRequest{
+		EventID:   "evt-callback-001",
+		PaymentID: created.Payment.ID,
+		Status:    domain.PaymentStatusSucceeded,
+	})
+
+	first, err := env.handleEvent.Execute(ctx, body, sig, ts)
+	require.NoError(t, err)
+	assert.False(t, first.Duplicate)
+	assert.Equal(t, domain.ProcessorEventOutcomeApplied, first.Event.Outcome)
+
+	second, err := env.handleEvent.Execute(ctx, body, sig, ts)
+	require.NoError(t, err)
+	assert.True(t, second.Duplicate)
+	assert.Equal(t, domain.ProcessorEventOutcomeApplied, second.Event.Outcome)
+
+	replay, err := env.createPayment.Execute(ctx, "callback-key", pendingRequest())
+	require.NoError(t, err)
+	assert.True(t, replay.Replayed)
+	assert.Equal(t, domain.PaymentStatusSucceeded, replay.Payment.Status)
+
+	events, err := env.outboxRepo.FindPending(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, domain.EventPaymentPending, events[0].EventType)
+	assert.Equal(t, domain.EventPaymentSucceeded, events[1].EventType)
+}
+
+func TestProcessorEvent_FinalPaymentIsIgnored(t *testing.T) {
+	env := setupProcessorEvents(t)
+	ctx := context.Background()
+
+	created, err := env.createPayment.Execute(ctx, "callback-final-key", validRequest())
+	require.NoError(t, err)
+
+	body, sig, ts := signedEvent(t, domain.ProcessorEventRequest{
+		EventID:    "evt-callback-late",
+		PaymentID:  created.Payment.ID,
+		Status:     domain.PaymentStatusFailed,
+		FailReason: "insufficient_funds",
+	})
+
+	result, err := env.handleEvent.Execute(ctx, body, sig, ts)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ProcessorEventOutcomeIgnored, result.Event.Outcome)
+
+	replay, err := env.createPayment.Execute(ctx, "callback-final-key", validRequest())
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusSucceeded, replay.Payment.Status)
+}
+
+func TestProcessorEvent_InvalidSignatureRejected(t *testing.T) {
+	env := setupProcessorEvents(t)
+
+	body, _, ts := signedEvent(t, domain.ProcessorEventRequest{
+		EventID:   "evt-bad-sig",
+		PaymentID: "pay-1",
+		Status:    domain.PaymentStatusSucceeded,
+	})
+
+	_, err := env.handleEvent.Execute(context.Background(), body, "v1=deadbeef", ts)
+	require.Error(t, err)
+
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "INVALID_PROCESSOR_SIGNATURE", appErr.Code)
+	assert.Equal(t, 401, appErr.HTTPCode)
+}
+
+func TestProcessorEvent_UnknownPaymentNotRecorded(t *testing.T) {
+	env := setupProcessorEvents(t)
+	ctx := context.Background()
+
+	body, sig, ts := signedEvent(t, domain.ProcessorEventRequest{
+		EventID:   "evt-unknown-payment",
+		PaymentID: "pay-missing",
+		Status:    domain.PaymentStatusSucceeded,
+	})
+
+	_, err := env.handleEvent.Execute(ctx, body, sig, ts)
+	require.Error(t, err)
+
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "PAYMENT_NOT_FOUND", appErr.Code)
+
+	_, err = env.handleEvent.Execute(ctx, body, sig, ts)
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "PAYMENT_NOT_FOUND", appErr.Code)
+}
+
+func TestProcessorEvent_InvalidPayload(t *testing.T) {
+	env := setupProcessorEvents(t)
+
+	body, sig, ts := signedEvent(t, domain.ProcessorEventRequest{
+		PaymentID: "pay-1",
+		Status:    domain.PaymentStatusSucceeded,
+	})
+
+	_, err := env.handleEvent.Execute(context.Background(), body, sig, ts)
+	require.Error(t, err)
+
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "INVALID_PROCESSOR_EVENT", appErr.Code)
+}
@@ -0,0 +1,87 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit_DatabaseStoreIsSharedAcrossInstances(t *testing.T) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	policy := use_cases.RateLimitPolicy{
+		domain.RateLimitScopeAPIKey:   {Limit: 3, Period: time.Minute},
+		domain.RateLimitScopeCustomer: {Limit: 2, Period: time.Minute},
+	}
+	instanceA := use_cases.NewCheckRateLimitUseCase(repositories.NewRateLimitRepo(db), policy)
+	instanceB := use_cases.NewCheckRateLimitUseCase(repositories.NewRateLimitRepo(db), policy)
+	subject := use_cases.RateLimitSubject{APIKey: "sk_test_1", CustomerID: "cust-001", IP: "10.0.0.1"}
+
+	decision, err := instanceA.Execute(ctx, subject)
+	require.NoError(t, err)
+	assert.Equal(t, 2, decision.Limit)
+	assert.Equal(t, 1, decision.Remaining)
+
+	_, err = instanceB.Execute(ctx, subject)
+	require.NoError(t, err)
+
+	decision, err = instanceA.Execute(ctx, subject)
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "RATE_LIMITED", appErr.Code)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 30*time.Second, decision.RetryAfter.Round(time.Second))
+
+	other := use_cases.RateLimitSubject{APIKey: "sk_test_1", CustomerID: "cust-002"}
+	decision, err = instanceB.Execute(ctx, other)
+	require.NoError(t, err, "the denied request gave its API key token back")
+	assert.Equal(t, 0, decision.Remaining)
+
+	_, err = instanceB.Execute(ctx, other)
+	require.ErrorAs(t, err, &appErr, "the API key bucket is shared by both customers")
+
+	var buckets int64
+	require.NoError(t, db.Model(&domain.TokenBucket{}).Where("key LIKE ?", "api_key:%").Count(&buckets).Error)
+	assert.Equal(t, int64(1), buckets)
+	var stored domain.TokenBucket
+	require.NoError(t, db.Where("key LIKE ?", "api_key:%").First(&stored).Error)
+	assert.NotContains(t, stored.Key, "sk_test_1")
+}
+
+func TestRateLimit_DeniedRequestsDoNotDrainOtherScopes(t *testing.T) {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	policy := use_cases.RateLimitPolicy{
+		domain.RateLimitScopeAPIKey:   {Limit: 5, Period: time.Minute},
+		domain.RateLimitScopeCustomer: {Limit: 1, Period: time.Minute},
+	}
+	limiter := use_cases.NewCheckRateLimitUseCase(repositories.NewRateLimitRepo(db), policy)
+	noisy := use_cases.RateLimitSubject{APIKey: "sk_test_2", CustomerID: "cust-noisy"}
+
+	_, err = limiter.Execute(ctx, noisy)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		_, err = limiter.Execute(ctx, noisy)
+		require.Error(t, err)
+	}
+
+	for i := 0; i < 4; i++ {
+		_, err = limiter.Execute(ctx, use_cases.RateLimitSubject{APIKey: "sk_test_2", CustomerID: fmt.Sprintf("cust-quiet-%d", i)})
+		require.NoError(t, err, "denied requests gave their API key tokens back")
+	}
+	_, err = limiter.Execute(ctx, use_cases.RateLimitSubject{APIKey: "sk_test_2", CustomerID: "cust-quiet-4"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/receipt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type receiptEnv struct {
+	*ledgerEnv
+	getReceipt *use_cases.GetPaymentReceiptUseCase
+}
+
+func setupReceipts(t *testing.T) *receiptEnv {
+	env := setupLedger(t, 100)
+	renderer, err := receipt.NewTemplateRenderer("")
+	require.NoError(t, err)
+	return &receiptEnv{
+		ledgerEnv:  env,
+		getReceipt: use_cases.NewGetPaymentReceiptUseCase(env.paymentRepo, env.ledgerRepo, renderer),
+	}
+}
+
+func TestReceipts_RendersLocalizedReceipt(t *testing.T) {
+	env := setupReceipts(t)
+	ctx := context.Background()
+
+	req := validRequest()
+	req.Amount = "1250000"
+	result, err := env.createPayment.Execute(ctx, "receipt-key-1", req)
+	require.NoError(t, err)
+
+	html, err := env.getReceipt.Execute(ctx, result.Payment.ID, use_cases.ReceiptQuery{Language: "en-US"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReceiptFormatHTML, html.Format)
+	assert.Equal(t, "en", html.Language)
+	assert.Contains(t, string(html.Body), "Rp 1.250.000")
+	assert.Contains(t, string(html.Body), "VISA •••• 4242")
+	assert.Contains(t, string(html.Body), "ride-001")
+	assert.NotContains(t, string(html.Body), "4242424242424242")
+
+	text, err := env.getReceipt.Execute(ctx, result.Payment.ID, use_cases.ReceiptQuery{Language: "es-MX", Format: "text"})
+	require.NoError(t, err)
+	assert.Equal(t, "es", text.Language)
+	assert.Contains(t, string(text.Body), "Monto cobrado: Rp 1.250.000")
+}
+
+func TestReceipts_ShowReversals(t *testing.T) {
+	env := setupReceipts(t)
+	ctx := context.Background()
+
+	pending, err := env.createPayment.Execute(ctx, "receipt-key-2", pendingRequest())
+	require.NoError(t, err)
+
+	body, sig, ts := signedEvent(t, domain.ProcessorEventRequest{
+		EventID:   "evt-receipt-fail",
+		PaymentID: pending.Payment.ID,
+		Status:    domain.PaymentStatusFailed,
+	})
+	_, err = env.handleEvent.Execute(ctx, body, sig, ts)
+	require.NoError(t, err)
+
+	rendered, err := env.getReceipt.Execute(ctx, pending.Payment.ID, use_cases.ReceiptQuery{Format: "text"})
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered.Body), "Reversal")
+	assert.Contains(t, string(rendered.Body), "Net paid: Rp 0")
+}
+
+func TestReceipts_Errors(t *testing.T) {
+	env := setupReceipts(t)
+	ctx := context.Background()
+
+	declinedReq := validRequest()
+	declinedReq.CardNumber = "4000000000000002"
+	declined, err := env.createPayment.Execute(ctx, "receipt-key-3", declinedReq)
+	require.NoError(t, err)
+
+	var appErr *apperrors.AppError
+	_, err = env.getReceipt.Execute(ctx, declined.Payment.ID, use_cases.ReceiptQuery{})
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, "RECEIPT_UNAVAILABLE", appErr.Code)
+
+	_, err = env.getReceipt.Execute(ctx, "missing", use_cases.ReceiptQuery{})
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, "PAYMENT_NOT_FOUND", appErr.Code)
+
+	_, err = env.getReceipt.Execute(ctx, declined.Payment.ID, use_cases.ReceiptQuery{Format: "pdf"})
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, "INVALID_RECEIPT_REQUEST", appErr.Code)
+
+	_, err = env.getReceipt.Execute(ctx, declined.Payment.ID, use_cases.ReceiptQuery{Tenant: "../etc"})
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, "INVALID_RECEIPT_REQUEST", appErr.Code)
+}
@@ -0,0 +1,168 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm/repositories"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/processor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type reconciliationEnv struct {
+	createPayment     *use_cases.CreatePaymentUseCase
+	reconcile         *use_cases.ReconcileSettlementUseCase
+	getReconciliation *use_cases.GetReconciliationUseCase
+	generateFile      *use_cases.GenerateSettlementFileUseCase
+}
+
+func setupReconciliation(t *testing.T) *reconciliationEnv {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+
+	paymentRepo := repositories.NewPaymentRepo(db)
+	reconciliationRepo := repositories.NewReconciliationRepo(db)
+	sim := processor.NewSimulator(processor.WithPendingSettleAfter(time.Hour))
+
+	return &reconciliationEnv{
+		createPayment: use_cases.NewCreatePaymentUseCase(
+			gormdb.NewTransactionManager(db), repositories.NewIdempotencyRepo(db), paymentRepo, sim, 24*time.Hour,
+		),
+		reconcile:         use_cases.NewReconcileSettlementUseCase(paymentRepo, reconciliationRepo),
+		getReconciliation: use_cases.NewGetReconciliationUseCase(reconciliationRepo),
+		generateFile:      use_cases.NewGenerateSettlementFileUseCase(sim),
+	}
+}
+
+func (env *reconciliationEnv) pay(t *testing.T, key, card string) *domain.Payment {
+	req := validRequest()
+	req.RideID = key
+	req.CardNumber = card
+	result, err := env.createPayment.Execute(context.Background(), key, req)
+	require.NoError(t, err)
+	return result.Payment
+}
+
+func TestReconciliation_ReportsEveryOutcome(t *testing.T) {
+	env := setupReconciliation(t)
+	ctx := context.Background()
+
+	matched := env.pay(t, "recon-matched", "4242424242424242")
+	mismatched := env.pay(t, "recon-mismatch", "4242424242424242")
+	missing := env.pay(t, "recon-missing", "4242424242424242")
+	pending := env.pay(t, "recon-pending", "4000000000000259")
+	env.pay(t, "recon-failed", "4000000000000002")
+	settlementDate := matched.CreatedAt.UTC().Format(domain.SettlementDateLayout)
+
+	generated, err := env.generateFile.Execute(ctx, settlementDate)
+	require.NoError(t, err)
+	rows, err := domain.ParseSettlementCSV(bytes.NewReader(generated))
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	var edited []domain.SettlementRow
+	for _, row := range rows {
+		switch row.ProcessorReference {
+		case missing.ID:
+			continue
+		case mismatched.ID:
+			row.Amount.Amount -= 1
+		}
+		edited = append(edited, row)
+	}
+	edited = append(edited,
+		domain.SettlementRow{ProcessorReference: pending.ID, Amount: pending.Money()},
+		domain.SettlementRow{ProcessorReference: "proc-ref-unknown", Amount: domain.Money{Amount: 500, Currency: domain.CurrencyIDR}},
+	)
+	var file bytes.Buffer
+	require.NoError(t, domain.WriteSettlementCSV(&file, edited))
+
+	report, err := env.reconcile.Execute(ctx, use_cases.ReconcileSettlementCommand{
+		SettlementDate: settlementDate,
+		FileName:       "settlement.csv",
+		Content:        file.Bytes(),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, report.Rows)
+	assert.Equal(t, 1, report.Matched)
+	assert.Equal(t, 1, report.AmountMismatches)
+	assert.Equal(t, 1, report.StatusMismatches)
+	assert.Equal(t, 1, report.MissingInOurs)
+	assert.Equal(t, 1, report.MissingInTheirs)
+	assert.Len(t, report.FileSHA256, 64)
+
+	outcomes := make(map[string]domain.ReconciliationOutcome)
+	for _, item := range report.Items {
+		key := item.PaymentID
+		if key == "" {
+			key = item.ProcessorReference
+		}
+		outcomes[key] = item.Outcome
+	}
+	assert.Equal(t, map[string]domain.ReconciliationOutcome{
+		matched.ID:         domain.ReconciliationMatched,
+		mismatched.ID:      domain.ReconciliationAmountMismatch,
+		missing.ID:         domain.ReconciliationMissingInTheirs,
+		pending.ID:         domain.ReconciliationStatusMismatch,
+		"proc-ref-unknown": domain.ReconciliationMissingInOurs,
+	}, outcomes)
+
+	stored, err := env.getReconciliation.Execute(ctx, report.ID)
+	require.NoError(t, err)
+	assert.Equal(t, report.Items, stored.Items)
+	assert.Equal(t, settlementDate, stored.SettlementDate)
+	assert.Equal(t, "settlement.csv", stored.FileName)
+}
+
+func TestReconciliation_GeneratedFileMatchesCleanly(t *testing.T) {
+	env := setupReconciliation(t)
+	ctx := context.Background()
+
+	var last *domain.Payment
+	for i := 0; i < 3; i++ {
+		last = env.pay(t, fmt.Sprintf("recon-clean-%d", i), "4242424242424242")
+	}
+	settlementDate := last.CreatedAt.UTC().Format(domain.SettlementDateLayout)
+
+	generated, err := env.generateFile.Execute(ctx, settlementDate)
+	require.NoError(t, err)
+
+	report, err := env.reconcile.Execute(ctx, use_cases.ReconcileSettlementCommand{SettlementDate: settlementDate, Content: generated})
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Matched)
+	assert.Equal(t, 3, report.Rows)
+	assert.Len(t, report.Items, 3)
+}
+
+func TestReconciliation_RejectsInvalidInput(t *testing.T) {
+	env := setupReconciliation(t)
+	ctx := context.Background()
+
+	_, err := env.reconcile.Execute(ctx, use_cases.ReconcileSettlementCommand{
+		SettlementDate: "18/10/2026",
+		Content:        []byte("processor_reference,amount,currency\n"),
+	})
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "INVALID_SETTLEMENT_DATE", appErr.Code)
+
+	_, err = env.reconcile.Execute(ctx, use_cases.ReconcileSettlementCommand{
+		SettlementDate: "2026-10-18",
+		Content:        []byte("reference,total\nabc,100\n"),
+	})
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "INVALID_SETTLEMENT_FILE", appErr.Code)
+
+	_, err = env.getReconciliation.Execute(ctx, "missing")
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "RECONCILIATION_NOT_FOUND", appErr.Code)
+}
@@ -0,0 +1,159 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm/repositories"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/processor"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/receipt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type refundEnv struct {
+	*ledgerEnv
+	refund     *use_cases.RefundPaymentUseCase
+	getReceipt *use_cases.GetPaymentReceiptUseCase
+}
+
+func setupRefunds(t *testing.T) *refundEnv {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+
+	txManager := gormdb.NewTransactionManager(db)
+	idempotencyRepo := repositories.NewIdempotencyRepo(db)
+	paymentRepo := repositories.NewPaymentRepo(db)
+	ledgerRepo := repositories.NewLedgerRepo(db)
+	ledger := use_cases.NewLedgerRecorder(ledgerRepo, 0)
+	simulator := processor.NewSimulator()
+	renderer, err := receipt.NewTemplateRenderer("")
+	require.NoError(t, err)
+
+	return &refundEnv{
+		ledgerEnv: &ledgerEnv{
+			createPayment: use_cases.NewCreatePaymentUseCase(
+				txManager, idempotencyRepo, paymentRepo, simulator, 24*time.Hour,
+				use_cases.WithLedger(ledger),
+			),
+			getBalances: use_cases.NewGetLedgerBalancesUseCase(ledgerRepo),
+			ledgerRepo:  ledgerRepo,
+			paymentRepo: paymentRepo,
+		},
+		refund: use_cases.NewRefundPaymentUseCase(
+			txManager, paymentRepo, repositories.NewRefundRepo(db), idempotencyRepo, simulator, ledger,
+		),
+		getReceipt: use_cases.NewGetPaymentReceiptUseCase(paymentRepo, ledgerRepo, renderer),
+	}
+}
+
+func TestRefunds_PartialThenFullRefundBalancesLedger(t *testing.T) {
+	env := setupRefunds(t)
+	ctx := context.Background()
+
+	charged, err := env.createPayment.Execute(ctx, "refund-charge-1", splitRequest())
+	require.NoError(t, err)
+	paymentID := charged.Payment.ID
+
+	partial, err := env.refund.Execute(ctx, "refund-key-1", paymentID, domain.RefundRequest{Amount: "50000", Reason: " ride cancelled "})
+	require.NoError(t, err)
+	assert.False(t, partial.Replayed)
+	assert.Equal(t, int64(50000), partial.Refund.Amount)
+	assert.Equal(t, "ride cancelled", partial.Refund.Reason)
+	assert.NotEmpty(t, partial.Refund.ProcessorReference)
+
+	balances := balancesByAccount(t, env.ledgerEnv, use_cases.LedgerBalanceQuery{Currency: "IDR"})
+	assert.Equal(t, int64(100000), balances["processor_receivable:IDR"])
+	assert.Equal(t, int64(80000), balances["recipient:driver-77:IDR"])
+	assert.Equal(t, int64(20000), balances["recipient:platform:IDR"])
+
+	_, err = env.refund.Execute(ctx, "refund-key-2", paymentID, domain.RefundRequest{Amount: "100001"})
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "REFUND_EXCEEDS_PAYMENT", appErr.Code)
+
+	rest, err := env.refund.Execute(ctx, "refund-key-3", paymentID, domain.RefundRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(100000), rest.Refund.Amount)
+
+	entries, err := env.ledgerRepo.ListEntriesByPayment(ctx, paymentID)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, domain.JournalEntryRefund, entries[1].Type)
+	assert.Equal(t, domain.JournalEntryRefund, entries[2].Type)
+	for _, entry := range entries {
+		assert.NoError(t, entry.Validate())
+	}
+	for account, balance := range balancesByAccount(t, env.ledgerEnv, use_cases.LedgerBalanceQuery{}) {
+		assert.Zero(t, balance, account)
+	}
+
+	rendered, err := env.getReceipt.Execute(ctx, paymentID, use_cases.ReceiptQuery{Format: "text"})
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered.Body), "Total refunded: -Rp 150.000")
+	assert.Contains(t, string(rendered.Body), "Net paid: Rp 0")
+
+	_, err = env.refund.Execute(ctx, "refund-key-4", paymentID, domain.RefundRequest{})
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "REFUND_NOT_ALLOWED", appErr.Code)
+}
+
+func TestRefunds_IdempotencyKeyReplaysAndConflicts(t *testing.T) {
+	env := setupRefunds(t)
+	ctx := context.Background()
+
+	charged, err := env.createPayment.Execute(ctx, "refund-charge-2", validRequest())
+	require.NoError(t, err)
+
+	first, err := env.refund.Execute(ctx, "refund-key-5", charged.Payment.ID, domain.RefundRequest{Amount: "40"})
+	require.NoError(t, err)
+
+	replay, err := env.refund.Execute(ctx, "refund-key-5", charged.Payment.ID, domain.RefundRequest{Amount: "40"})
+	require.NoError(t, err)
+	assert.True(t, replay.Replayed)
+	assert.Equal(t, first.Refund.ID, replay.Refund.ID)
+
+	_, err = env.refund.Execute(ctx, "refund-key-5", charged.Payment.ID, domain.RefundRequest{Amount: "41"})
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "IDEMPOTENCY_KEY_CONFLICT", appErr.Code)
+
+	_, err = env.refund.Execute(ctx, "refund-charge-2", charged.Payment.ID, domain.RefundRequest{Amount: "1"})
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "IDEMPOTENCY_KEY_CONFLICT", appErr.Code, "payment keys cannot be reused for refunds")
+
+	entries, err := env.ledgerRepo.ListEntriesByPayment(ctx, charged.Payment.ID)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "replays do not post again")
+}
+
+func TestRefunds_RejectsUnrefundablePayments(t *testing.T) {
+	env := setupRefunds(t)
+	ctx := context.Background()
+
+	declinedReq := validRequest()
+	declinedReq.CardNumber = "4000000000000002"
+	declined, err := env.createPayment.Execute(ctx, "refund-charge-3", declinedReq)
+	require.NoError(t, err)
+
+	_, err = env.refund.Execute(ctx, "refund-key-6", declined.Payment.ID, domain.RefundRequest{})
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "REFUND_NOT_ALLOWED", appErr.Code)
+	assert.Equal(t, 409, appErr.HTTPCode)
+
+	_, err = env.refund.Execute(ctx, "refund-key-7", "missing", domain.RefundRequest{})
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "PAYMENT_NOT_FOUND", appErr.Code)
+
+	charged, err := env.createPayment.Execute(ctx, "refund-charge-4", validRequest())
+	require.NoError(t, err)
+	_, err = env.refund.Execute(ctx, "refund-key-8", charged.Payment.ID, domain.RefundRequest{Amount: "0"})
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "INVALID_REFUND", appErr.Code)
+}
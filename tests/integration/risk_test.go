@@ -0,0 +1,183 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm/repositories"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/processor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type riskEnv struct {
+	createPayment  *use_cases.CreatePaymentUseCase
+	getRisk        *use_cases.GetRiskAssessmentUseCase
+	createCustomer *use_cases.CreateCustomerUseCase
+	ledgerRepo     domain.LedgerRepository
+}
+
+func setupRisk(t *testing.T, rules func(domain.RiskAssessmentRepository, domain.CustomerRepository) []domain.RiskRule) *riskEnv {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+
+	riskRepo := repositories.NewRiskAssessmentRepo(db)
+	customerRepo := repositories.NewCustomerRepo(db)
+	ledgerRepo := repositories.NewLedgerRepo(db)
+
+	return &riskEnv{
+		createPayment: use_cases.NewCreatePaymentUseCase(
+			gormdb.NewTransactionManager(db), repositories.NewIdempotencyRepo(db), repositories.NewPaymentRepo(db),
+			processor.NewSimulator(), 24*time.Hour,
+			use_cases.WithRiskEngine(use_cases.NewRiskEngine(riskRepo, nil, rules(riskRepo, customerRepo)...)),
+			use_cases.WithLedger(use_cases.NewLedgerRecorder(ledgerRepo, 0)),
+		),
+		getRisk:        use_cases.NewGetRiskAssessmentUseCase(riskRepo),
+		createCustomer: use_cases.NewCreateCustomerUseCase(customerRepo),
+		ledgerRepo:     ledgerRepo,
+	}
+}
+
+func noRiskRules(domain.RiskAssessmentRepository, domain.CustomerRepository) []domain.RiskRule {
+	return nil
+}
+
+func TestRisk_AllowedPaymentIsProcessedAndAudited(t *testing.T) {
+	env := setupRisk(t, noRiskRules)
+	ctx := context.Background()
+
+	result, err := env.createPayment.Execute(ctx, "risk-allow-1", validRequest())
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusSucceeded, result.Payment.Status)
+	assert.Equal(t, domain.RiskDecisionAllow, result.Payment.RiskDecision)
+
+	assessment, err := env.getRisk.Execute(ctx, result.Payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RiskDecisionAllow, assessment.Decision)
+	assert.Equal(t, "risk-allow-1", assessment.IdempotencyKey)
+	assert.Empty(t, assessment.Triggers)
+}
+
+func TestRisk_BlockedBINDeniesWithoutCharging(t *testing.T) {
+	env := setupRisk(t, func(domain.RiskAssessmentRepository, domain.CustomerRepository) []domain.RiskRule {
+		return []domain.RiskRule{use_cases.NewBlockedBINRule([]string{"424242"})}
+	})
+	ctx := context.Background()
+
+	result, err := env.createPayment.Execute(ctx, "risk-bin-1", validRequest())
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusBlocked, result.Payment.Status)
+	assert.Equal(t, domain.RiskRuleBlockedBIN, result.Payment.FailReason)
+	assert.Equal(t, domain.RiskDecisionDeny, result.Payment.RiskDecision)
+	assert.Equal(t, "4242", result.Payment.CardLast4)
+
+	entries, err := env.ledgerRepo.ListEntriesByPayment(ctx, result.Payment.ID)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	replay, err := env.createPayment.Execute(ctx, "risk-bin-1", validRequest())
+	require.NoError(t, err)
+	assert.True(t, replay.Replayed)
+	assert.Equal(t, result.Payment.ID, replay.Payment.ID)
+	assert.Equal(t, domain.PaymentStatusBlocked, replay.Payment.Status)
+
+	assessment, err := env.getRisk.Execute(ctx, result.Payment.ID)
+	require.NoError(t, err)
+	require.Len(t, assessment.Triggers, 1)
+	assert.Equal(t, "card BIN 424242 is blocked", assessment.Triggers[0].Reason)
+}
+
+func TestRisk_CustomerVelocityIgnoresReplays(t *testing.T) {
+	env := setupRisk(t, func(riskRepo domain.RiskAssessmentRepository, _ domain.CustomerRepository) []domain.RiskRule {
+		return []domain.RiskRule{use_cases.NewVelocityRule(riskRepo, use_cases.RiskVelocityPerCustomer, 2, time.Hour)}
+	})
+	ctx := context.Background()
+
+	for _, key := range []string{"risk-vel-1", "risk-vel-2", "risk-vel-1"} {
+		req := validRequest()
+		req.RideID = key
+		result, err := env.createPayment.Execute(ctx, key, req)
+		require.NoError(t, err)
+		assert.Equal(t, domain.PaymentStatusSucceeded, result.Payment.Status)
+	}
+
+	req := validRequest()
+	req.RideID = "risk-vel-3"
+	result, err := env.createPayment.Execute(ctx, "risk-vel-3", req)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusBlocked, result.Payment.Status)
+	assert.Equal(t, domain.RiskRuleCustomerVelocity, result.Payment.FailReason)
+
+	other := validRequest()
+	other.CustomerID = "cust-002"
+	result, err = env.createPayment.Execute(ctx, "risk-vel-4", other)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusSucceeded, result.Payment.Status)
+}
+
+func TestRisk_CardVelocitySpansCustomers(t *testing.T) {
+	env := setupRisk(t, func(riskRepo domain.RiskAssessmentRepository, _ domain.CustomerRepository) []domain.RiskRule {
+		return []domain.RiskRule{use_cases.NewVelocityRule(riskRepo, use_cases.RiskVelocityPerCard, 1, time.Hour)}
+	})
+	ctx := context.Background()
+
+	_, err := env.createPayment.Execute(ctx, "risk-card-1", validRequest())
+	require.NoError(t, err)
+
+	req := validRequest()
+	req.CustomerID = "cust-002"
+	result, err := env.createPayment.Execute(ctx, "risk-card-2", req)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusBlocked, result.Payment.Status)
+	assert.Equal(t, domain.RiskRuleCardVelocity, result.Payment.FailReason)
+}
+
+func TestRisk_MaxAmountPerCurrency(t *testing.T) {
+	env := setupRisk(t, func(domain.RiskAssessmentRepository, domain.CustomerRepository) []domain.RiskRule {
+		return []domain.RiskRule{use_cases.NewMaxAmountRule(map[domain.Currency]int64{domain.CurrencyIDR: 99})}
+	})
+	ctx := context.Background()
+
+	result, err := env.createPayment.Execute(ctx, "risk-amount-1", validRequest())
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusBlocked, result.Payment.Status)
+	assert.Equal(t, domain.RiskRuleMaxAmount, result.Payment.FailReason)
+
+	req := validRequest()
+	req.Currency = domain.CurrencyTHB
+	result, err = env.createPayment.Execute(ctx, "risk-amount-2", req)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusSucceeded, result.Payment.Status)
+}
+
+func TestRisk_CountryMismatchFlagsForReview(t *testing.T) {
+	env := setupRisk(t, func(_ domain.RiskAssessmentRepository, customerRepo domain.CustomerRepository) []domain.RiskRule {
+		return []domain.RiskRule{use_cases.NewCountryMismatchRule(map[string]string{"4242": "US", "424242": "ID"}, customerRepo)}
+	})
+	ctx := context.Background()
+
+	_, err := env.createCustomer.Execute(ctx, domain.CustomerRequest{ID: "cust-001", Name: "Ayu", Country: "ID"})
+	require.NoError(t, err)
+	_, err = env.createCustomer.Execute(ctx, domain.CustomerRequest{ID: "cust-002", Name: "Somchai", Country: "TH"})
+	require.NoError(t, err)
+
+	result, err := env.createPayment.Execute(ctx, "risk-country-1", validRequest())
+	require.NoError(t, err)
+	assert.Equal(t, domain.RiskDecisionAllow, result.Payment.RiskDecision)
+
+	req := validRequest()
+	req.CustomerID = "cust-002"
+	result, err = env.createPayment.Execute(ctx, "risk-country-2", req)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusSucceeded, result.Payment.Status)
+	assert.Equal(t, domain.RiskDecisionReview, result.Payment.RiskDecision)
+
+	assessment, err := env.getRisk.Execute(ctx, result.Payment.ID)
+	require.NoError(t, err)
+	require.Len(t, assessment.Triggers, 1)
+	assert.Equal(t, "card issued in ID, customer registered in TH", assessment.Triggers[0].Reason)
+}
@@ -0,0 +1,284 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm/repositories"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/processor"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/vault"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type scheduledEnv struct {
+	*paymentMethodEnv
+	schedule   *use_cases.SchedulePaymentUseCase
+	list       *use_cases.ListScheduledPaymentsUseCase
+	get        *use_cases.GetScheduledPaymentUseCase
+	cancel     *use_cases.CancelScheduledPaymentUseCase
+	scheduler  *use_cases.ExecuteScheduledPaymentsUseCase
+	getPayment *use_cases.GetPaymentUseCase
+}
+
+func setupScheduled(t *testing.T) *scheduledEnv {
+	env := setupPaymentMethods(t)
+	scheduledRepo := repositories.NewScheduledPaymentRepo(env.db)
+	return &scheduledEnv{
+		paymentMethodEnv: env,
+		schedule:         use_cases.NewSchedulePaymentUseCase(scheduledRepo, repositories.NewIdempotencyRepo(env.db), 24*time.Hour),
+		list:             use_cases.NewListScheduledPaymentsUseCase(scheduledRepo),
+		get:              use_cases.NewGetScheduledPaymentUseCase(scheduledRepo),
+		cancel:           use_cases.NewCancelScheduledPaymentUseCase(scheduledRepo),
+		scheduler:        use_cases.NewExecuteScheduledPaymentsUseCase(scheduledRepo, env.createPayment, 10),
+		getPayment:       use_cases.NewGetPaymentUseCase(repositories.NewPaymentRepo(env.db)),
+	}
+}
+
+func scheduledRequest(t *testing.T, env *scheduledEnv, card string, at time.Time) domain.PaymentRequest {
+	created, err := env.createMethod.Execute(context.Background(), "cust-001", cardRequest(card))
+	require.NoError(t, err)
+
+	req := validRequest()
+	req.CardNumber = ""
+	req.PaymentMethodID = created.PaymentMethod.ID
+	req.Description = "No-show fee"
+	req.ScheduledAt = &at
+	return req
+}
+
+func makeDue(t *testing.T, env *scheduledEnv, id string) {
+	err := env.db.Model(&domain.ScheduledPayment{}).Where("id = ?", id).
+		Update("scheduled_at", time.Now().Add(-time.Second)).Error
+	require.NoError(t, err)
+}
+
+func TestScheduledPayments_ExecutesWhenDue(t *testing.T) {
+	env := setupScheduled(t)
+	ctx := context.Background()
+	req := scheduledRequest(t, env, "4242424242424242", time.Now().Add(15*time.Minute))
+
+	result, err := env.schedule.Execute(ctx, "noshow-fee-1", req)
+	require.NoError(t, err)
+	assert.False(t, result.Replayed)
+	scheduled := result.ScheduledPayment
+	assert.Equal(t, domain.ScheduledPaymentScheduled, scheduled.Status)
+	assert.Nil(t, scheduled.PaymentID)
+
+	executed, err := env.scheduler.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, executed, "nothing is due yet")
+
+	makeDue(t, env, scheduled.ID)
+	executed, err = env.scheduler.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, executed)
+
+	done, err := env.get.Execute(ctx, scheduled.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ScheduledPaymentExecuted, done.Status)
+	assert.Equal(t, 1, done.Attempts)
+	require.NotNil(t, done.PaymentID)
+	require.NotNil(t, done.ExecutedAt)
+
+	payment, err := env.getPayment.Execute(ctx, *done.PaymentID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusSucceeded, payment.Status)
+	assert.Equal(t, "No-show fee", payment.Description)
+
+	immediate := req
+	immediate.ScheduledAt = nil
+	charged, err := env.createPayment.Execute(ctx, "noshow-fee-1", immediate)
+	require.NoError(t, err)
+	assert.True(t, charged.Replayed, "the scheduled payment used the client's idempotency key")
+	assert.Equal(t, payment.ID, charged.Payment.ID)
+
+	executed, err = env.scheduler.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, executed)
+}
+
+func TestScheduledPayments_IdempotentScheduling(t *testing.T) {
+	env := setupScheduled(t)
+	ctx := context.Background()
+	at := time.Now().Add(time.Hour)
+	req := scheduledRequest(t, env, "4242424242424242", at)
+
+	first, err := env.schedule.Execute(ctx, "noshow-fee-2", req)
+	require.NoError(t, err)
+
+	inOtherZone := at.In(time.FixedZone("WIB", 7*3600))
+	req.ScheduledAt = &inOtherZone
+	replay, err := env.schedule.Execute(ctx, "noshow-fee-2", req)
+	require.NoError(t, err)
+	assert.True(t, replay.Replayed)
+	assert.Equal(t, first.ScheduledPayment.ID, replay.ScheduledPayment.ID)
+
+	later := at.Add(time.Minute)
+	req.ScheduledAt = &later
+	_, err = env.schedule.Execute(ctx, "noshow-fee-2", req)
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "IDEMPOTENCY_KEY_CONFLICT", appErr.Code)
+
+	immediate := req
+	immediate.ScheduledAt = nil
+	_, err = env.createPayment.Execute(ctx, "already-charged", immediate)
+	require.NoError(t, err)
+	_, err = env.schedule.Execute(ctx, "already-charged", req)
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "IDEMPOTENCY_KEY_CONFLICT", appErr.Code)
+}
+
+func TestScheduledPayments_CancelAndList(t *testing.T) {
+	env := setupScheduled(t)
+	ctx := context.Background()
+	req := scheduledRequest(t, env, "4242424242424242", time.Now().Add(time.Hour))
+
+	first, err := env.schedule.Execute(ctx, "noshow-fee-3", req)
+	require.NoError(t, err)
+	req.RideID = "ride-002"
+	second, err := env.schedule.Execute(ctx, "noshow-fee-4", req)
+	require.NoError(t, err)
+
+	canceled, err := env.cancel.Execute(ctx, first.ScheduledPayment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ScheduledPaymentCanceled, canceled.Status)
+	assert.NotNil(t, canceled.CanceledAt)
+
+	again, err := env.cancel.Execute(ctx, first.ScheduledPayment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ScheduledPaymentCanceled, again.Status)
+
+	makeDue(t, env, first.ScheduledPayment.ID)
+	makeDue(t, env, second.ScheduledPayment.ID)
+	executed, err := env.scheduler.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, executed, "canceled payments are never executed")
+
+	_, err = env.cancel.Execute(ctx, second.ScheduledPayment.ID)
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "SCHEDULED_PAYMENT_NOT_CANCELABLE", appErr.Code)
+
+	all, err := env.list.Execute(ctx, use_cases.ListScheduledPaymentsQuery{CustomerID: "cust-001"})
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	canceledOnly, err := env.list.Execute(ctx, use_cases.ListScheduledPaymentsQuery{Status: "canceled"})
+	require.NoError(t, err)
+	require.Len(t, canceledOnly, 1)
+	assert.Equal(t, first.ScheduledPayment.ID, canceledOnly[0].ID)
+
+	_, err = env.list.Execute(ctx, use_cases.ListScheduledPaymentsQuery{Status: "LATER"})
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "INVALID_PAYMENT_QUERY", appErr.Code)
+
+	_, err = env.cancel.Execute(ctx, "00000000-0000-0000-0000-000000000000")
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "SCHEDULED_PAYMENT_NOT_FOUND", appErr.Code)
+}
+
+func TestScheduledPayments_FailedExecutionIsRecorded(t *testing.T) {
+	env := setupScheduled(t)
+	ctx := context.Background()
+	req := scheduledRequest(t, env, "4242424242424242", time.Now().Add(time.Hour))
+
+	result, err := env.schedule.Execute(ctx, "noshow-fee-5", req)
+	require.NoError(t, err)
+
+	err = env.deleteMethod.Execute(ctx, "cust-001", req.PaymentMethodID)
+	require.NoError(t, err)
+
+	makeDue(t, env, result.ScheduledPayment.ID)
+	executed, err := env.scheduler.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, executed)
+
+	failed, err := env.get.Execute(ctx, result.ScheduledPayment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ScheduledPaymentFailed, failed.Status)
+	assert.Equal(t, "PAYMENT_METHOD_NOT_FOUND", failed.ErrorCode)
+	assert.Nil(t, failed.PaymentID)
+}
+
+func TestScheduledPayments_RejectsInvalidSchedules(t *testing.T) {
+	env := setupScheduled(t)
+	ctx := context.Background()
+	base := scheduledRequest(t, env, "4242424242424242", time.Now().Add(time.Hour))
+
+	past := time.Now().Add(-time.Minute)
+	farAway := time.Now().Add(48 * time.Hour)
+	tests := []struct {
+		name   string
+		modify func(req *domain.PaymentRequest)
+		code   string
+	}{
+		{"past", func(req *domain.PaymentRequest) { req.ScheduledAt = &past }, "INVALID_SCHEDULE"},
+		{"too far ahead", func(req *domain.PaymentRequest) { req.ScheduledAt = &farAway }, "INVALID_SCHEDULE"},
+		{"raw card", func(req *domain.PaymentRequest) {
+			req.PaymentMethodID = ""
+			req.CardNumber = "4242424242424242"
+		}, "INVALID_SCHEDULE"},
+		{"invalid amount", func(req *domain.PaymentRequest) { req.Amount = "0" }, "INVALID_PAYMENT_REQUEST"},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := base
+			tt.modify(&req)
+			_, err := env.schedule.Execute(ctx, "invalid-schedule-"+string(rune('a'+i)), req)
+			var appErr *apperrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, tt.code, appErr.Code)
+		})
+	}
+
+	_, err := env.createPayment.Execute(ctx, "direct-scheduled", base)
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "INVALID_SCHEDULE", appErr.Code)
+}
+
+func TestScheduledPayments_RetryableFailureBacksOff(t *testing.T) {
+	env := setupScheduled(t)
+	ctx := context.Background()
+	req := scheduledRequest(t, env, "4242424242424242", time.Now().Add(time.Hour))
+
+	result, err := env.schedule.Execute(ctx, "noshow-fee-6", req)
+	require.NoError(t, err)
+
+	cardVault, err := vault.NewAESGCMVault("integration-key")
+	require.NoError(t, err)
+	down := use_cases.NewCreatePaymentUseCase(
+		gormdb.NewTransactionManager(env.db), repositories.NewIdempotencyRepo(env.db), repositories.NewPaymentRepo(env.db),
+		processor.NewSimulator(processor.WithUnavailableRate(1)), 24*time.Hour,
+		use_cases.WithPaymentMethods(repositories.NewPaymentMethodRepo(env.db), cardVault),
+	)
+	scheduler := use_cases.NewExecuteScheduledPaymentsUseCase(repositories.NewScheduledPaymentRepo(env.db), down, 10)
+
+	makeDue(t, env, result.ScheduledPayment.ID)
+	before := time.Now()
+	executed, err := scheduler.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, executed)
+
+	retrying, err := env.get.Execute(ctx, result.ScheduledPayment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ScheduledPaymentScheduled, retrying.Status)
+	assert.Equal(t, 1, retrying.Attempts)
+	assert.Equal(t, "PROCESSOR_UNAVAILABLE", retrying.ErrorCode)
+	assert.WithinDuration(t, before.Add(30*time.Second), retrying.ScheduledAt, 5*time.Second)
+
+	executed, err = scheduler.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, executed)
+	again, err := env.get.Execute(ctx, result.ScheduledPayment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, again.Attempts, "a backed-off payment is not claimed before its retry time")
+}
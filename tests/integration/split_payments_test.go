@@ -0,0 +1,93 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func splitRequest() domain.PaymentRequest {
+	req := validRequest()
+	req.Amount = "150000"
+	req.Splits = []domain.SplitRequest{
+		{Recipient: "driver-77", Percentage: "80"},
+		{Recipient: "platform", Amount: "30000"},
+	}
+	return req
+}
+
+func TestSplitPayments_PersistedAndExposed(t *testing.T) {
+	env := setupIntegration(t)
+	ctx := context.Background()
+
+	result, err := env.createPayment.Execute(ctx, "split-key-1", splitRequest())
+	require.NoError(t, err)
+	require.Len(t, result.Payment.Splits, 2)
+
+	payment, err := env.getPayment.Execute(ctx, result.Payment.ID)
+	require.NoError(t, err)
+	require.Len(t, payment.Splits, 2)
+	assert.Equal(t, "driver-77", payment.Splits[0].Recipient)
+	assert.Equal(t, int64(120000), payment.Splits[0].Amount)
+	assert.Equal(t, json.Number("80"), payment.Splits[0].Percentage)
+	assert.Equal(t, "platform", payment.Splits[1].Recipient)
+	assert.Equal(t, int64(30000), payment.Splits[1].Amount)
+
+	replay, err := env.createPayment.Execute(ctx, "split-key-1", splitRequest())
+	require.NoError(t, err)
+	assert.True(t, replay.Replayed)
+	require.Len(t, replay.Payment.Splits, 2)
+	assert.Equal(t, int64(120000), replay.Payment.Splits[0].Amount)
+}
+
+func TestSplitPayments_EquivalentSplitsReplay(t *testing.T) {
+	env := setupIntegration(t)
+	ctx := context.Background()
+
+	_, err := env.createPayment.Execute(ctx, "split-key-2", splitRequest())
+	require.NoError(t, err)
+
+	equivalent := splitRequest()
+	equivalent.Splits[0].Percentage = "80.00"
+	equivalent.Splits[1].Amount = "30000.0"
+	replay, err := env.createPayment.Execute(ctx, "split-key-2", equivalent)
+	require.NoError(t, err)
+	assert.True(t, replay.Replayed)
+}
+
+func TestSplitPayments_DifferentSplitsConflict(t *testing.T) {
+	env := setupIntegration(t)
+	ctx := context.Background()
+
+	_, err := env.createPayment.Execute(ctx, "split-key-3", splitRequest())
+	require.NoError(t, err)
+
+	changed := splitRequest()
+	changed.Splits[0].Percentage = "70"
+	changed.Splits[1].Amount = "45000"
+	_, err = env.createPayment.Execute(ctx, "split-key-3", changed)
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "IDEMPOTENCY_KEY_CONFLICT", appErr.Code)
+}
+
+func TestSplitPayments_MustSumToTotal(t *testing.T) {
+	env := setupIntegration(t)
+
+	req := splitRequest()
+	req.Splits[1].Amount = "20000"
+	_, err := env.createPayment.Execute(context.Background(), "split-key-4", req)
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "INVALID_SPLIT", appErr.Code)
+	assert.Contains(t, appErr.Message, "sum to the payment amount")
+
+	_, err = env.getByIdempotencyKey.Execute(context.Background(), "split-key-4")
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "IDEMPOTENCY_KEY_NOT_FOUND", appErr.Code)
+}
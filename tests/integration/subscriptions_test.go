@@ -0,0 +1,260 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type subscriptionEnv struct {
+	*paymentMethodEnv
+	createPlan   *use_cases.CreatePlanUseCase
+	subscribe    *use_cases.CreateSubscriptionUseCase
+	get          *use_cases.GetSubscriptionUseCase
+	list         *use_cases.ListSubscriptionsUseCase
+	transition   *use_cases.TransitionSubscriptionUseCase
+	billing      *use_cases.BillSubscriptionsUseCase
+	listPayments *use_cases.ListPaymentsUseCase
+}
+
+func setupSubscriptions(t *testing.T) *subscriptionEnv {
+	env := setupPaymentMethods(t)
+	txManager := gormdb.NewTransactionManager(env.db)
+	planRepo := repositories.NewSubscriptionPlanRepo(env.db)
+	subscriptionRepo := repositories.NewSubscriptionRepo(env.db)
+	dunning := []time.Duration{time.Hour, 3 * time.Hour}
+	return &subscriptionEnv{
+		paymentMethodEnv: env,
+		createPlan:       use_cases.NewCreatePlanUseCase(planRepo),
+		subscribe:        use_cases.NewCreateSubscriptionUseCase(subscriptionRepo, planRepo, repositories.NewPaymentMethodRepo(env.db)),
+		get:              use_cases.NewGetSubscriptionUseCase(subscriptionRepo),
+		list:             use_cases.NewListSubscriptionsUseCase(subscriptionRepo),
+		transition:       use_cases.NewTransitionSubscriptionUseCase(txManager, subscriptionRepo, planRepo),
+		billing:          use_cases.NewBillSubscriptionsUseCase(txManager, subscriptionRepo, planRepo, env.createPayment, dunning, 10),
+		listPayments:     use_cases.NewListPaymentsUseCase(repositories.NewPaymentRepo(env.db)),
+	}
+}
+
+func subscribe(t *testing.T, env *subscriptionEnv, card string) (*domain.SubscriptionPlan, *domain.Subscription) {
+	ctx := context.Background()
+	plan, err := env.createPlan.Execute(ctx, domain.PlanRequest{
+		Name: "Rider Pass", Amount: "50000", Currency: domain.CurrencyIDR, Interval: "month",
+	})
+	require.NoError(t, err)
+
+	method, err := env.createMethod.Execute(ctx, "cust-001", cardRequest(card))
+	require.NoError(t, err)
+
+	sub, err := env.subscribe.Execute(ctx, domain.SubscriptionRequest{
+		CustomerID: "cust-001", PlanID: plan.ID, PaymentMethodID: method.PaymentMethod.ID,
+	})
+	require.NoError(t, err)
+	return plan, sub
+}
+
+func makeSubscriptionDue(t *testing.T, env *subscriptionEnv, id string) {
+	err := env.db.Model(&domain.Subscription{}).Where("id = ?", id).
+		Update("next_billing_at", time.Now().Add(-time.Second)).Error
+	require.NoError(t, err)
+}
+
+func subscriptionPayments(t *testing.T, env *subscriptionEnv) []*domain.Payment {
+	page, err := env.listPayments.Execute(context.Background(), use_cases.ListPaymentsQuery{CustomerID: "cust-001"})
+	require.NoError(t, err)
+	return page.Data
+}
+
+func TestSubscriptions_BillsEachPeriodOnce(t *testing.T) {
+	env := setupSubscriptions(t)
+	ctx := context.Background()
+	plan, sub := subscribe(t, env, "4242424242424242")
+	assert.Equal(t, domain.SubscriptionActive, sub.Status)
+	assert.Equal(t, 1, sub.Period)
+	assert.Equal(t, domain.BillingIntervalMonth, plan.Interval)
+	assert.Equal(t, int64(50000), plan.Amount)
+
+	billed, err := env.billing.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, billed)
+
+	renewed, err := env.get.Execute(ctx, sub.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SubscriptionActive, renewed.Status)
+	assert.Equal(t, 2, renewed.Period)
+	require.NotNil(t, renewed.PaidThrough)
+	assert.WithinDuration(t, sub.PeriodEnd, *renewed.PaidThrough, time.Second)
+	assert.WithinDuration(t, sub.PeriodEnd, *renewed.NextBillingAt, time.Second)
+	assert.NotEmpty(t, renewed.LastPaymentID)
+
+	billed, err = env.billing.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, billed, "the next period is not due yet")
+
+	payments := subscriptionPayments(t, env)
+	require.Len(t, payments, 1)
+	assert.Equal(t, renewed.LastPaymentID, payments[0].ID)
+	assert.Equal(t, domain.PaymentStatusSucceeded, payments[0].Status)
+	assert.Equal(t, "Rider Pass, period 1", payments[0].Description)
+	assert.Equal(t, fmt.Sprintf("subscription-%s-1", sub.ID), payments[0].RideID)
+}
+
+func TestSubscriptions_BillingReplaysAfterCrash(t *testing.T) {
+	env := setupSubscriptions(t)
+	ctx := context.Background()
+	plan, sub := subscribe(t, env, "4242424242424242")
+
+	charged, err := env.createPayment.Execute(ctx, sub.BillingKey(), domain.PaymentRequest{
+		Amount:          plan.Money().Number(),
+		Currency:        plan.Currency,
+		CustomerID:      sub.CustomerID,
+		RideID:          fmt.Sprintf("subscription-%s-%d", sub.ID, sub.Period),
+		Description:     fmt.Sprintf("%s, period %d", plan.Name, sub.Period),
+		PaymentMethodID: sub.PaymentMethodID,
+		AllowDuplicate:  true,
+	})
+	require.NoError(t, err)
+
+	billed, err := env.billing.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, billed)
+
+	renewed, err := env.get.Execute(ctx, sub.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, renewed.Period)
+	assert.Equal(t, charged.Payment.ID, renewed.LastPaymentID)
+	assert.Len(t, subscriptionPayments(t, env), 1)
+}
+
+func TestSubscriptions_PendingChargeKeepsPeriod(t *testing.T) {
+	env := setupSubscriptions(t)
+	ctx := context.Background()
+	_, sub := subscribe(t, env, "4000000000000259")
+
+	for i := 0; i < 2; i++ {
+		_, err := env.billing.Execute(ctx)
+		require.NoError(t, err)
+
+		waiting, err := env.get.Execute(ctx, sub.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.SubscriptionActive, waiting.Status)
+		assert.Equal(t, 1, waiting.Period)
+		assert.Nil(t, waiting.PaidThrough)
+		assert.Zero(t, waiting.FailedAttempts)
+		assert.Equal(t, "payment PENDING, waiting for the processor", waiting.LastError)
+		assert.WithinDuration(t, *sub.NextBillingAt, *waiting.NextBillingAt, time.Second)
+	}
+
+	payments := subscriptionPayments(t, env)
+	require.Len(t, payments, 1, "the next run replays the same key")
+	assert.Equal(t, domain.PaymentStatusPending, payments[0].Status)
+}
+
+func TestSubscriptions_DunningCancelsAfterLastRetry(t *testing.T) {
+	env := setupSubscriptions(t)
+	ctx := context.Background()
+	_, sub := subscribe(t, env, "4000000000000002")
+
+	_, err := env.billing.Execute(ctx)
+	require.NoError(t, err)
+
+	pastDue, err := env.get.Execute(ctx, sub.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SubscriptionPastDue, pastDue.Status)
+	assert.Equal(t, 1, pastDue.FailedAttempts)
+	assert.Equal(t, 1, pastDue.Period)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), *pastDue.NextBillingAt, time.Minute)
+	assert.NotEmpty(t, pastDue.LastError)
+
+	billed, err := env.billing.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, billed, "the retry waits for the dunning delay")
+
+	for i := 0; i < 2; i++ {
+		makeSubscriptionDue(t, env, sub.ID)
+		_, err := env.billing.Execute(ctx)
+		require.NoError(t, err)
+	}
+
+	canceled, err := env.get.Execute(ctx, sub.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SubscriptionCanceled, canceled.Status)
+	assert.Equal(t, domain.SubscriptionCancelDunningExhausted, canceled.CancelReason)
+	assert.Equal(t, 3, canceled.FailedAttempts)
+	assert.Nil(t, canceled.NextBillingAt)
+	assert.Len(t, subscriptionPayments(t, env), 3, "each retry is a separate charge attempt")
+}
+
+func TestSubscriptions_PauseResumeCancel(t *testing.T) {
+	env := setupSubscriptions(t)
+	ctx := context.Background()
+	_, sub := subscribe(t, env, "4242424242424242")
+
+	paused, err := env.transition.Execute(ctx, sub.ID, domain.SubscriptionActionPause)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SubscriptionPaused, paused.Status)
+	require.NotNil(t, paused.PausedAt)
+
+	billed, err := env.billing.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, billed, "paused subscriptions are not billed")
+
+	resumed, err := env.transition.Execute(ctx, sub.ID, domain.SubscriptionActionResume)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SubscriptionActive, resumed.Status)
+	assert.Nil(t, resumed.PausedAt)
+
+	billed, err = env.billing.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, billed)
+
+	canceled, err := env.transition.Execute(ctx, sub.ID, domain.SubscriptionActionCancel)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SubscriptionCanceled, canceled.Status)
+	assert.Equal(t, domain.SubscriptionCancelRequested, canceled.CancelReason)
+
+	_, err = env.transition.Execute(ctx, sub.ID, domain.SubscriptionActionResume)
+	var appErr *apperrors.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, "INVALID_SUBSCRIPTION_TRANSITION", appErr.Code)
+
+	_, err = env.transition.Execute(ctx, "missing", domain.SubscriptionActionPause)
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, "SUBSCRIPTION_NOT_FOUND", appErr.Code)
+
+	active, err := env.list.Execute(ctx, use_cases.ListSubscriptionsQuery{CustomerID: "cust-001", Status: "active"})
+	require.NoError(t, err)
+	assert.Empty(t, active)
+}
+
+func TestSubscriptions_CreateValidation(t *testing.T) {
+	env := setupSubscriptions(t)
+	ctx := context.Background()
+	plan, sub := subscribe(t, env, "4242424242424242")
+
+	_, err := env.subscribe.Execute(ctx, domain.SubscriptionRequest{
+		CustomerID: "cust-001", PlanID: plan.ID, PaymentMethodID: sub.PaymentMethodID,
+	})
+	var appErr *apperrors.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, "SUBSCRIPTION_EXISTS", appErr.Code)
+
+	_, err = env.subscribe.Execute(ctx, domain.SubscriptionRequest{
+		CustomerID: "cust-002", PlanID: plan.ID, PaymentMethodID: sub.PaymentMethodID,
+	})
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperrors.ErrPaymentMethodNotFound().Code, appErr.Code)
+
+	_, err = env.createPlan.Execute(ctx, domain.PlanRequest{Name: "Bad", Amount: "100", Currency: domain.CurrencyIDR, Interval: "hourly"})
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, "INVALID_PLAN", appErr.Code)
+}
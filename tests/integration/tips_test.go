@@ -0,0 +1,114 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tipEnv struct {
+	*paymentMethodEnv
+	createTip  *use_cases.CreateTipUseCase
+	getPayment *use_cases.GetPaymentUseCase
+}
+
+func setupTips(t *testing.T, maxRatio float64) *tipEnv {
+	env := setupPaymentMethods(t)
+	paymentRepo := repositories.NewPaymentRepo(env.db)
+	return &tipEnv{
+		paymentMethodEnv: env,
+		createTip:        use_cases.NewCreateTipUseCase(paymentRepo, env.createPayment, maxRatio),
+		getPayment:       use_cases.NewGetPaymentUseCase(paymentRepo),
+	}
+}
+
+func chargeStoredCard(t *testing.T, env *tipEnv, key, card string) *domain.Payment {
+	created, err := env.createMethod.Execute(context.Background(), "cust-001", cardRequest(card))
+	require.NoError(t, err)
+
+	req := validRequest()
+	req.Amount = "100000"
+	req.CardNumber = ""
+	req.PaymentMethodID = created.PaymentMethod.ID
+	result, err := env.createPayment.Execute(context.Background(), key, req)
+	require.NoError(t, err)
+	return result.Payment
+}
+
+func TestTips_LinkedToParent(t *testing.T) {
+	env := setupTips(t, 0.5)
+	ctx := context.Background()
+	parent := chargeStoredCard(t, env, "tip-parent-1", "4242424242424242")
+	require.Equal(t, domain.PaymentStatusSucceeded, parent.Status)
+
+	tip, err := env.createTip.Execute(ctx, "tip-key-1", parent.ID, domain.TipRequest{Amount: "20000"})
+	require.NoError(t, err)
+	assert.False(t, tip.Replayed)
+	assert.Equal(t, parent.ID, tip.Payment.ParentPaymentID)
+	assert.Equal(t, parent.PaymentMethodID, tip.Payment.PaymentMethodID)
+	assert.Equal(t, parent.RideID, tip.Payment.RideID)
+	assert.Equal(t, int64(20000), tip.Payment.Amount)
+	assert.Equal(t, domain.PaymentStatusSucceeded, tip.Payment.Status)
+
+	replay, err := env.createTip.Execute(ctx, "tip-key-1", parent.ID, domain.TipRequest{Amount: "20000"})
+	require.NoError(t, err)
+	assert.True(t, replay.Replayed)
+	assert.Equal(t, tip.Payment.ID, replay.Payment.ID)
+
+	withTips, err := env.getPayment.Execute(ctx, parent.ID)
+	require.NoError(t, err)
+	require.Len(t, withTips.Tips, 1)
+	assert.Equal(t, tip.Payment.ID, withTips.Tips[0].ID)
+}
+
+func TestTips_MaxRatio(t *testing.T) {
+	env := setupTips(t, 0.5)
+	ctx := context.Background()
+	parent := chargeStoredCard(t, env, "tip-parent-2", "4242424242424242")
+
+	_, err := env.createTip.Execute(ctx, "tip-key-2", parent.ID, domain.TipRequest{Amount: "30000"})
+	require.NoError(t, err)
+
+	_, err = env.createTip.Execute(ctx, "tip-key-3", parent.ID, domain.TipRequest{Amount: "25000"})
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "TIP_LIMIT_EXCEEDED", appErr.Code)
+	assert.Contains(t, appErr.Message, "20000 IDR")
+
+	_, err = env.createTip.Execute(ctx, "tip-key-3", parent.ID, domain.TipRequest{Amount: "20000"})
+	require.NoError(t, err, "a rejected tip does not consume its idempotency key")
+}
+
+func TestTips_Rejected(t *testing.T) {
+	env := setupTips(t, 0.5)
+	ctx := context.Background()
+	var appErr *apperrors.AppError
+
+	_, err := env.createTip.Execute(ctx, "tip-key-4", "missing", domain.TipRequest{Amount: "1000"})
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "PAYMENT_NOT_FOUND", appErr.Code)
+
+	cardPayment, err := env.createPayment.Execute(ctx, "tip-parent-3", validRequest())
+	require.NoError(t, err)
+	_, err = env.createTip.Execute(ctx, "tip-key-5", cardPayment.Payment.ID, domain.TipRequest{Amount: "10"})
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "TIP_NOT_ALLOWED", appErr.Code)
+
+	failed := chargeStoredCard(t, env, "tip-parent-4", "4000000000000002")
+	require.Equal(t, domain.PaymentStatusFailed, failed.Status)
+	_, err = env.createTip.Execute(ctx, "tip-key-6", failed.ID, domain.TipRequest{Amount: "1000"})
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "TIP_NOT_ALLOWED", appErr.Code)
+
+	req := validRequest()
+	req.ParentPaymentID = cardPayment.Payment.ID
+	_, err = env.createPayment.Execute(ctx, "tip-key-7", req)
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "INVALID_PAYMENT_REQUEST", appErr.Code)
+}
@@ -0,0 +1,258 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm/repositories"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/processor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type hangingProcessor struct{}
+
+func (hangingProcessor) Process(ctx context.Context, idempotencyKey string, req domain.PaymentRequest) (*domain.Payment, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (hangingProcessor) GetStatus(ctx context.Context, paymentID string) (*domain.PaymentStatusUpdate, error) {
+	return nil, processor.ErrUnknownPayment
+}
+
+func (hangingProcessor) LookupByKey(ctx context.Context, idempotencyKey string) (*domain.Payment, error) {
+	return nil, nil
+}
+
+type unreachableProcessor struct {
+	hangingProcessor
+}
+
+func (unreachableProcessor) LookupByKey(ctx context.Context, idempotencyKey string) (*domain.Payment, error) {
+	return nil, domain.ErrProcessorUnavailable
+}
+
+type unknownEnv struct {
+	createPayment *use_cases.CreatePaymentUseCase
+	resolve       func(grace, maxAge time.Duration) *use_cases.ResolveUnknownPaymentsUseCase
+	reconcile     *use_cases.ReconcileSettlementUseCase
+	handleEvent   *use_cases.HandleProcessorEventUseCase
+	paymentRepo   domain.PaymentRepository
+	ledgerRepo    domain.LedgerRepository
+}
+
+func setupUnknown(t *testing.T, next domain.PaymentProcessor) *unknownEnv {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+
+	txManager := gormdb.NewTransactionManager(db)
+	idempotencyRepo := repositories.NewIdempotencyRepo(db)
+	paymentRepo := repositories.NewPaymentRepo(db)
+	outboxRepo := repositories.NewOutboxRepo(db)
+	ledgerRepo := repositories.NewLedgerRepo(db)
+	ledger := use_cases.NewLedgerRecorder(ledgerRepo, 0)
+	impatient := processor.NewBreaker("sim", next, processor.WithCallTimeout(20*time.Millisecond))
+
+	return &unknownEnv{
+		createPayment: use_cases.NewCreatePaymentUseCase(txManager, idempotencyRepo, paymentRepo, impatient, 24*time.Hour,
+			use_cases.WithOutbox(outboxRepo),
+			use_cases.WithLedger(ledger),
+		),
+		resolve: func(grace, maxAge time.Duration) *use_cases.ResolveUnknownPaymentsUseCase {
+			return use_cases.NewResolveUnknownPaymentsUseCase(
+				txManager, idempotencyRepo, paymentRepo, outboxRepo, next,
+				time.Minute, time.Hour, grace, maxAge, 50,
+				use_cases.WithStatusLedger(ledger),
+			)
+		},
+		reconcile: use_cases.NewReconcileSettlementUseCase(paymentRepo, repositories.NewReconciliationRepo(db)),
+		handleEvent: use_cases.NewHandleProcessorEventUseCase(
+			txManager, repositories.NewProcessorEventRepo(db), idempotencyRepo, paymentRepo, outboxRepo,
+			processorSecret, 5*time.Minute,
+			use_cases.WithStatusLedger(ledger),
+		),
+		paymentRepo: paymentRepo,
+		ledgerRepo:  ledgerRepo,
+	}
+}
+
+func TestUnknownPayment_TimeoutIsPersistedAndReplayed(t *testing.T) {
+	sim := processor.NewSimulator(processor.WithLatency(200*time.Millisecond, 200*time.Millisecond))
+	env := setupUnknown(t, sim)
+	ctx := context.Background()
+
+	first, err := env.createPayment.Execute(ctx, "unknown-key", validRequest())
+	require.NoError(t, err)
+	assert.False(t, first.Replayed)
+	assert.True(t, first.OutcomeUnknown())
+	assert.Equal(t, domain.PaymentStatusUnknown, first.Payment.Status)
+
+	replay, err := env.createPayment.Execute(ctx, "unknown-key", validRequest())
+	require.NoError(t, err)
+	assert.True(t, replay.Replayed)
+	assert.True(t, replay.OutcomeUnknown())
+	assert.Equal(t, first.Payment.ID, replay.Payment.ID)
+
+	entries, err := env.ledgerRepo.ListEntriesByPayment(ctx, first.Payment.ID)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	resolved, err := env.resolve(time.Hour, 24*time.Hour).Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resolved)
+
+	stored, err := env.paymentRepo.FindByID(ctx, first.Payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusSucceeded, stored.Status)
+	require.NotEmpty(t, stored.ProcessorReference)
+	assert.NotEqual(t, stored.ID, stored.ProcessorReference)
+
+	entries, err = env.ledgerRepo.ListEntriesByPayment(ctx, first.Payment.ID)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	replay, err = env.createPayment.Execute(ctx, "unknown-key", validRequest())
+	require.NoError(t, err)
+	assert.True(t, replay.Replayed)
+	assert.False(t, replay.OutcomeUnknown())
+	assert.Equal(t, domain.PaymentStatusSucceeded, replay.Payment.Status)
+
+	var file bytes.Buffer
+	require.NoError(t, sim.(domain.SettlementFileGenerator).GenerateSettlementFile(ctx, stored.CreatedAt, &file))
+	report, err := env.reconcile.Execute(ctx, use_cases.ReconcileSettlementCommand{
+		SettlementDate: stored.CreatedAt.UTC().Format(domain.SettlementDateLayout),
+		FileName:       "settlement.csv",
+		Content:        file.Bytes(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Matched)
+	assert.Zero(t, report.MissingInOurs)
+	assert.Zero(t, report.MissingInTheirs)
+}
+
+func TestUnknownPayment_ResolvesDeclineAsFailed(t *testing.T) {
+	env := setupUnknown(t, processor.NewSimulator(processor.WithLatency(200*time.Millisecond, 200*time.Millisecond)))
+	ctx := context.Background()
+
+	req := validRequest()
+	req.CardNumber = "4000000000000002"
+	result, err := env.createPayment.Execute(ctx, "unknown-decline-key", req)
+	require.NoError(t, err)
+	require.True(t, result.OutcomeUnknown())
+
+	_, err = env.resolve(time.Hour, 24*time.Hour).Execute(ctx)
+	require.NoError(t, err)
+
+	stored, err := env.paymentRepo.FindByID(ctx, result.Payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusFailed, stored.Status)
+	assert.Equal(t, "insufficient_funds", stored.FailReason)
+}
+
+func TestUnknownPayment_CallbackMatchesProcessorKey(t *testing.T) {
+	env := setupUnknown(t, hangingProcessor{})
+	ctx := context.Background()
+
+	result, err := env.createPayment.Execute(ctx, "unknown-callback-key", validRequest())
+	require.NoError(t, err)
+	require.True(t, result.OutcomeUnknown())
+
+	stored, err := env.paymentRepo.FindByID(ctx, result.Payment.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, stored.ProcessorKey)
+
+	body, sig, ts := signedEvent(t, domain.ProcessorEventRequest{
+		EventID:   "evt-unknown-001",
+		PaymentID: stored.ProcessorKey,
+		Status:    domain.PaymentStatusSucceeded,
+	})
+	handled, err := env.handleEvent.Execute(ctx, body, sig, ts)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ProcessorEventOutcomeApplied, handled.Event.Outcome)
+	assert.Equal(t, result.Payment.ID, handled.Event.PaymentID)
+
+	stored, err = env.paymentRepo.FindByID(ctx, result.Payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusSucceeded, stored.Status)
+
+	entries, err := env.ledgerRepo.ListEntriesByPayment(ctx, result.Payment.ID)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	replay, err := env.createPayment.Execute(ctx, "unknown-callback-key", validRequest())
+	require.NoError(t, err)
+	assert.True(t, replay.Replayed)
+	assert.Equal(t, domain.PaymentStatusSucceeded, replay.Payment.Status)
+}
+
+func TestUnknownPayment_NotChargedAfterGrace(t *testing.T) {
+	env := setupUnknown(t, hangingProcessor{})
+	ctx := context.Background()
+
+	result, err := env.createPayment.Execute(ctx, "unknown-lost-key", validRequest())
+	require.NoError(t, err)
+	require.True(t, result.OutcomeUnknown())
+
+	resolved, err := env.resolve(time.Hour, 24*time.Hour).Execute(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, resolved)
+
+	stored, err := env.paymentRepo.FindByID(ctx, result.Payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusUnknown, stored.Status)
+	require.NotNil(t, stored.NextStatusCheckAt)
+	assert.Equal(t, 1, stored.StatusChecks)
+
+	stored.NextStatusCheckAt = nil
+	require.NoError(t, env.paymentRepo.Update(ctx, stored))
+
+	resolved, err = env.resolve(0, 24*time.Hour).Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resolved)
+
+	stored, err = env.paymentRepo.FindByID(ctx, result.Payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusFailed, stored.Status)
+	assert.Equal(t, "not_charged", stored.FailReason)
+}
+
+func TestUnknownPayment_EscalatedAfterMaxAge(t *testing.T) {
+	env := setupUnknown(t, unreachableProcessor{})
+	ctx := context.Background()
+
+	result, err := env.createPayment.Execute(ctx, "unknown-stuck-key", validRequest())
+	require.NoError(t, err)
+	require.True(t, result.OutcomeUnknown())
+
+	_, err = env.resolve(0, 24*time.Hour).Execute(ctx)
+	require.NoError(t, err)
+
+	stored, err := env.paymentRepo.FindByID(ctx, result.Payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusUnknown, stored.Status, "an unreachable processor never fails the payment")
+	assert.Nil(t, stored.EscalatedAt)
+	assert.Equal(t, 1, stored.StatusChecks)
+
+	stored.NextStatusCheckAt = nil
+	require.NoError(t, env.paymentRepo.Update(ctx, stored))
+
+	resolved, err := env.resolve(0, 0).Execute(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, resolved)
+
+	stored, err = env.paymentRepo.FindByID(ctx, result.Payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusUnknown, stored.Status)
+	require.NotNil(t, stored.EscalatedAt)
+	assert.Equal(t, 1, stored.StatusChecks)
+
+	due, err := env.paymentRepo.FindUnknownDue(ctx, time.Now(), 10)
+	require.NoError(t, err)
+	assert.Empty(t, due, "escalated payments are no longer polled")
+}
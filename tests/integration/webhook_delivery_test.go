@@ -0,0 +1,246 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/application/use_cases"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain"
+	apperrors "github.com/mirola777/Yuno-Idempotency-Challenge/internal/domain/errors"
+	gormdb "github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/gorm/repositories"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/processor"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/infrastructure/webhook"
+	"github.com/mirola777/Yuno-Idempotency-Challenge/internal/utils/signature"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type webhookEnv struct {
+	createPayment    *use_cases.CreatePaymentUseCase
+	registerEndpoint *use_cases.RegisterWebhookEndpointUseCase
+	dispatch         *use_cases.DispatchWebhooksUseCase
+	newDispatch      func() *use_cases.DispatchWebhooksUseCase
+	redeliver        *use_cases.RedeliverEventUseCase
+	listDeliveries   *use_cases.ListWebhookDeliveriesUseCase
+	outboxRepo       domain.OutboxRepository
+}
+
+func setupWebhooks(t *testing.T, maxAttempts int) *webhookEnv {
+	db, err := gormdb.NewTestConnection()
+	require.NoError(t, err)
+
+	txManager := gormdb.NewTransactionManager(db)
+	idempotencyRepo := repositories.NewIdempotencyRepo(db)
+	paymentRepo := repositories.NewPaymentRepo(db)
+	outboxRepo := repositories.NewOutboxRepo(db)
+	endpointRepo := repositories.NewWebhookEndpointRepo(db)
+	deliveryRepo := repositories.NewWebhookDeliveryRepo(db)
+
+	newDispatch := func() *use_cases.DispatchWebhooksUseCase {
+		return use_cases.NewDispatchWebhooksUseCase(
+			txManager, outboxRepo, endpointRepo, deliveryRepo,
+			webhook.NewHTTPSender(time.Second),
+			maxAttempts, 0, 0, 10,
+		)
+	}
+
+	return &webhookEnv{
+		createPayment: use_cases.NewCreatePaymentUseCase(
+			txManager, idempotencyRepo, paymentRepo, processor.NewSimulator(), 24*time.Hour,
+			use_cases.WithOutbox(outboxRepo),
+		),
+		registerEndpoint: use_cases.NewRegisterWebhookEndpointUseCase(endpointRepo),
+		dispatch:         newDispatch(),
+		newDispatch:      newDispatch,
+		redeliver:        use_cases.NewRedeliverEventUseCase(txManager, outboxRepo, deliveryRepo),
+		listDeliveries:   use_cases.NewListWebhookDeliveriesUseCase(deliveryRepo),
+		outboxRepo:       outboxRepo,
+	}
+}
+
+type webhookReceiver struct {
+	mu       sync.Mutex
+	status   int
+	delay    time.Duration
+	secret   string
+	received []domain.PaymentEvent
+	errs     []error
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	time.Sleep(r.delay)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	body, _ := io.ReadAll(req.Body)
+	r.errs = append(r.errs, signature.Verify(
+		r.secret,
+		req.Header.Get(webhook.HeaderWebhookSignature),
+		req.Header.Get(webhook.HeaderWebhookTimestamp),
+		body,
+		5*time.Minute,
+		time.Now(),
+	))
+
+	var event domain.PaymentEvent
+	_ = json.Unmarshal(body, &event)
+	r.received = append(r.received, event)
+	w.WriteHeader(r.status)
+}
+
+func TestWebhooks_PaymentEventDeliveredWithSignature(t *testing.T) {
+	env := setupWebhooks(t, 3)
+	ctx := context.Background()
+
+	receiver := &webhookReceiver{status: http.StatusOK}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	endpoint, err := env.registerEndpoint.Execute(ctx, domain.WebhookEndpointRequest{URL: server.URL})
+	require.NoError(t, err)
+	receiver.secret = endpoint.Secret
+
+	created, err := env.createPayment.Execute(ctx, "webhook-key-001", validRequest())
+	require.NoError(t, err)
+
+	delivered, err := env.dispatch.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+
+	require.Len(t, receiver.received, 1)
+	assert.NoError(t, receiver.errs[0])
+	assert.Equal(t, domain.EventPaymentSucceeded, receiver.received[0].Type)
+	assert.Equal(t, created.Payment.ID, receiver.received[0].Data.ID)
+
+	delivered, err = env.dispatch.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+}
+
+func TestWebhooks_ConcurrentDispatchersDeliverOnce(t *testing.T) {
+	env := setupWebhooks(t, 3)
+	ctx := context.Background()
+
+	receiver := &webhookReceiver{status: http.StatusOK, delay: 20 * time.Millisecond}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	endpoint, err := env.registerEndpoint.Execute(ctx, domain.WebhookEndpointRequest{URL: server.URL})
+	require.NoError(t, err)
+	receiver.secret = endpoint.Secret
+
+	for i := 0; i < 5; i++ {
+		req := validRequest()
+		req.RideID = fmt.Sprintf("ride-webhook-%d", i)
+		_, err := env.createPayment.Execute(ctx, fmt.Sprintf("webhook-concurrent-%d", i), req)
+		require.NoError(t, err)
+	}
+
+	var wg sync.WaitGroup
+	for _, dispatcher := range []*use_cases.DispatchWebhooksUseCase{env.dispatch, env.newDispatch()} {
+		wg.Add(1)
+		go func(dispatcher *use_cases.DispatchWebhooksUseCase) {
+			defer wg.Done()
+			for i := 0; i < 3; i++ {
+				_, err := dispatcher.Execute(ctx)
+				assert.NoError(t, err)
+			}
+		}(dispatcher)
+	}
+	wg.Wait()
+
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	require.Len(t, receiver.received, 5)
+	seen := make(map[string]bool)
+	for _, event := range receiver.received {
+		assert.False(t, seen[event.ID], "event %s delivered twice", event.ID)
+		seen[event.ID] = true
+	}
+
+	delivered, err := env.listDeliveries.Execute(ctx, domain.WebhookDeliveryStatusDelivered)
+	require.NoError(t, err)
+	assert.Len(t, delivered, 5)
+}
+
+func TestWebhooks_ReplayDoesNotEmitEvent(t *testing.T) {
+	env := setupWebhooks(t, 3)
+	ctx := context.Background()
+
+	_, err := env.createPayment.Execute(ctx, "webhook-replay-key", validRequest())
+	require.NoError(t, err)
+	_, err = env.createPayment.Execute(ctx, "webhook-replay-key", validRequest())
+	require.NoError(t, err)
+
+	events, err := env.outboxRepo.FindPending(ctx, 10)
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+}
+
+func TestWebhooks_FailingEndpointDeadLettersAndRedelivers(t *testing.T) {
+	env := setupWebhooks(t, 2)
+	ctx := context.Background()
+
+	receiver := &webhookReceiver{status: http.StatusInternalServerError}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	endpoint, err := env.registerEndpoint.Execute(ctx, domain.WebhookEndpointRequest{URL: server.URL})
+	require.NoError(t, err)
+	receiver.secret = endpoint.Secret
+
+	_, err = env.createPayment.Execute(ctx, "webhook-dead-key", validRequest())
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = env.dispatch.Execute(ctx)
+		require.NoError(t, err)
+	}
+	assert.Len(t, receiver.received, 2)
+
+	dead, err := env.listDeliveries.Execute(ctx, domain.WebhookDeliveryStatusDead)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, 2, dead[0].Attempts)
+	assert.Equal(t, http.StatusInternalServerError, dead[0].LastStatusCode)
+
+	receiver.status = http.StatusOK
+	redelivered, err := env.redeliver.Execute(ctx, dead[0].EventID)
+	require.NoError(t, err)
+	require.Len(t, redelivered, 1)
+	assert.Equal(t, domain.WebhookDeliveryStatusPending, redelivered[0].Status)
+
+	delivered, err := env.dispatch.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+}
+
+func TestWebhooks_RedeliverUnknownEvent(t *testing.T) {
+	env := setupWebhooks(t, 3)
+
+	_, err := env.redeliver.Execute(context.Background(), "missing-event")
+	require.Error(t, err)
+
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "EVENT_NOT_FOUND", appErr.Code)
+}
+
+func TestWebhooks_RegisterRejectsInvalidURL(t *testing.T) {
+	env := setupWebhooks(t, 3)
+
+	_, err := env.registerEndpoint.Execute(context.Background(), domain.WebhookEndpointRequest{URL: "not a url"})
+	require.Error(t, err)
+
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "INVALID_WEBHOOK_ENDPOINT", appErr.Code)
+}